@@ -27,6 +27,9 @@ func (a *App) GetExportsPage(
 	collection := exports.GetExportsCollection(payload)
 	ret, err := getCollectionPage[*exports.ExportsPage](collection, payload, first, pageSize, sort, filter)
 	// EXISTING_CODE
+	if err == nil {
+		collection.AddFiatValues(ret)
+	}
 	// EXISTING_CODE
 	return ret, err
 }
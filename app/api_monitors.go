@@ -35,6 +35,7 @@ func (a *App) GetMonitorsPage(
 				ret.Monitors[i].Name = namePtr.Name
 			}
 		}
+		collection.AddRefreshStatus(ret, payload.ActiveChain)
 	}
 	// EXISTING_CODE
 	return ret, err
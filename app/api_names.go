@@ -66,4 +66,41 @@ func (a *App) GetNamesBuckets(payload *types.Payload) (*types.Buckets, error) {
 }
 
 // EXISTING_CODE
+// PreviewNamesImport parses a csv, tsv or json list of names and reports what importing
+// it would create, update or skip, without changing anything
+func (a *App) PreviewNamesImport(data string, format string) (*names.BulkPreview, error) {
+	items, err := names.ParseBulkNames([]byte(data), format)
+	if err != nil {
+		return nil, err
+	}
+	return names.PreviewBulkNames(items), nil
+}
+
+// ApplyNamesImport imports a list of names as one batch. The preview is recomputed so the
+// batch reflects the names as they are now; the returned batch's ID rolls it back.
+func (a *App) ApplyNamesImport(payload *types.Payload, data string, format string) (*names.BulkBatch, error) {
+	preview, err := a.PreviewNamesImport(data, format)
+	if err != nil {
+		return nil, err
+	}
+	collection := names.GetNamesCollection(payload)
+	return collection.ApplyBulkNames(preview)
+}
+
+// RollbackNamesImport restores the names a batch changed
+func (a *App) RollbackNamesImport(payload *types.Payload, batchID string) error {
+	collection := names.GetNamesCollection(payload)
+	return collection.RollbackBulkNames(batchID)
+}
+
+// AutonameAddresses queues the addresses to be autonamed on the payload's chain and
+// returns the job's ID at once. Progress arrives as names:autoname events.
+func (a *App) AutonameAddresses(payload *types.Payload, addresses []string) (string, error) {
+	job, err := names.AutonameAddresses(payload.ActiveChain, addresses)
+	if err != nil {
+		return "", err
+	}
+	return job.ID, nil
+}
+
 // EXISTING_CODE
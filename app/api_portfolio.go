@@ -0,0 +1,59 @@
+// Copyright 2016, 2026 The Authors. All rights reserved.
+// Use of this source code is governed by a license that can
+// be found in the LICENSE file.
+/*
+ * Parts of this file were auto generated. Edit only those parts of
+ * the code inside of 'EXISTING_CODE' tags.
+ */
+
+package app
+
+import (
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types/portfolio"
+
+	//
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+	// EXISTING_CODE
+	// EXISTING_CODE
+)
+
+func (a *App) GetPortfolioPage(
+	payload *types.Payload,
+	first, pageSize int,
+	sort sdk.SortSpec,
+	filter string,
+) (*portfolio.PortfolioPage, error) {
+	collection := portfolio.GetPortfolioCollection(payload)
+	ret, err := getCollectionPage[*portfolio.PortfolioPage](collection, payload, first, pageSize, sort, filter)
+	// EXISTING_CODE
+	// EXISTING_CODE
+	return ret, err
+}
+
+func (a *App) GetPortfolioSummary(payload *types.Payload) types.Summary {
+	collection := portfolio.GetPortfolioCollection(payload)
+	return collection.GetSummary(payload)
+}
+
+func (a *App) ReloadPortfolio(payload *types.Payload) error {
+	collection := portfolio.GetPortfolioCollection(payload)
+	collection.Reset(payload)
+	collection.FetchByFacet(payload)
+	return nil
+}
+
+// GetPortfolioConfig returns the view configuration for portfolio
+func (a *App) GetPortfolioConfig(payload types.Payload) (*types.ViewConfig, error) {
+	collection := portfolio.GetPortfolioCollection(&payload)
+	return collection.GetConfig()
+}
+
+// GetPortfolioBuckets returns bucket visualization data for portfolio
+func (a *App) GetPortfolioBuckets(payload *types.Payload) (*types.Buckets, error) {
+	collection := portfolio.GetPortfolioCollection(payload)
+	return collection.GetBuckets(payload)
+}
+
+// EXISTING_CODE
+// EXISTING_CODE
@@ -9,12 +9,24 @@
 package app
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
+	"strconv"
 
 	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/config"
 	"github.com/TrueBlocks/trueblocks-explorer/pkg/logging"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/msgs"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/rpcpool"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/txbuilder"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/typeddata"
 	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types/abis"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types/names"
 	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	wailsRuntime "github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
 type PrepareTransactionRequest struct {
@@ -23,6 +35,8 @@ type PrepareTransactionRequest struct {
 	From     string        `json:"from"`
 	To       string        `json:"to"`
 	Value    string        `json:"value"`
+	// FeeModel is "legacy" or "eip1559"; empty chooses by whether the chain has a base fee
+	FeeModel txbuilder.FeeModel `json:"feeModel,omitempty"`
 }
 
 type PrepareTransactionResult struct {
@@ -31,6 +45,13 @@ type PrepareTransactionResult struct {
 	GasEstimate     string `json:"gasEstimate"`
 	GasPrice        string `json:"gasPrice"`
 	Error           string `json:"error,omitempty"`
+	// Unsigned is the transaction ready to sign offline. It is missing, with the reason in
+	// UnsignedError, if the nonce or fees could not be read.
+	Unsigned      *txbuilder.UnsignedTx `json:"unsigned,omitempty"`
+	UnsignedError string                `json:"unsignedError,omitempty"`
+	// Simulation is the outcome of calling the transaction at the latest block. A transaction
+	// that would revert is not prepared, and Error says why.
+	Simulation *txbuilder.Simulation `json:"simulation,omitempty"`
 }
 
 func (a *App) PrepareTransaction(payload *types.Payload, req PrepareTransactionRequest) (*PrepareTransactionResult, error) {
@@ -87,7 +108,7 @@ func (a *App) PrepareTransaction(payload *types.Payload, req PrepareTransactionR
 	transactionData := "0x" + fmt.Sprintf("%x", packed)
 	result.TransactionData = transactionData
 
-	// Step 3: Estimate gas
+	// Step 3: Simulate the call as the sender
 	fromAddr := base.HexToAddress(req.From)
 	toAddr := base.HexToAddress(req.To)
 
@@ -99,6 +120,17 @@ func (a *App) PrepareTransaction(payload *types.Payload, req PrepareTransactionR
 		valueWei = &wei
 	}
 
+	if !fromAddr.IsZero() {
+		sim := simulateTransaction(chain, fromAddr, toAddr, valueWei, packed, &req.Function)
+		result.Simulation = sim
+		if sim.Reverted {
+			logging.LogBEError(fmt.Sprintf("Simulated call reverted: %s", sim.RevertReason))
+			result.Error = fmt.Sprintf("The transaction would revert: %s", sim.RevertReason)
+			return result, nil
+		}
+	}
+
+	// Step 4: Estimate gas
 	estimatedGas, gasPrice, err := sdk.EstimateGasAndPrice(chain, fromAddr, toAddr, transactionData, valueWei)
 	if err != nil {
 		logging.LogBEError(fmt.Sprintf("SDK.EstimateGasAndPrice FAILED: %v", err))
@@ -110,5 +142,90 @@ func (a *App) PrepareTransaction(payload *types.Payload, req PrepareTransactionR
 	result.GasPrice = fmt.Sprintf("0x%x", gasPrice)
 	result.Success = true
 
+	// Step 5: Build the unsigned transaction
+	unsigned, err := buildUnsigned(chain, fromAddr, toAddr, valueWei, packed, uint64(estimatedGas), req.FeeModel)
+	if err != nil {
+		logging.LogBEError(fmt.Sprintf("Failed to build unsigned transaction: %v", err))
+		result.UnsignedError = err.Error()
+	}
+	result.Unsigned = unsigned
+
 	return result, nil
 }
+
+// simulateTransaction runs the transaction with eth_call at the latest block. If it reverts,
+// the reason is decoded with the custom errors in the ABI of to.
+func simulateTransaction(chain string, from, to base.Address, value *base.Wei, data []byte, fn *sdk.Function) *txbuilder.Simulation {
+	getErrors := func() []ethabi.Error {
+		errs, err := abis.GetErrors(chain, to)
+		if err != nil {
+			logging.LogBEWarning(fmt.Sprintf("Could not read the errors of %s: %v", to.Hex(), err))
+		}
+		return errs
+	}
+	return txbuilder.Simulate(rpcpool.Provider(chain, config.GetChain(chain).GetRpcProvider()), txbuilder.Request{
+		From:  from,
+		To:    &to,
+		Value: value.BigInt(),
+		Data:  data,
+	}, fn, getErrors)
+}
+
+// buildUnsigned reads the nonce of from and the chain's fees from its RPC provider and
+// returns the transaction unsigned
+func buildUnsigned(chain string, from, to base.Address, value *base.Wei, data []byte, gas uint64, feeModel txbuilder.FeeModel) (*txbuilder.UnsignedTx, error) {
+	if from.IsZero() {
+		return nil, fmt.Errorf("connect a wallet to build a transaction to sign")
+	}
+	ch := config.GetChain(chain)
+	chainId, _ := strconv.ParseUint(ch.ChainId, 10, 64)
+	return txbuilder.Build(rpcpool.Provider(chain, ch.GetRpcProvider()), txbuilder.Request{
+		ChainId:  chainId,
+		From:     from,
+		To:       &to,
+		Value:    value.BigInt(),
+		Data:     data,
+		Gas:      gas,
+		FeeModel: feeModel,
+	})
+}
+
+// ExportUnsignedTransaction asks where to save tx and writes it there as JSON, for signing on
+// another device. It returns the path, which is empty if the user cancelled.
+func (a *App) ExportUnsignedTransaction(tx txbuilder.UnsignedTx) (string, error) {
+	path, err := wailsRuntime.SaveFileDialog(a.ctx, wailsRuntime.SaveDialogOptions{
+		Title:           "Export Unsigned Transaction",
+		DefaultFilename: fmt.Sprintf("unsigned-tx-%d-%d.json", tx.ChainId, tx.Nonce),
+		Filters: []wailsRuntime.FileFilter{
+			{
+				DisplayName: "JSON Files (*.json)",
+				Pattern:     "*.json",
+			},
+		},
+	})
+	if err != nil || path == "" {
+		return "", err
+	}
+
+	bytes, err := json.MarshalIndent(tx, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, bytes, 0644); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	msgs.EmitStatus("unsigned transaction exported to " + path)
+	return path, nil
+}
+
+// InspectTypedData checks an EIP-712 payload, as a dapp passes it to eth_signTypedData_v4,
+// against its declared types. It returns the domain separator, struct hash and signing hash,
+// and the domain and message field by field with known addresses named.
+func (a *App) InspectTypedData(payload *types.Payload, data string) (*typeddata.Inspection, error) {
+	chain := payload.ActiveChain
+	if chain == "" {
+		chain = "mainnet"
+	}
+	chainId, _ := strconv.ParseUint(config.GetChain(chain).ChainId, 10, 64)
+	return typeddata.Inspect(data, chainId, names.NameAddress)
+}
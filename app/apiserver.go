@@ -0,0 +1,133 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/apiserver"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/msgs"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/preferences"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types/exports"
+)
+
+// apiBackend serves the app's collections through the headless API server
+type apiBackend struct {
+	app *App
+}
+
+func (b apiBackend) Views() []string {
+	return b.app.GetRegisteredViews()
+}
+
+func (b apiBackend) Collection(payload *types.Payload) types.Collection {
+	return b.app.getCollection(payload, true)
+}
+
+// Page returns the same page the frontend receives, fiat values included
+func (b apiBackend) Page(payload *types.Payload, first, pageSize int, sort sdk.SortSpec, filter string) (types.Page, error) {
+	collection := b.app.getCollection(payload, true)
+	if collection == nil {
+		return nil, fmt.Errorf("unknown collection: %s", payload.Collection)
+	}
+	page, err := collection.GetPage(payload, first, pageSize, sort, filter)
+	if err != nil {
+		return nil, err
+	}
+	if ec, ok := collection.(*exports.ExportsCollection); ok {
+		if ep, ok := page.(*exports.ExportsPage); ok {
+			ec.AddFiatValues(ep)
+		}
+	}
+	return page, nil
+}
+
+// Complete fills in what the request left out from the active project
+func (b apiBackend) Complete(payload *types.Payload) {
+	b.app.completePayload(payload)
+}
+
+// completePayload fills in the chain, address, contract and period a caller left out of
+// payload from the active project, and points the payload at the project for exports
+func (a *App) completePayload(payload *types.Payload) {
+	active := a.GetActiveProject()
+	if active == nil {
+		return
+	}
+	if payload.ActiveChain == "" {
+		payload.ActiveChain = active.GetActiveChain()
+	}
+	if payload.ActiveAddress == "" {
+		addr := active.GetActiveAddress()
+		payload.ActiveAddress = addr.Hex()
+	}
+	if payload.ActiveContract == "" {
+		payload.ActiveContract = active.GetActiveContract()
+	}
+	if payload.ActivePeriod == "" {
+		payload.ActivePeriod = active.GetActivePeriod()
+	}
+	payload.ProjectPath = active.GetPath()
+}
+
+// apiPort returns the configured API port. TB_API_PORT, if set, overrides the preference.
+func (a *App) apiPort() int {
+	if env := os.Getenv("TB_API_PORT"); env != "" {
+		if port, err := strconv.Atoi(env); err == nil {
+			return port
+		}
+	}
+	a.prefsMu.RLock()
+	defer a.prefsMu.RUnlock()
+	return a.Preferences.App.ApiPort
+}
+
+// startApiServer starts the headless API server if a port is configured
+func (a *App) startApiServer() {
+	port := a.apiPort()
+	if port <= 0 {
+		return
+	}
+	if a.apiServer == nil {
+		a.apiServer = apiserver.NewServer(apiBackend{app: a})
+	}
+	if err := a.apiServer.Start(port); err != nil {
+		msgs.EmitError("Failed to start api server", err)
+	}
+}
+
+// GetApiServerURL returns the URL the headless API is served under, or an empty string if
+// the server is off
+func (a *App) GetApiServerURL() string {
+	if a.apiServer == nil {
+		return ""
+	}
+	return a.apiServer.GetBaseURL()
+}
+
+// SetApiPort saves the port the headless API server listens on and restarts it there. Zero
+// turns the server off.
+func (a *App) SetApiPort(port int) error {
+	if port < 0 || port > 65535 {
+		return fmt.Errorf("invalid port %d", port)
+	}
+
+	a.prefsMu.Lock()
+	a.Preferences.App.ApiPort = port
+	err := preferences.SetAppPreferences(&a.Preferences.App)
+	a.prefsMu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if a.apiServer != nil {
+		if err := a.apiServer.Stop(); err != nil {
+			return err
+		}
+	}
+	a.startApiServer()
+	return nil
+}
@@ -10,16 +10,26 @@ import (
 	"sync"
 	"time"
 
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/apiserver"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/fiat"
 	"github.com/TrueBlocks/trueblocks-explorer/pkg/fileserver"
 	"github.com/TrueBlocks/trueblocks-explorer/pkg/filewriter"
 	"github.com/TrueBlocks/trueblocks-explorer/pkg/manager"
 	"github.com/TrueBlocks/trueblocks-explorer/pkg/msgs"
 	"github.com/TrueBlocks/trueblocks-explorer/pkg/preferences"
 	"github.com/TrueBlocks/trueblocks-explorer/pkg/project"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/refresh"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/rpcpool"
 	"github.com/TrueBlocks/trueblocks-explorer/pkg/skin"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/sorting"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/store"
 	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types/comparitoor"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types/contracts"
 	"github.com/TrueBlocks/trueblocks-explorer/pkg/types/exports"
 	"github.com/TrueBlocks/trueblocks-explorer/pkg/types/names"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types/portfolio"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/validation"
 
 	"github.com/joho/godotenv"
 	"github.com/wailsapp/wails/v2/pkg/menu"
@@ -48,12 +58,18 @@ type App struct {
 	collections []types.Collection
 	meta        *coreTypes.MetaData
 	fileServer  *fileserver.FileServer
+	apiServer   *apiserver.Server
+	refresher   *refresh.Scheduler
+	rpcPool     *rpcpool.Pool
+	rpcCaps     map[string]*validation.RpcCapabilities
+	rpcCapsMu   sync.Mutex
 	prefsMu     sync.RWMutex
 	ctx         context.Context
 	apiKeys     map[string]string
 	ensMap      map[string]base.Address
 	Dalle       *dalle.Context
 	skinManager *skin.SkinManager
+	fiatConv    *fiat.Converter
 }
 
 func NewApp(assets embed.FS) (*App, *menu.Menu) {
@@ -109,22 +125,52 @@ func (a *App) Startup(ctx context.Context) {
 
 	msgs.InitializeContext(ctx)
 
+	if !a.initialize() {
+		return
+	}
+
+	// Restore previously opened projects from last session
+	a.restoreLastProjects()
+
+	// Initialize file server directly on the dalle OutputDir
+	if out := storage.OutputDir(); out != "" {
+		if _, err := os.Stat(out); err == nil {
+			a.fileServer = fileserver.NewFileServer(out)
+			if err := a.fileServer.Start(); err != nil {
+				msgs.EmitError("Failed to start image file server", err)
+			}
+		}
+	}
+
+	// The headless API server only runs if a port is configured
+	a.startApiServer()
+
+	// Scheduled monitors are freshened in the background while the app is open
+	a.startMonitorRefresh()
+
+	// Each chain's RPC providers are health checked and the best one handed to the SDK
+	a.startRpcPool()
+}
+
+// initialize loads the preferences and configures the services the collections depend on.
+// It needs no window, so the headless export runs it too. It reports whether it succeeded.
+func (a *App) initialize() bool {
 	org, err := preferences.GetOrgPreferences()
 	if err != nil {
 		msgs.EmitError("Loading org preferences failed", err)
-		return
+		return false
 	}
 
 	user, err := preferences.GetUserPreferences()
 	if err != nil {
 		msgs.EmitError("Loading user preferences failed", err)
-		return
+		return false
 	}
 
 	appPrefs, err := preferences.GetAppPreferences()
 	if err != nil {
 		msgs.EmitError("Loading app preferences failed", err)
-		return
+		return false
 	}
 
 	a.Preferences.Org = org
@@ -134,18 +180,44 @@ func (a *App) Startup(ctx context.Context) {
 	// Initialize global file writer to eliminate race conditions (auto-starts)
 	_ = filewriter.GetGlobalWriter()
 
-	// Restore previously opened projects from last session
-	a.restoreLastProjects()
+	// Stores persist their contents here so views can show data immediately on restart
+	_, appFolder := preferences.GetConfigFolders()
+	store.SetSnapshotDir(filepath.Join(appFolder, "snapshots"))
 
-	// Initialize file server directly on the dalle OutputDir
-	if out := storage.OutputDir(); out != "" {
-		if _, err := os.Stat(out); err == nil {
-			a.fileServer = fileserver.NewFileServer(out)
-			if err := a.fileServer.Start(); err != nil {
-				msgs.EmitError("Failed to start image file server", err)
-			}
+	// The portfolio view combines every address in whichever project is active
+	portfolio.SetAddressSource(func() []base.Address {
+		if active := a.GetActiveProject(); active != nil {
+			return active.GetAddresses()
+		}
+		return nil
+	})
+
+	// The contracts dashboard reads every contract in whichever project is active
+	contracts.SetContractsSource(func() []string {
+		if active := a.GetActiveProject(); active != nil {
+			return active.GetContracts()
+		}
+		return nil
+	})
+
+	// The exports gains facet matches lots using the method chosen in its panel
+	exports.SetCostBasisSource(func() string {
+		return a.GetExportsMetric(string(exports.ExportsGains))
+	})
+
+	// Balances, assets and asset charts are valued in the currency chosen in preferences
+	a.loadFiatConverter()
+	exports.SetFiatSource(a.fiatConverter)
+
+	// Third-party sources in the Comparitoor view read from fixtures configured in preferences
+	for name, source := range a.Preferences.App.ComparitoorSources {
+		provider := comparitoor.NewFixtureProvider(name, source)
+		if err := comparitoor.SetProvider(types.DataFacet(name), provider); err != nil {
+			msgs.EmitError("Ignoring comparitoor source", err)
 		}
 	}
+
+	return true
 }
 
 // DomReady configures the window and starts monitoring after DOM is ready
@@ -177,6 +249,20 @@ func (a *App) BeforeClose(ctx context.Context) bool {
 		}
 	}
 
+	if a.apiServer != nil {
+		if err := a.apiServer.Stop(); err != nil {
+			log.Printf("Error shutting down api server: %v", err)
+		}
+	}
+
+	if a.refresher != nil {
+		a.refresher.Stop()
+	}
+
+	if a.rpcPool != nil {
+		a.rpcPool.Stop()
+	}
+
 	// Shutdown global file writer and flush any pending writes
 	writer := filewriter.GetGlobalWriter()
 	_ = writer.Shutdown()
@@ -299,6 +385,10 @@ func getCollectionPage[T any](
 	var zero T
 
 	dataFacet := payload.DataFacet
+	if sorting.IsEmptySort(sort) && payload.Sort != nil {
+		sort = *payload.Sort
+	}
+	sort = sorting.Normalize(sort)
 	page, err := collection.GetPage(payload, first, pageSize, sort, filter)
 	if err != nil {
 		return zero, err
@@ -23,6 +23,7 @@ import (
 	"github.com/TrueBlocks/trueblocks-explorer/pkg/types/exports"
 	"github.com/TrueBlocks/trueblocks-explorer/pkg/types/monitors"
 	"github.com/TrueBlocks/trueblocks-explorer/pkg/types/names"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types/portfolio"
 	"github.com/TrueBlocks/trueblocks-explorer/pkg/types/projects"
 	"github.com/TrueBlocks/trueblocks-explorer/pkg/types/status"
 )
@@ -42,6 +43,8 @@ func (a *App) Reload(payload *types.Payload) (err error) {
 		err = a.ReloadProjects(payload)
 	case "exports":
 		err = a.ReloadExports(payload)
+	case "portfolio":
+		err = a.ReloadPortfolio(payload)
 	case "monitors":
 		err = a.ReloadMonitors(payload)
 	case "abis":
@@ -70,6 +73,7 @@ func (a *App) GetRegisteredViews() []string {
 	return []string{
 		"projects",
 		"exports",
+		"portfolio",
 		"monitors",
 		"abis",
 		"names",
@@ -87,6 +91,8 @@ func (a *App) getCollection(payload *types.Payload, missingOk bool) types.Collec
 		return projects.GetProjectsCollection(payload, a.Projects)
 	case "exports":
 		return exports.GetExportsCollection(payload)
+	case "portfolio":
+		return portfolio.GetPortfolioCollection(payload)
 	case "monitors":
 		return monitors.GetMonitorsCollection(payload)
 	case "abis":
@@ -0,0 +1,28 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types/chunks"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/config"
+)
+
+// ProbeBlooms asks the local bloom filters of the active chain which chunks between
+// firstBlock and lastBlock (zero for the end of the index) may hold address. If confirm is
+// set, each hit is checked against its index chunk to count the false positives. The result
+// also appears as two series in the blooms facet's buckets.
+func (a *App) ProbeBlooms(payload *types.Payload, address string, firstBlock, lastBlock uint64, confirm bool) (*chunks.BloomProbeResult, error) {
+	if !base.IsValidAddress(address) {
+		return nil, fmt.Errorf("cannot probe %q: not an address", address)
+	}
+	chain := payload.ActiveChain
+	if chain == "" {
+		return nil, fmt.Errorf("no active chain to probe")
+	}
+
+	collection := chunks.GetChunksCollection(payload)
+	return collection.ProbeBlooms(chain, config.PathToIndex(chain), base.HexToAddress(address), firstBlock, lastBlock, confirm)
+}
@@ -0,0 +1,287 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/bundle"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/logging"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/msgs"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types/monitors"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types/names"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/abi"
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/config"
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/crud"
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+	"github.com/wailsapp/wails/v2/pkg/menu"
+	wailsRuntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// FileExportBundle asks where to save a bundle of the active project and writes it
+func (a *App) FileExportBundle(_ *menu.CallbackData) {
+	path, err := a.ExportProjectBundle("")
+	if err != nil {
+		msgs.EmitError("bundle export failed", err)
+		return
+	}
+	if path == "" {
+		msgs.EmitStatus("bundle export canceled")
+		return
+	}
+	msgs.EmitStatus(fmt.Sprintf("project bundle saved to %s", path))
+}
+
+// FileImportBundle asks for a bundle and imports it, keeping local data on conflicts
+func (a *App) FileImportBundle(_ *menu.CallbackData) {
+	report, err := a.ImportProjectBundle("", false)
+	if err != nil {
+		msgs.EmitError("bundle import failed", err)
+		return
+	}
+	if report == nil {
+		msgs.EmitStatus("bundle import canceled")
+	}
+}
+
+// ExportProjectBundle writes the active project, the custom names of its addresses, the
+// ABIs of its contracts, its monitors and its exports to a zip archive at path. With an
+// empty path the user is asked where to save it; the returned path is empty if they cancel.
+func (a *App) ExportProjectBundle(path string) (string, error) {
+	active := a.GetActiveProject()
+	if active == nil {
+		return "", errors.New("no active project")
+	}
+
+	if path == "" {
+		selected, err := wailsRuntime.SaveFileDialog(a.ctx, wailsRuntime.SaveDialogOptions{
+			Title:           "Export Project Bundle",
+			DefaultFilename: active.GetName() + bundle.Extension,
+			Filters: []wailsRuntime.FileFilter{
+				{
+					DisplayName: "Project Bundles (*" + bundle.Extension + ")",
+					Pattern:     "*" + bundle.Extension,
+				},
+			},
+		})
+		if err != nil || selected == "" {
+			return "", err
+		}
+		path = selected
+	}
+
+	data, err := json.MarshalIndent(active, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrSerializeFailed, err)
+	}
+
+	b := &bundle.Bundle{
+		Manifest: bundle.Manifest{Project: projectFileName(active.GetPath(), active.GetName())},
+		Project:  data,
+		Names:    []sdk.Name{},
+		Abis:     []bundle.Abi{},
+		Monitors: []bundle.Monitor{},
+		Exports:  []bundle.File{},
+	}
+
+	for _, addr := range active.GetAddresses() {
+		if name, ok := customName(addr); ok {
+			b.Names = append(b.Names, *name)
+		}
+	}
+
+	for _, chain := range active.GetChains() {
+		for _, contract := range active.GetContracts() {
+			addr := base.HexToAddress(contract)
+			if name, ok := customName(addr); ok && !containsName(b.Names, addr) {
+				b.Names = append(b.Names, *name)
+			}
+			if data, err := os.ReadFile(abi.PathToAbisCache(chain, addr.Hex())); err == nil {
+				b.Abis = append(b.Abis, bundle.Abi{Chain: chain, Address: addr, Data: data})
+			}
+		}
+
+		monitored, err := listMonitors(chain)
+		if err != nil {
+			logging.LogBEWarning(fmt.Sprintf("bundle: could not list monitors on %s: %v", chain, err))
+			continue
+		}
+		for _, addr := range active.GetAddresses() {
+			if m, ok := monitored[addr]; ok {
+				b.Monitors = append(b.Monitors, bundle.Monitor{Chain: chain, Address: addr, Name: m.Name, Deleted: m.Deleted})
+			}
+		}
+	}
+
+	if projectPath := active.GetPath(); projectPath != "" {
+		if b.Exports, err = bundle.ReadExports(types.ExportsFolder(projectPath)); err != nil {
+			return "", fmt.Errorf("reading exports: %w", err)
+		}
+	}
+
+	if err := bundle.WriteFile(path, b); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrWriteFileFailed, err)
+	}
+	logging.LogBackend(fmt.Sprintf("bundle: wrote %s (%d names, %d abis, %d monitors, %d exports)",
+		path, b.Manifest.Names, b.Manifest.Abis, b.Manifest.Monitors, b.Manifest.Exports))
+	return path, nil
+}
+
+// ImportProjectBundle merges the bundle at path into this installation and opens its
+// project, which is written beside the bundle. Conflicting names, ABIs and files keep
+// their local values unless overwrite is set; either way each conflict is reported. With
+// an empty path the user is asked for a bundle; the report is nil if they cancel.
+func (a *App) ImportProjectBundle(path string, overwrite bool) (*bundle.Report, error) {
+	if path == "" {
+		selected, err := wailsRuntime.OpenFileDialog(a.ctx, wailsRuntime.OpenDialogOptions{
+			Title: "Import Project Bundle",
+			Filters: []wailsRuntime.FileFilter{
+				{
+					DisplayName: "Project Bundles (*" + bundle.Extension + ")",
+					Pattern:     "*" + bundle.Extension,
+				},
+			},
+		})
+		if err != nil || selected == "" {
+			return nil, err
+		}
+		path = selected
+	}
+
+	b, err := bundle.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	projectPath := filepath.Join(filepath.Dir(path), projectFileName(b.Manifest.Project, fallback))
+	report, err := bundle.Import(b, newBundleStore(), bundle.Options{ProjectPath: projectPath, Overwrite: overwrite})
+	if err != nil {
+		return report, err
+	}
+	logging.LogBackend(report.String())
+
+	if err := a.OpenProjectFile(projectPath); err != nil {
+		return report, fmt.Errorf("imported, but could not open %s: %w", projectPath, err)
+	}
+	msgs.EmitStatus(fmt.Sprintf("imported %s: %d added, %d conflicts, %d failed", filepath.Base(projectPath), len(report.Added), len(report.Conflicts), len(report.Failed)))
+	return report, nil
+}
+
+// projectFileName is the base name of a project file, falling back to name.tbx
+func projectFileName(path, name string) string {
+	if path != "" {
+		return filepath.Base(path)
+	}
+	if name == "" {
+		name = "project"
+	}
+	return name + ".tbx"
+}
+
+// customName returns the custom name of addr, if it has one
+func customName(addr base.Address) (*names.Name, bool) {
+	name, ok := names.NameFromAddress(addr)
+	if !ok || name == nil || !name.IsCustom {
+		return nil, false
+	}
+	return name, true
+}
+
+func containsName(list []sdk.Name, addr base.Address) bool {
+	for _, n := range list {
+		if n.Address == addr {
+			return true
+		}
+	}
+	return false
+}
+
+// listMonitors returns the monitors on chain by address
+func listMonitors(chain string) (map[base.Address]sdk.Monitor, error) {
+	opts := sdk.MonitorsOptions{Globals: sdk.Globals{Chain: chain}}
+	list, _, err := opts.MonitorsList()
+	if err != nil {
+		return nil, err
+	}
+	ret := make(map[base.Address]sdk.Monitor, len(list))
+	for _, m := range list {
+		ret[m.Address] = m
+	}
+	return ret, nil
+}
+
+// bundleStore imports bundles into the local names database, ABI cache and monitors
+type bundleStore struct {
+	monitors map[string]map[base.Address]sdk.Monitor
+}
+
+func newBundleStore() *bundleStore {
+	return &bundleStore{monitors: make(map[string]map[base.Address]sdk.Monitor)}
+}
+
+func (s *bundleStore) LookupName(addr base.Address) (*sdk.Name, bool) {
+	return customName(addr)
+}
+
+func (s *bundleStore) SaveName(name *sdk.Name, exists bool) error {
+	op := crud.Create
+	if exists {
+		op = crud.Update
+	}
+	payload := &types.Payload{Collection: "names", DataFacet: names.NamesCustom}
+	return names.GetNamesCollection(payload).Crud(payload, op, name)
+}
+
+func (s *bundleStore) ReadAbi(chain string, addr base.Address) ([]byte, bool) {
+	if !config.IsChainConfigured(chain) {
+		return nil, false
+	}
+	data, err := os.ReadFile(abi.PathToAbisCache(chain, addr.Hex()))
+	return data, err == nil
+}
+
+func (s *bundleStore) WriteAbi(chain string, addr base.Address, data []byte) error {
+	if !config.IsChainConfigured(chain) {
+		return fmt.Errorf("chain %s is not configured", chain)
+	}
+	path := abi.PathToAbisCache(chain, addr.Hex())
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+func (s *bundleStore) HasMonitor(chain string, addr base.Address) bool {
+	if !config.IsChainConfigured(chain) {
+		return false
+	}
+	monitored, ok := s.monitors[chain]
+	if !ok {
+		var err error
+		if monitored, err = listMonitors(chain); err != nil {
+			logging.LogBEWarning(fmt.Sprintf("bundle: could not list monitors on %s: %v", chain, err))
+		}
+		s.monitors[chain] = monitored
+	}
+	m, ok := monitored[addr]
+	return ok && !m.Deleted
+}
+
+// CreateMonitor builds the monitor by listing the address's appearances, as chifra list does
+func (s *bundleStore) CreateMonitor(chain string, addr base.Address) error {
+	if !config.IsChainConfigured(chain) {
+		return fmt.Errorf("chain %s is not configured", chain)
+	}
+	if _, err := freshenMonitors(chain, []base.Address{addr}); err != nil {
+		return err
+	}
+	monitors.MarkStale(chain, "monitor created by bundle import")
+	return nil
+}
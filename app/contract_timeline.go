@@ -0,0 +1,27 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types/contracts"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+)
+
+// ReadContractTimeline reads the active contract's zero-argument view functions at each of
+// blocks or, if there are none, at the start of each period from firstBlock to lastBlock
+// (zero for the chain's head). The result also appears as a series per function in the
+// dashboard facet's buckets.
+func (a *App) ReadContractTimeline(payload *types.Payload, blocks []uint64, period types.Period, firstBlock, lastBlock uint64) (*contracts.ContractTimeline, error) {
+	if !base.IsValidAddress(payload.ActiveContract) {
+		return nil, fmt.Errorf("no active contract to read")
+	}
+	chain := payload.ActiveChain
+	if chain == "" {
+		return nil, fmt.Errorf("no active chain to read the contract on")
+	}
+
+	collection := contracts.GetContractsCollection(payload)
+	return collection.ReadTimeline(chain, base.HexToAddress(payload.ActiveContract), blocks, period, firstBlock, lastBlock)
+}
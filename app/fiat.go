@@ -0,0 +1,88 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/fiat"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/msgs"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/preferences"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types/exports"
+)
+
+// GetFiatCurrency returns the currency balances and charts are valued in
+func (a *App) GetFiatCurrency() string {
+	return a.fiatConverter().Currency()
+}
+
+// SetFiatCurrency changes the currency balances and charts are valued in. Spot prices are
+// in USD, so any other currency needs USD rows in the price file to convert them.
+func (a *App) SetFiatCurrency(currency string) error {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency != "" && len(currency) != 3 {
+		return fmt.Errorf("invalid currency code %q", currency)
+	}
+
+	a.prefsMu.Lock()
+	a.Preferences.App.FiatCurrency = currency
+	err := preferences.SetAppPreferences(&a.Preferences.App)
+	a.prefsMu.Unlock()
+	if err != nil {
+		return err
+	}
+	a.loadFiatConverter()
+	exports.RevalueCharts()
+	return nil
+}
+
+// SetPriceFile sets the CSV of prices used when a statement has no spot price. An empty
+// path removes it. The file is read before it is saved so a bad file is reported at once.
+func (a *App) SetPriceFile(path string) error {
+	path = strings.TrimSpace(path)
+	if path != "" {
+		if err := fiat.NewConverter(a.GetFiatCurrency()).LoadFile(path); err != nil {
+			return err
+		}
+	}
+
+	a.prefsMu.Lock()
+	a.Preferences.App.PriceFile = path
+	err := preferences.SetAppPreferences(&a.Preferences.App)
+	a.prefsMu.Unlock()
+	if err != nil {
+		return err
+	}
+	a.loadFiatConverter()
+	exports.RevalueCharts()
+	return nil
+}
+
+// fiatConverter returns the converter built from the current preferences
+func (a *App) fiatConverter() *fiat.Converter {
+	a.prefsMu.RLock()
+	defer a.prefsMu.RUnlock()
+	if a.fiatConv == nil {
+		return fiat.NewConverter(a.Preferences.App.FiatCurrency)
+	}
+	return a.fiatConv
+}
+
+// loadFiatConverter rebuilds the converter from preferences. A price file that cannot be
+// read is reported and ignored so spot prices still work.
+func (a *App) loadFiatConverter() {
+	a.prefsMu.RLock()
+	currency, path := a.Preferences.App.FiatCurrency, a.Preferences.App.PriceFile
+	a.prefsMu.RUnlock()
+
+	conv := fiat.NewConverter(currency)
+	if path != "" {
+		if err := conv.LoadFile(path); err != nil {
+			msgs.EmitError("Ignoring price file", err)
+			conv = fiat.NewConverter(currency)
+		}
+	}
+
+	a.prefsMu.Lock()
+	a.fiatConv = conv
+	a.prefsMu.Unlock()
+}
@@ -0,0 +1,262 @@
+package app
+
+import (
+	"embed"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/filewriter"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/msgs"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/project"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
+)
+
+// Exit codes of the headless export
+const (
+	ExitOk    = 0 // the export was written
+	ExitError = 1 // the export failed or an error was reported while it ran
+	ExitUsage = 2 // the command line was invalid
+)
+
+// exportOptions are the command line options of the headless export
+type exportOptions struct {
+	project    string
+	collection string
+	facet      string
+	format     string
+	chain      string
+	address    string
+	period     string
+	output     string
+	timeout    time.Duration
+}
+
+func parseExportOptions(args []string, stderr io.Writer) (exportOptions, error) {
+	var opts exportOptions
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: trueblocks-explorer export --project <file.tbx> --collection <name> [options]")
+		fmt.Fprintln(stderr, "\nExports one facet of a collection without opening the window.")
+		fs.PrintDefaults()
+	}
+	fs.StringVar(&opts.project, "project", "", "the .tbx project to open (required)")
+	fs.StringVar(&opts.collection, "collection", "", "the collection to export, for example exports or names (required)")
+	fs.StringVar(&opts.facet, "facet", "", "the facet to export (defaults to the collection's first facet)")
+	fs.StringVar(&opts.format, "format", "csv", "one of "+strings.Join(types.ExportFormats, ", "))
+	fs.StringVar(&opts.chain, "chain", "", "the chain (defaults to the project's active chain)")
+	fs.StringVar(&opts.address, "address", "", "the address (defaults to the project's active address)")
+	fs.StringVar(&opts.period, "period", "", "the period to summarize by (defaults to the project's active period)")
+	fs.StringVar(&opts.output, "output", "", "where to copy the export; - for stdout (defaults to the project's .Exports folder)")
+	fs.DurationVar(&opts.timeout, "timeout", 30*time.Minute, "how long to wait for the data to load")
+
+	if err := fs.Parse(args); err != nil {
+		return opts, err
+	}
+	if fs.NArg() > 0 {
+		return opts, fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+	if opts.project == "" {
+		return opts, fmt.Errorf("--project is required")
+	}
+	if opts.collection == "" {
+		return opts, fmt.Errorf("--collection is required")
+	}
+	if !types.IsExportFormat(opts.format) {
+		return opts, fmt.Errorf("unsupported format %q (want one of %s)", opts.format, strings.Join(types.ExportFormats, ", "))
+	}
+	if opts.timeout <= 0 {
+		return opts, fmt.Errorf("--timeout must be positive")
+	}
+	return opts, nil
+}
+
+// RunExport is the headless command line entry point. It loads the preferences, opens a
+// project, waits for one facet of a collection to load and exports it, returning the
+// process exit code. Errors, including those the collections report through msgs, go to
+// stderr.
+func RunExport(assets embed.FS, args []string, stdout, stderr io.Writer) int {
+	opts, err := parseExportOptions(args, stderr)
+	if err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(stderr, "Error:", err)
+		}
+		return ExitUsage
+	}
+
+	a, _ := NewApp(assets)
+	errs := newErrorLog(stderr)
+	defer errs.close()
+
+	if !a.initialize() {
+		return ExitError
+	}
+	defer func() {
+		_ = filewriter.GetGlobalWriter().Shutdown()
+	}()
+
+	return a.export(opts, errs, stdout, stderr)
+}
+
+// export does the work of RunExport once the app is initialized
+func (a *App) export(opts exportOptions, errs *errorLog, stdout, stderr io.Writer) int {
+	fail := func(err error) int {
+		fmt.Fprintln(stderr, "Error:", err)
+		return ExitError
+	}
+
+	if !slices.Contains(a.GetRegisteredViews(), opts.collection) {
+		return fail(fmt.Errorf("unknown collection %q (want one of %s)", opts.collection, strings.Join(a.GetRegisteredViews(), ", ")))
+	}
+	if _, err := os.Stat(opts.project); err != nil {
+		return fail(fmt.Errorf("cannot open project: %w", err))
+	}
+	if _, err := a.Projects.Open(opts.project, project.Load); err != nil {
+		return fail(fmt.Errorf("cannot open project %s: %w", opts.project, err))
+	}
+
+	payload := &types.Payload{
+		Collection:    opts.collection,
+		ActiveChain:   opts.chain,
+		ActiveAddress: opts.address,
+		ActivePeriod:  types.Period(opts.period),
+		Format:        opts.format,
+	}
+	a.completePayload(payload)
+
+	collection := a.getCollection(payload, false)
+	if collection == nil {
+		return fail(fmt.Errorf("unknown collection %q", opts.collection))
+	}
+	cfg, err := collection.GetConfig()
+	if err != nil {
+		return fail(err)
+	}
+	facet := opts.facet
+	if facet == "" && len(cfg.FacetOrder) > 0 {
+		facet = cfg.FacetOrder[0]
+	}
+	if _, ok := cfg.Facets[facet]; !ok {
+		return fail(fmt.Errorf("unknown facet %q for %s (want one of %s)", facet, opts.collection, strings.Join(cfg.FacetOrder, ", ")))
+	}
+	payload.DataFacet = types.DataFacet(facet)
+
+	if err := waitForLoaded(collection, payload, errs, opts.timeout); err != nil {
+		return fail(err)
+	}
+
+	path, err := collection.ExportData(payload)
+	if err != nil {
+		return fail(fmt.Errorf("failed to export data: %w", err))
+	}
+	if errs.count() > 0 {
+		return ExitError
+	}
+
+	switch opts.output {
+	case "":
+		fmt.Fprintln(stdout, path)
+	case "-":
+		if err := copyFile(stdout, path); err != nil {
+			return fail(err)
+		}
+	default:
+		out, err := os.Create(opts.output)
+		if err != nil {
+			return fail(err)
+		}
+		err = copyFile(out, path)
+		if cerr := out.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return fail(err)
+		}
+	}
+	return ExitOk
+}
+
+// waitForLoaded asks for the facet and polls it until its store reports StateLoaded. It
+// gives up early if an error is emitted while the store loads.
+func waitForLoaded(collection types.Collection, payload *types.Payload, errs *errorLog, timeout time.Duration) error {
+	if collection.NeedsUpdate(payload) {
+		collection.FetchByFacet(payload)
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		// GetPage syncs the facet with its store, which is what ExportData reads
+		page, err := collection.GetPage(payload, 0, 1, sdk.SortSpec{}, "")
+		if err != nil {
+			return err
+		}
+		if page != nil && page.GetState() == types.StateLoaded {
+			return nil
+		}
+		if errs.count() > 0 {
+			return fmt.Errorf("%s/%s did not load", payload.Collection, payload.DataFacet)
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out after %s waiting for %s/%s to load", timeout, payload.Collection, payload.DataFacet)
+		}
+		time.Sleep(250 * time.Millisecond)
+	}
+}
+
+func copyFile(w io.Writer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(w, f)
+	return err
+}
+
+// errorLog writes every error emitted through msgs to stderr and counts them so the
+// export can fail with them
+type errorLog struct {
+	stderr io.Writer
+	n      int
+	untap  func()
+	mutex  sync.Mutex
+}
+
+func newErrorLog(stderr io.Writer) *errorLog {
+	l := &errorLog{stderr: stderr}
+	l.untap = msgs.Tap(func(eventType msgs.EventType, optionalData ...interface{}) {
+		if eventType != msgs.EventError {
+			return
+		}
+		msg := "unknown error"
+		if len(optionalData) > 0 {
+			if s, ok := optionalData[0].(string); ok {
+				msg = s
+			}
+		}
+		l.mutex.Lock()
+		defer l.mutex.Unlock()
+		l.n++
+		fmt.Fprintln(l.stderr, "Error:", msg)
+	})
+	return l
+}
+
+func (l *errorLog) count() int {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.n
+}
+
+func (l *errorLog) close() {
+	l.untap()
+}
@@ -0,0 +1,78 @@
+package app
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/manager"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/msgs"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/preferences"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/project"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExportOptions(t *testing.T) {
+	var stderr bytes.Buffer
+	opts, err := parseExportOptions([]string{"--project", "a.tbx", "--collection", "exports", "--facet", "statements", "--format", "json"}, &stderr)
+	require.NoError(t, err)
+	assert.Equal(t, "a.tbx", opts.project)
+	assert.Equal(t, "statements", opts.facet)
+	assert.Equal(t, "json", opts.format)
+	assert.Equal(t, 30*time.Minute, opts.timeout)
+
+	for name, args := range map[string][]string{
+		"no project":     {"--collection", "exports"},
+		"no collection":  {"--project", "a.tbx"},
+		"bad format":     {"--project", "a.tbx", "--collection", "exports", "--format", "xls"},
+		"extra argument": {"--project", "a.tbx", "--collection", "exports", "more"},
+		"bad timeout":    {"--project", "a.tbx", "--collection", "exports", "--timeout", "0s"},
+		"unknown flag":   {"--nope"},
+	} {
+		_, err := parseExportOptions(args, &stderr)
+		assert.Error(t, err, name)
+	}
+
+	_, err = parseExportOptions([]string{"--help"}, &stderr)
+	assert.True(t, errors.Is(err, flag.ErrHelp))
+}
+
+func TestExportFailures(t *testing.T) {
+	app := &App{
+		Projects:    manager.NewManager[*project.Project]("project"),
+		Preferences: &preferences.Preferences{},
+	}
+	var stdout, stderr bytes.Buffer
+	errs := newErrorLog(&stderr)
+	defer errs.close()
+
+	opts := exportOptions{project: "a.tbx", collection: "nothing", format: "csv", timeout: time.Second}
+	assert.Equal(t, ExitError, app.export(opts, errs, &stdout, &stderr))
+	assert.Contains(t, stderr.String(), `unknown collection "nothing"`)
+
+	stderr.Reset()
+	opts.collection = "exports"
+	opts.project = filepath.Join(t.TempDir(), "missing.tbx")
+	assert.Equal(t, ExitError, app.export(opts, errs, &stdout, &stderr))
+	assert.Contains(t, stderr.String(), "cannot open project")
+	assert.Empty(t, stdout.String())
+}
+
+func TestErrorLogReportsEmittedErrors(t *testing.T) {
+	var stderr bytes.Buffer
+	errs := newErrorLog(&stderr)
+
+	msgs.EmitStatus("not an error")
+	msgs.EmitError("fetch failed", errors.New("rpc unavailable"))
+	assert.Equal(t, 1, errs.count())
+	assert.Equal(t, "Error: fetch failed: rpc unavailable\n", stderr.String())
+
+	errs.close()
+	msgs.EmitError("after close", errors.New("ignored"))
+	assert.Equal(t, 1, errs.count())
+}
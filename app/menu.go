@@ -30,6 +30,9 @@ func (a *App) buildAppMenu() *menu.Menu {
 	file.AddText("Open", keys.CmdOrCtrl("o"), a.FileOpen)
 	file.AddText("Save", keys.CmdOrCtrl("s"), a.FileSave)
 	file.AddText("Save As", keys.CmdOrCtrl("shift+s"), a.FileSaveAs)
+	file.AddSeparator()
+	file.AddText("Export Project Bundle...", nil, a.FileExportBundle)
+	file.AddText("Import Project Bundle...", nil, a.FileImportBundle)
 
 	if runtime.GOOS == "darwin" {
 		appMenu.Append(menu.EditMenu())
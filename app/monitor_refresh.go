@@ -0,0 +1,159 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/logging"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/msgs"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/preferences"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/refresh"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types/exports"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types/monitors"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+)
+
+// refreshTick is how often the scheduler looks for chains that are due
+const refreshTick = 30 * time.Second
+
+// startMonitorRefresh configures the background refresh from preferences and starts it.
+// It runs even when the schedule is disabled so that enabling it later takes effect at once.
+func (a *App) startMonitorRefresh() {
+	if a.refresher == nil {
+		a.refresher = refresh.NewScheduler(freshenMonitors)
+		a.refresher.OnChange = onMonitorChanged
+		a.refresher.OnRun = a.saveRefreshResults
+		monitors.SetRefreshSource(a.refresher.Result)
+	}
+
+	a.prefsMu.RLock()
+	schedule := a.Preferences.App.MonitorRefresh
+	a.prefsMu.RUnlock()
+	configureRefresh(a.refresher, schedule)
+
+	a.refresher.Start(refreshTick)
+}
+
+func configureRefresh(s *refresh.Scheduler, schedule *preferences.MonitorRefresh) {
+	if schedule == nil {
+		s.Configure(false, 0, nil, nil)
+		return
+	}
+	targets := make([]refresh.Target, 0, len(schedule.Monitors))
+	for _, m := range schedule.Monitors {
+		targets = append(targets, refresh.Target{Chain: m.Chain, Address: base.HexToAddress(m.Address)})
+	}
+	interval := time.Duration(schedule.IntervalMinutes) * time.Minute
+	s.Configure(schedule.Enabled, interval, targets, schedule.Results)
+}
+
+// freshenMonitors brings each monitor up to date and returns its record count
+func freshenMonitors(chain string, addrs []base.Address) (map[base.Address]int64, error) {
+	counts := make(map[base.Address]int64, len(addrs))
+	for _, addr := range addrs {
+		opts := sdk.ListOptions{
+			Addrs:   []string{addr.Hex()},
+			Globals: sdk.Globals{Chain: chain},
+		}
+		list, _, err := opts.ListCount()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", addr.Hex(), err)
+		}
+		if len(list) > 0 {
+			counts[addr] = int64(list[0].Count)
+		}
+	}
+	return counts, nil
+}
+
+// onMonitorChanged marks the stores built from a grown monitor stale and tells the
+// frontend, so open views reload
+func onMonitorChanged(chain string, addr base.Address, newRecords int64) {
+	reason := fmt.Sprintf("%d new records for %s", newRecords, addr.Hex())
+	n := exports.MarkStale(chain, addr, reason)
+	monitors.MarkStale(chain, reason)
+	logging.LogBackend(fmt.Sprintf("refresh: %s on %s, %d stores marked stale", reason, chain, n))
+
+	msgs.EmitReloaded(types.Payload{Collection: "exports", ActiveChain: chain, ActiveAddress: addr.Hex()})
+	msgs.EmitReloaded(types.Payload{Collection: "monitors", ActiveChain: chain})
+}
+
+// saveRefreshResults keeps the last results in preferences so they survive a restart
+func (a *App) saveRefreshResults(results []refresh.Result) {
+	a.prefsMu.Lock()
+	defer a.prefsMu.Unlock()
+	if a.Preferences.App.MonitorRefresh == nil {
+		return
+	}
+	a.Preferences.App.MonitorRefresh.Results = results
+	if err := preferences.SetAppPreferences(&a.Preferences.App); err != nil {
+		logging.LogBEWarning(fmt.Sprintf("refresh: could not save results: %v", err))
+	}
+}
+
+// GetMonitorRefresh returns the background refresh schedule with its latest results
+func (a *App) GetMonitorRefresh() *preferences.MonitorRefresh {
+	a.prefsMu.RLock()
+	defer a.prefsMu.RUnlock()
+	ret := &preferences.MonitorRefresh{Monitors: []preferences.RefreshMonitor{}}
+	if schedule := a.Preferences.App.MonitorRefresh; schedule != nil {
+		*ret = *schedule
+	}
+	if a.refresher != nil {
+		ret.Results = a.refresher.Results()
+	}
+	return ret
+}
+
+// SetMonitorRefresh saves the background refresh schedule and applies it. Monitors with an
+// empty chain are taken to be on mainnet; an interval of zero uses the default.
+func (a *App) SetMonitorRefresh(enabled bool, intervalMinutes int, list []preferences.RefreshMonitor) error {
+	if intervalMinutes < 0 {
+		return fmt.Errorf("invalid refresh interval %d", intervalMinutes)
+	}
+	if intervalMinutes > 0 && time.Duration(intervalMinutes)*time.Minute < refresh.MinInterval {
+		return fmt.Errorf("the refresh interval must be at least %s", refresh.MinInterval)
+	}
+	cleaned := make([]preferences.RefreshMonitor, 0, len(list))
+	for _, m := range list {
+		if !base.IsValidAddress(m.Address) {
+			return fmt.Errorf("cannot refresh %q: not an address", m.Address)
+		}
+		if m.Chain == "" {
+			m.Chain = "mainnet"
+		}
+		addr := base.HexToAddress(m.Address)
+		m.Address = addr.Hex()
+		cleaned = append(cleaned, m)
+	}
+
+	a.prefsMu.Lock()
+	schedule := &preferences.MonitorRefresh{Enabled: enabled, IntervalMinutes: intervalMinutes, Monitors: cleaned}
+	if prev := a.Preferences.App.MonitorRefresh; prev != nil {
+		schedule.Results = prev.Results
+	}
+	a.Preferences.App.MonitorRefresh = schedule
+	err := preferences.SetAppPreferences(&a.Preferences.App)
+	a.prefsMu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if a.refresher != nil {
+		configureRefresh(a.refresher, schedule)
+	}
+	return nil
+}
+
+// RefreshMonitorsNow freshens the scheduled monitors on chain without waiting for their
+// next run and returns what it found
+func (a *App) RefreshMonitorsNow(chain string) ([]refresh.Result, error) {
+	if a.refresher == nil {
+		return nil, fmt.Errorf("the monitor refresh is not running")
+	}
+	a.refresher.RunChain(chain)
+	return a.refresher.Results(), nil
+}
@@ -13,7 +13,11 @@ func (a *App) GetUserPreferences() *preferences.UserPreferences {
 // SetUserPreferences updates and persists user preferences
 func (a *App) SetUserPreferences(userPrefs *preferences.UserPreferences) error {
 	a.Preferences.User = *userPrefs
-	return preferences.SetUserPreferences(userPrefs)
+	if err := preferences.SetUserPreferences(userPrefs); err != nil {
+		return err
+	}
+	a.configureRpcPool()
+	return nil
 }
 
 // GetOrgPreferences returns the current organization preferences
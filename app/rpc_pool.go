@@ -0,0 +1,168 @@
+package app
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/logging"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/msgs"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/preferences"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/rpcpool"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types/exports"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types/status"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/validation"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/config"
+)
+
+// rpcCheckInterval is how often every chain's providers are checked
+const rpcCheckInterval = 2 * time.Minute
+
+// startRpcPool configures the provider pool from the chain config and preferences and
+// starts its background checks
+func (a *App) startRpcPool() {
+	if a.rpcPool == nil {
+		a.rpcPool = rpcpool.NewPool(rpcpool.JsonRpcProbe)
+		a.rpcPool.OnSelect = onRpcSelected
+		a.rpcPool.OnCheck = onRpcChecked
+		rpcpool.SetDefault(a.rpcPool)
+		status.SetHealthSource(a.rpcPool.History)
+		exports.SetTraceSupport(a.rpcTraceSupport)
+	}
+	a.configureRpcPool()
+	a.rpcPool.Start(rpcCheckInterval)
+	go a.checkRpcCapabilities()
+}
+
+// configureRpcPool hands the pool each chain's providers: those in the chain config, which
+// the SDK uses, followed by any more the user added in preferences. The chain config itself
+// is left alone; the SDK reads it without locking.
+func (a *App) configureRpcPool() {
+	if a.rpcPool == nil {
+		return
+	}
+
+	a.prefsMu.RLock()
+	chains := append([]preferences.Chain(nil), a.Preferences.User.Chains...)
+	a.prefsMu.RUnlock()
+
+	for _, ch := range chains {
+		if ch.Chain == "" {
+			continue
+		}
+		providers := ch.RpcProviders
+		chainId := ch.ChainId
+		if config.IsChainConfigured(ch.Chain) {
+			group := config.GetChain(ch.Chain)
+			providers = mergeProviders(group.RpcProviders, ch.RpcProviders)
+			if id, err := strconv.ParseUint(group.ChainId, 10, 64); err == nil && chainId == 0 {
+				chainId = id
+			}
+		}
+		a.rpcPool.Configure(ch.Chain, chainId, providers)
+	}
+}
+
+// mergeProviders returns first followed by those of more not already in it
+func mergeProviders(first, more []string) []string {
+	ret := make([]string, 0, len(first)+len(more))
+	seen := make(map[string]bool)
+	for _, list := range [][]string{first, more} {
+		for _, url := range list {
+			if url = strings.TrimSpace(url); url != "" && !seen[url] {
+				seen[url] = true
+				ret = append(ret, url)
+			}
+		}
+	}
+	return ret
+}
+
+// onRpcSelected logs a change of the provider the application's own calls on chain use
+func onRpcSelected(chain, url string) {
+	logging.LogBackend(fmt.Sprintf("rpc: %s now uses %s", chain, url))
+}
+
+// onRpcChecked marks the chain's health store stale and tells the frontend, so an open
+// health view shows the new checks
+func onRpcChecked(chain string, checks []rpcpool.Check) {
+	status.MarkHealthStale(chain, fmt.Sprintf("%d provider checks", len(checks)))
+	msgs.EmitReloaded(types.Payload{Collection: "status", ActiveChain: chain})
+}
+
+// GetRpcHealth returns the provider checks of chain, oldest first
+func (a *App) GetRpcHealth(chain string) []rpcpool.Check {
+	if a.rpcPool == nil {
+		return []rpcpool.Check{}
+	}
+	return a.rpcPool.History(chain)
+}
+
+// CheckRpcProviders checks the providers of chain now, failing the application's own calls
+// over if the selected one is no longer healthy, and returns the new checks. SDK queries
+// keep the chain config's first provider whatever the checks find.
+func (a *App) CheckRpcProviders(chain string) ([]rpcpool.Check, error) {
+	if a.rpcPool == nil {
+		return nil, fmt.Errorf("the provider pool is not running")
+	}
+	checks := a.rpcPool.Check(chain)
+	if len(checks) == 0 {
+		return nil, fmt.Errorf("no rpc providers are configured for %s", chain)
+	}
+	return checks, nil
+}
+
+// CheckRpcEndpoint queries url for its chain ID, client version and support for traces and
+// historical state. The report is returned even if the endpoint should not be used for
+// chainId, with the reason in its error; only a malformed url is an error.
+func (a *App) CheckRpcEndpoint(url string, chainId uint64) (*validation.RpcCapabilities, error) {
+	caps, err := validation.CheckRPC(url, chainId)
+	if caps == nil {
+		return nil, err
+	}
+	a.keepRpcCapabilities(caps)
+	return caps, nil
+}
+
+// keepRpcCapabilities remembers the report of an endpoint so features it cannot serve are
+// turned away before they are tried
+func (a *App) keepRpcCapabilities(caps *validation.RpcCapabilities) {
+	if caps == nil {
+		return
+	}
+	a.rpcCapsMu.Lock()
+	defer a.rpcCapsMu.Unlock()
+	if a.rpcCaps == nil {
+		a.rpcCaps = make(map[string]*validation.RpcCapabilities)
+	}
+	a.rpcCaps[caps.URL] = caps
+}
+
+// checkRpcCapabilities reports on every provider of every chain in preferences
+func (a *App) checkRpcCapabilities() {
+	a.prefsMu.RLock()
+	chains := append([]preferences.Chain(nil), a.Preferences.User.Chains...)
+	a.prefsMu.RUnlock()
+
+	for _, ch := range chains {
+		for _, url := range ch.RpcProviders {
+			caps, _ := validation.CheckRPC(url, ch.ChainId)
+			a.keepRpcCapabilities(caps)
+		}
+	}
+}
+
+// rpcTraceSupport reports whether the provider the SDK uses for chain serves traces. It is
+// known only once that provider has been reached.
+func (a *App) rpcTraceSupport(chain string) (known, supported bool) {
+	url := config.GetChain(chain).GetRpcProvider()
+	a.rpcCapsMu.Lock()
+	defer a.rpcCapsMu.Unlock()
+	if caps := a.rpcCaps[url]; caps != nil && caps.Reachable {
+		return true, caps.Traces
+	}
+	return false, false
+}
@@ -68,7 +68,11 @@ func (a *App) SetUserInfo(name, email string) error {
 	a.Preferences.User.Name = name
 	a.Preferences.User.Email = email
 
-	return preferences.SetUserPreferences(&a.Preferences.User)
+	if err := preferences.SetUserPreferences(&a.Preferences.User); err != nil {
+		return err
+	}
+	a.configureRpcPool()
+	return nil
 }
 
 // SetChain validates and adds or updates a blockchain chain configuration with RPC providers
@@ -79,6 +83,13 @@ func (a *App) SetChain(ch preferences.Chain) error {
 			if err := validation.ValidRPC(rpc); err != nil {
 				return err
 			}
+			// An endpoint that answers for another chain is refused; one that does not
+			// answer is kept, as it may only be down for now
+			caps, err := validation.CheckRPC(rpc, ch.ChainId)
+			a.keepRpcCapabilities(caps)
+			if caps != nil && caps.Reachable && !caps.ChainIdMatches {
+				return err
+			}
 		}
 	}
 
@@ -115,5 +126,9 @@ func (a *App) SetChain(ch preferences.Chain) error {
 		a.Preferences.User.Chains = append([]preferences.Chain{ch}, a.Preferences.User.Chains...)
 	}
 
-	return preferences.SetUserPreferences(&a.Preferences.User)
+	if err := preferences.SetUserPreferences(&a.Preferences.User); err != nil {
+		return err
+	}
+	a.configureRpcPool()
+	return nil
 }
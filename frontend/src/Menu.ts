@@ -16,6 +16,7 @@ import {
   Exports,
   Monitors,
   Names,
+  Portfolio,
   Projects,
   Status,
 } from '@views';
@@ -50,6 +51,14 @@ export const MenuItems: MenuItem[] = [
     component: Exports,
     type: 'navigation',
   },
+  {
+    label: 'Portfolio',
+    path: '/portfolio',
+    menuOrder: 12,
+    position: 'top',
+    component: Portfolio,
+    type: 'navigation',
+  },
   {
     label: 'Monitors',
     path: '/monitors',
@@ -17,6 +17,7 @@ const iconNames = [
   'Khedra',
   'Projects',
   'Exports',
+  'Portfolio',
   'Monitors',
   'Abis',
   'Names',
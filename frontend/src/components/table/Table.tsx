@@ -377,7 +377,8 @@ export const Table = <T extends Record<string, unknown>>({
               <Header columns={displayColumns} viewStateKey={viewStateKey} />
               <tbody className={getDebugClass(5)}>
                 {data.length === 0 ? (
-                  state === types.StoreState.FETCHING ? (
+                  state === types.StoreState.FETCHING ||
+                  state === types.StoreState.CACHED ? (
                     <tr>
                       <td
                         colSpan={displayColumns.length}
@@ -425,7 +426,8 @@ export const Table = <T extends Record<string, unknown>>({
                     selectedRowIndex={selectedRowIndex}
                     handleRowClick={handleRowClick}
                     noDataMessage={
-                      state === types.StoreState.FETCHING
+                      state === types.StoreState.FETCHING ||
+                      state === types.StoreState.CACHED
                         ? 'Loading...'
                         : 'No data found.'
                     }
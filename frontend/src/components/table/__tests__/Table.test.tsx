@@ -173,6 +173,11 @@ describe('Table', () => {
       // Just check that the table exists
       expect(screen.getByRole('table')).toBeInTheDocument();
     });
+
+    it('shows loading while a cached store refreshes', () => {
+      setupTest({ data: [], state: types.StoreState.CACHED });
+      expect(screen.getByText('Loading...')).toBeInTheDocument();
+    });
   });
 
   // Group 3: ViewStateKey integration tests
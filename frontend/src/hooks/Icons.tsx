@@ -7,6 +7,7 @@
  */
 // EXISTING_CODE
 import {
+  BiBriefcase,
   BiBuildings,
   BiCog,
   BiColumns,
@@ -19,6 +20,7 @@ import {
   BiUser,
 } from 'react-icons/bi';
 import {
+  FaBriefcase,
   FaCircleNotch,
   FaColumns,
   FaDesktop,
@@ -36,6 +38,7 @@ import {
 // Add new icons to the above list for each of these views
 // Projects
 // Exports
+// Portfolio
 // Monitors
 // Abis
 // Names
@@ -153,6 +156,7 @@ export const FaStatus = FaCircleNotch;
 export const FaMonitors = FaDesktop;
 export const FaProjects = FaUser;
 export const FaExports = FaHistory;
+export const FaPortfolio = FaBriefcase;
 export const FaChunks = FaIndustry;
 export const FaContracts = FaFileContract;
 export const FaAbis = FaListAlt;
@@ -165,6 +169,7 @@ export const BiStatus = BiCog;
 export const BiMonitors = BiHome;
 export const BiProjects = BiUser;
 export const BiExports = BiHistory;
+export const BiPortfolio = BiBriefcase;
 export const BiChunks = BiBuildings;
 export const BiContracts = BiFile;
 export const BiAbis = BiListUl;
@@ -178,6 +183,7 @@ export const BiMissing = BiQuestionMark;
 // Add to the above exports
 // Projects
 // Exports
+// Portfolio
 // Monitors
 // Abis
 // Names
@@ -7,6 +7,7 @@ import {
   GetExportsConfig,
   GetMonitorsConfig,
   GetNamesConfig,
+  GetPortfolioConfig,
   GetProjectsConfig,
   GetStatusConfig,
 } from '@app';
@@ -53,6 +54,7 @@ export async function initializeAllViewConfigs(): Promise<{
       const viewConfigs = [
         { name: 'projects', getter: GetProjectsConfig },
         { name: 'exports', getter: GetExportsConfig },
+        { name: 'portfolio', getter: GetPortfolioConfig },
         { name: 'monitors', getter: GetMonitorsConfig },
         { name: 'abis', getter: GetAbisConfig },
         { name: 'names', getter: GetNamesConfig },
@@ -206,6 +208,9 @@ export async function refreshViewConfig(viewName: string): Promise<void> {
       case 'names':
         getter = GetNamesConfig;
         break;
+      case 'portfolio':
+        getter = GetPortfolioConfig;
+        break;
       case 'projects':
         getter = GetProjectsConfig;
         break;
@@ -363,6 +363,9 @@ export const useActions = <TPageData extends { totalItems: number }, TItem>(
     const facet = getCurrentDataFacet();
     const payload = createPayload(facet);
     payload.collection = collection;
+    if (sort && sort.fields && sort.fields.length > 0) {
+      payload.sort = sort;
+    }
 
     try {
       const isDialogSilenced = await IsDialogSilenced('exportFormat');
@@ -392,7 +395,7 @@ export const useActions = <TPageData extends { totalItems: number }, TItem>(
         pendingPayload: payload,
       });
     }
-  }, [collection, getCurrentDataFacet, createPayload]);
+  }, [collection, getCurrentDataFacet, createPayload, sort]);
 
   // Handle format selection from modal
   const handleFormatSelected = useCallback(
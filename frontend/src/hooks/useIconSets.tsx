@@ -14,6 +14,7 @@ import * as Icons from './Icons';
 export type IconSet = {
   Projects: IconType;
   Exports: IconType;
+  Portfolio: IconType;
   Monitors: IconType;
   Abis: IconType;
   Names: IconType;
@@ -67,6 +68,7 @@ const faIcons: IconSet = {
   // Collections
   Projects: Icons.FaProjects,
   Exports: Icons.FaExports,
+  Portfolio: Icons.FaPortfolio,
   Monitors: Icons.FaMonitors,
   Abis: Icons.FaAbis,
   Names: Icons.FaNames,
@@ -128,6 +130,7 @@ const biIcons: IconSet = {
   // Collections
   Projects: Icons.BiProjects,
   Exports: Icons.BiExports,
+  Portfolio: Icons.BiPortfolio,
   Monitors: Icons.BiMonitors,
   Abis: Icons.BiAbis,
   Names: Icons.BiNames,
@@ -218,6 +221,8 @@ export const useIconSets = (
       createElement(iconSet.Projects, { size, ...props });
     const Exports: FC<IconProps> = (props = {}) =>
       createElement(iconSet.Exports, { size, ...props });
+    const Portfolio: FC<IconProps> = (props = {}) =>
+      createElement(iconSet.Portfolio, { size, ...props });
     const Monitors: FC<IconProps> = (props = {}) =>
       createElement(iconSet.Monitors, { size, ...props });
     const Abis: FC<IconProps> = (props = {}) =>
@@ -305,6 +310,7 @@ export const useIconSets = (
     return {
       Projects,
       Exports,
+      Portfolio,
       Monitors,
       Abis,
       Names,
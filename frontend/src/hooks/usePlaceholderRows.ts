@@ -29,7 +29,10 @@ export const usePlaceholderRows = ({
       return;
     }
 
-    if (state === types.StoreState.FETCHING) {
+    if (
+      state === types.StoreState.FETCHING ||
+      state === types.StoreState.CACHED
+    ) {
       // Start with a 2-second delay
       const delayTimer = setTimeout(() => {
         let currentCount = 0;
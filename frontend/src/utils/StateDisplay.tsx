@@ -18,6 +18,8 @@ const getStateColor = (state: types.StoreState) => {
       return 'blue';
     case types.StoreState.LOADED:
       return 'green';
+    case types.StoreState.CACHED:
+      return 'cyan';
     default:
       return 'gray';
   }
@@ -31,6 +33,8 @@ const getStateLabel = (state: types.StoreState) => {
       return 'Fetching...';
     case types.StoreState.LOADED:
       return 'Loaded';
+    case types.StoreState.CACHED:
+      return 'Cached, refreshing...';
     default:
       return 'Unknown' + String(state);
   }
@@ -107,6 +107,8 @@ export const Chunks = () => {
         return pageData.blooms || [];
       case types.DataFacet.MANIFEST:
         return pageData.manifest || [];
+      case types.DataFacet.INTEGRITY:
+        return pageData.integrity || [];
       default:
         LogError('[Chunks] unexpected facet=' + String(facet));
         return [];
@@ -102,7 +102,7 @@ export const Comparitoor = () => {
     const facet = getCurrentDataFacet();
     switch (facet) {
       case types.DataFacet.COMPARITOOR:
-        return pageData.transaction || [];
+        return pageData.appearance || [];
       case types.DataFacet.CHIFRA:
         return pageData.transaction || [];
       case types.DataFacet.ETHERSCAN:
@@ -17,44 +17,54 @@ export type ComparitoorSource = {
   stats: { appearances: number; time?: number; unique: number };
 };
 
-const sourceDefs = [
-  { key: 'chifra', label: 'Chifra', field: 'chifra' },
-  { key: 'etherscan', label: 'EtherScan', field: 'etherscan' },
-  { key: 'covalent', label: 'Covalent', field: 'covalent' },
-  { key: 'alchemy', label: 'Alchemy', field: 'alchemy' },
-];
-
-type AnnotatedTxType = {
-  blockNumber?: number;
-  transactionIndex?: number;
-  missing?: boolean;
-  unique?: boolean;
+export type UnionStats = {
+  unionCount: number;
+  overlapCount: number;
+  intersectionCount: number;
 };
 
-export function useComparitoorData(page: comparitoor.ComparitoorPage | null) {
+type SourceKey = 'chifra' | 'etherscan' | 'covalent' | 'alchemy';
+
+const sourceDefs: { key: SourceKey; label: string }[] = [
+  { key: 'chifra', label: 'Chifra' },
+  { key: 'etherscan', label: 'EtherScan' },
+  { key: 'covalent', label: 'Covalent' },
+  { key: 'alchemy', label: 'Alchemy' },
+];
+
+// Turns the backend's diff rows into one column per source. Every column has a row for
+// every appearance so the columns line up; a source that lacks an appearance shows it
+// as missing.
+export function useComparitoorData(
+  rows: comparitoor.Appearance[] | null,
+): ComparitoorSource[] {
   return useMemo(() => {
-    if (!page) return [];
+    if (!rows) return [];
     return sourceDefs.map((src) => {
-      const arr = page[src.field as keyof typeof page] as
-        | AnnotatedTxType[]
-        | undefined;
-      const data: AppearanceItem[] = (arr || []).map((tx) => ({
-        blockNum: tx.blockNumber !== undefined ? String(tx.blockNumber) : '',
-        txid:
-          tx.transactionIndex !== undefined ? String(tx.transactionIndex) : '',
-        value: `${tx.blockNumber ?? ''}.${tx.transactionIndex ?? ''}`,
-        missing: !!tx.missing,
-        unique: !!tx.unique,
+      const data: AppearanceItem[] = rows.map((row) => ({
+        blockNum: String(row.blockNumber),
+        txid: String(row.transactionIndex),
+        value: `${row.blockNumber}.${row.transactionIndex}`,
+        missing: !row[src.key],
+        unique: row[src.key] && row.nSources === 1,
       }));
       return {
         key: src.key,
         label: src.label,
         data,
         stats: {
-          appearances: arr?.length ?? 0,
+          appearances: data.filter((item) => !item.missing).length,
           unique: data.filter((item) => item.unique).length,
         },
       };
     });
-  }, [page]);
+  }, [rows]);
+}
+
+export function getUnionStats(rows: comparitoor.Appearance[]): UnionStats {
+  return {
+    unionCount: rows.length,
+    overlapCount: rows.filter((row) => row.nSources > 1).length,
+    intersectionCount: rows.filter((row) => row.status === 'agreed').length,
+  };
 }
@@ -6,9 +6,10 @@
  */
 // EXISTING_CODE
 import React from 'react';
-import { useRef, useState } from 'react';
+import { useMemo, useRef, useState } from 'react';
 
 import { RendererParams } from '@components';
+import { useActiveProject } from '@hooks';
 import {
   Box,
   Group,
@@ -21,7 +22,10 @@ import {
 } from '@mantine/core';
 import { comparitoor } from '@models';
 
-import { useComparitoorData } from '../../../hooks/useComparitoorData';
+import {
+  getUnionStats,
+  useComparitoorData,
+} from '../../../hooks/useComparitoorData';
 import { SummaryColumn } from '../../components/SummaryColumn';
 
 export type AppearanceItem = {
@@ -37,8 +41,11 @@ export type AppearanceItem = {
 export const ComparitoorFacet = ({ params }: { params: RendererParams }) => {
   // EXISTING_CODE
   const { data } = params;
-  const pageData = data;
-  const address = '0x503017d7baf7fbc0fff7492b751025c6a78179b'; // Default address for now
+  const rows = useMemo(
+    () => (data || []) as unknown as comparitoor.Appearance[],
+    [data],
+  );
+  const { activeAddress: address } = useActiveProject();
   const containerRef = useRef<HTMLDivElement>(null);
   const theme = useMantineTheme();
   // statusColors and getRowStyle removed (no longer used)
@@ -88,21 +95,8 @@ export const ComparitoorFacet = ({ params }: { params: RendererParams }) => {
     setActive({ sourceIdx, itemIdx });
   }
 
-  // Transform raw pageData
-  const transformed = pageData
-    ? ({
-        transaction: pageData,
-        chifra: pageData,
-        etherscan: pageData,
-        covalent: pageData,
-        alchemy: pageData,
-        unionCount: pageData.length,
-        overlapCount: 0,
-        intersectionCount: 0,
-      } as unknown as comparitoor.ComparitoorPage)
-    : null;
-
-  const sources = useComparitoorData(transformed);
+  // Each diff row becomes one line in every source's column
+  const sources = useComparitoorData(rows);
 
   // Data-driven row style: assign by item properties only
   // getRowStyle removed (no longer used)
@@ -121,7 +115,7 @@ export const ComparitoorFacet = ({ params }: { params: RendererParams }) => {
       </Title>
       <Group align="center" justify="center" style={{ flexShrink: 0 }}>
         <TextInput
-          value={address || '0x503017d7baf7fbc0fff7492b751025c6a78179b'}
+          value={address}
           readOnly
           maw={420}
           style={{ flexGrow: 1 }}
@@ -193,9 +187,7 @@ export const ComparitoorFacet = ({ params }: { params: RendererParams }) => {
                         <Text variant={variant} size="sm">
                           {isMissing ? '[missing]' : item.value}
                         </Text>
-                        {item.value === '100.100' && src.key === 'chifra' ? (
-                          <MaterialIcon />
-                        ) : null}
+                        {item.unique ? <MaterialIcon /> : null}
                       </Group>
                     );
                   })}
@@ -235,15 +227,7 @@ export const ComparitoorFacet = ({ params }: { params: RendererParams }) => {
           }
           sourceKeys={sources.map((src) => src.key)}
           sources={sources}
-          unionStats={
-            transformed
-              ? {
-                  unionCount: transformed.unionCount,
-                  overlapCount: transformed.overlapCount,
-                  intersectionCount: transformed.intersectionCount,
-                }
-              : undefined
-          }
+          unionStats={getUnionStats(rows)}
         />
       </Box>
       {/** Legend removed as per requirements */}
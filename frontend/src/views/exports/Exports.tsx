@@ -100,13 +100,15 @@ export const Exports = () => {
     const facet = getCurrentDataFacet();
     switch (facet) {
       case types.DataFacet.STATEMENTS:
-        return pageData.statements || [];
+        return withFiat(pageData.statements, pageData.fiat);
       case types.DataFacet.ASSETS:
-        return pageData.assets || [];
+        return withFiat(pageData.assets, pageData.fiat);
       case types.DataFacet.ASSETCHARTS:
         return pageData.statements || [];
       case types.DataFacet.BALANCES:
-        return pageData.balances || [];
+        return withFiat(pageData.balances, pageData.fiat);
+      case types.DataFacet.GAINS:
+        return pageData.gains || [];
       case types.DataFacet.TRANSFERS:
         return pageData.transfers || [];
       case types.DataFacet.OPENAPPROVALS:
@@ -328,4 +330,23 @@ export const Exports = () => {
 };
 
 // EXISTING_CODE
+// withFiat copies the backend's per-row fiat values onto the rows so the price and
+// value columns can be displayed like any other field
+const withFiat = <T extends object>(
+  rows: T[] | undefined,
+  fiat: exports.FiatValue[] | undefined,
+): T[] => {
+  if (!rows) return [];
+  if (!fiat || fiat.length !== rows.length) return rows;
+  return rows.map((row, i) => {
+    const value = fiat[i];
+    if (!value) return row;
+    return {
+      ...row,
+      fiatPrice: value.source ? value.price : undefined,
+      fiatValue: value.source ? value.value : undefined,
+      fiatSource: value.source || 'none',
+    } as T;
+  });
+};
 // EXISTING_CODE
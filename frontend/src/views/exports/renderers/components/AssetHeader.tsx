@@ -10,7 +10,7 @@ import {
 } from '@mantine/core';
 import { types } from '@models';
 
-export type MetricOption = 'frequency' | 'volume' | 'endBal';
+export type MetricOption = 'frequency' | 'volume' | 'endBal' | 'value';
 
 export interface AssetHeaderProps {
   assetKey?: string;
@@ -135,6 +135,7 @@ export const AssetHeader = ({
                   { value: 'frequency', label: 'Statement Frequency' },
                   { value: 'volume', label: 'Volume' },
                   { value: 'endBal', label: 'Ending Balance' },
+                  { value: 'value', label: 'Value' },
                 ]}
                 style={{ minWidth: 150 }}
               />
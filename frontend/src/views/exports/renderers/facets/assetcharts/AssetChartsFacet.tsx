@@ -16,6 +16,10 @@ import { LogError, useErrorHandler } from '@utils';
 
 import { AssetChart, AssetHeader, type MetricOption } from '../../components';
 
+// The ending balance series has always been stored as endBalEth
+const seriesMetric = (metric: MetricOption): string =>
+  metric === 'endBal' ? 'endBalEth' : metric;
+
 // EXISTING_CODE
 
 export const AssetChartsFacet = ({ params }: { params: RendererParams }) => {
@@ -39,7 +43,10 @@ export const AssetChartsFacet = ({ params }: { params: RendererParams }) => {
     const loadSelectedMetric = async () => {
       try {
         const saved = await GetExportsMetric('assetcharts');
-        if (saved && ['frequency', 'volume', 'endBal'].includes(saved)) {
+        if (
+          saved &&
+          ['frequency', 'volume', 'endBal', 'value'].includes(saved)
+        ) {
           setSelectedMetric(saved as MetricOption);
         }
       } catch {
@@ -47,7 +54,7 @@ export const AssetChartsFacet = ({ params }: { params: RendererParams }) => {
         const localSaved = localStorage.getItem('assetCharts-selectedMetric');
         if (
           localSaved &&
-          ['frequency', 'volume', 'endBal'].includes(localSaved)
+          ['frequency', 'volume', 'endBal', 'value'].includes(localSaved)
         ) {
           setSelectedMetric(localSaved as MetricOption);
         }
@@ -71,7 +78,7 @@ export const AssetChartsFacet = ({ params }: { params: RendererParams }) => {
 
   // Cycle through metrics using hotkey (Cmd+M / Ctrl+M)
   const cycleMetric = useCallback(() => {
-    const metrics: MetricOption[] = ['frequency', 'volume', 'endBal'];
+    const metrics: MetricOption[] = ['frequency', 'volume', 'endBal', 'value'];
     const currentIndex = metrics.indexOf(selectedMetric);
     const nextIndex = (currentIndex + 1) % metrics.length;
     const nextMetric = metrics[nextIndex];
@@ -244,7 +251,7 @@ export const AssetChartsFacet = ({ params }: { params: RendererParams }) => {
       >
         {sortedAssets.map(
           ([assetKey, metrics]: [string, Record<string, types.Bucket[]>]) => {
-            const metricBuckets = metrics[selectedMetric];
+            const metricBuckets = metrics[seriesMetric(selectedMetric)];
             return (
               <AssetChart
                 key={assetKey}
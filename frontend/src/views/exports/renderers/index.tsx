@@ -7,6 +7,7 @@ import * as panels from './panels';
 export const renderers = {
   panels: {
     [types.DataFacet.STATEMENTS]: panels.StatementsPanel,
+    [types.DataFacet.GAINS]: panels.GainsPanel,
     [types.DataFacet.OPENAPPROVALS]: panels.OpenApprovalsPanel,
     [types.DataFacet.APPROVALTXS]: panels.ApprovalTxsPanel,
     [types.DataFacet.APPROVALLOGS]: panels.ApprovalLogsPanel,
@@ -0,0 +1,156 @@
+// Copyright 2016, 2026 The Authors. All rights reserved.
+// Use of this source code is governed by a license that can
+// be found in the LICENSE file.
+/*
+ * This file was auto generated. Do not edit.
+ */
+// EXISTING_CODE
+import { useCallback, useEffect, useMemo, useState } from 'react';
+
+import { GetExportsMetric, Reload, SetExportsMetric } from '@app';
+import {
+  DetailContainer,
+  DetailSection,
+  PanelRow,
+  PanelTable,
+  StyledLabel,
+  StyledValue,
+} from '@components';
+import { usePayload } from '@hooks';
+import { Select, Stack, Text } from '@mantine/core';
+import { exports, types } from '@models';
+import { LogError } from '@utils';
+
+const methods = [
+  { value: 'fifo', label: 'FIFO (first in, first out)' },
+  { value: 'lifo', label: 'LIFO (last in, first out)' },
+  { value: 'hifo', label: 'HIFO (highest cost first)' },
+];
+
+const formatUsd = (value: number | undefined): string =>
+  (value ?? 0).toLocaleString(undefined, {
+    style: 'currency',
+    currency: 'USD',
+  });
+
+// EXISTING_CODE
+
+export const GainsPanel = (
+  rowData: Record<string, unknown>,
+  _onFinal: (rowKey: string, newValue: string, txHash: string) => void,
+) => {
+  // EXISTING_CODE
+  const facet = 'gains';
+  const createPayload = usePayload('exports');
+  const [method, setMethod] = useState<string>('fifo');
+
+  const gain = useMemo(
+    () => (rowData as unknown as exports.Gain) || exports.Gain.createFrom({}),
+    [rowData],
+  );
+
+  useEffect(() => {
+    GetExportsMetric(facet)
+      .then((saved) => {
+        if (saved && methods.some((m) => m.value === saved)) {
+          setMethod(saved);
+        }
+      })
+      .catch(() => {});
+  }, []);
+
+  // Changing the method rebuilds every lot, so the whole facet is reloaded
+  const handleMethodChange = useCallback(
+    async (value: string | null) => {
+      if (!value || value === method) return;
+      setMethod(value);
+      try {
+        await SetExportsMetric(facet, value);
+        await Reload(createPayload(types.DataFacet.GAINS));
+      } catch (err) {
+        LogError(`Failed to change cost basis method: ${err}`);
+      }
+    },
+    [method, createPayload],
+  );
+
+  const acquired = gain.various
+    ? 'Various'
+    : gain.dateAcquired
+      ? new Date(gain.dateAcquired * 1000).toLocaleDateString()
+      : '-';
+  const hasUnmatched = String(gain.unmatched || '0') !== '0';
+
+  return (
+    <Stack gap={8} className="fixed-prompt-width">
+      <DetailContainer
+        title={
+          <Text variant="primary" size="md" fw={600}>
+            {gain.quantityEth} {gain.symbol} ({gain.term} term)
+          </Text>
+        }
+      >
+        <DetailSection facet={facet} title="Method">
+          <Select
+            size="xs"
+            data={methods}
+            value={method}
+            onChange={handleMethodChange}
+            allowDeselect={false}
+          />
+        </DetailSection>
+        <DetailSection facet={facet} title="Disposal">
+          <PanelTable>
+            {[
+              ['Acquired', acquired],
+              ['Sold', new Date(gain.dateSold * 1000).toLocaleDateString()],
+              ['Block', String(gain.blockNumber)],
+              ['Lots Chosen By', (gain.method || method).toUpperCase()],
+            ].map(([label, value]) => (
+              <PanelRow
+                key={`disposal-${label}`}
+                label={
+                  <StyledLabel variant="blue" weight="strong">
+                    {label}
+                  </StyledLabel>
+                }
+                value={<StyledValue variant="default">{value}</StyledValue>}
+              />
+            ))}
+          </PanelTable>
+        </DetailSection>
+        <DetailSection facet={facet} title="Gain (USD)">
+          <PanelTable>
+            {[
+              ['Proceeds', gain.proceeds],
+              ['Cost Basis', gain.costBasis],
+              ['Realized', gain.realizedGain],
+              ['Unrealized', gain.unrealizedGain],
+            ].map(([label, value]) => (
+              <PanelRow
+                key={`gain-${label}`}
+                layout="wide"
+                label={<StyledLabel variant="dimmed">{label}</StyledLabel>}
+                value={
+                  <StyledValue variant="default" size="sm" align="right">
+                    {formatUsd(value as number)}
+                  </StyledValue>
+                }
+              />
+            ))}
+          </PanelTable>
+        </DetailSection>
+        {hasUnmatched && (
+          <StyledValue variant="error" size="sm">
+            {gain.unmatched} units were disposed of beyond every known lot and
+            carry no cost basis
+          </StyledValue>
+        )}
+      </DetailContainer>
+    </Stack>
+  );
+  // EXISTING_CODE
+};
+
+// EXISTING_CODE
+// EXISTING_CODE
@@ -5,6 +5,7 @@
  * This file was auto generated. Do not edit.
  */
 export { StatementsPanel } from './statements';
+export { GainsPanel } from './gains';
 export { OpenApprovalsPanel } from './openapprovals';
 export { ApprovalTxsPanel } from './approvaltxs';
 export { ApprovalLogsPanel } from './approvallogs';
@@ -7,6 +7,7 @@
 
 export { Projects } from './projects';
 export { Exports } from './exports';
+export { Portfolio } from './portfolio';
 export { Monitors } from './monitors';
 export { Abis } from './abis';
 export { Names } from './names';
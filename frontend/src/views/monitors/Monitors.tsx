@@ -100,7 +100,7 @@ export const Monitors = () => {
     const facet = getCurrentDataFacet();
     switch (facet) {
       case types.DataFacet.MONITORS:
-        return pageData.monitors || [];
+        return withRefresh(pageData.monitors, pageData.refresh);
       default:
         LogError('[Monitors] unexpected facet=' + String(facet));
         return [];
@@ -321,4 +321,18 @@ export const Monitors = () => {
 };
 
 // EXISTING_CODE
+// withRefresh copies the backend's refresh status, keyed by address, onto the rows so the
+// refresh columns can be displayed like any other field
+const withRefresh = <T extends { address: unknown }>(
+  rows: T[] | undefined,
+  refresh: Record<string, monitors.RefreshStatus> | undefined,
+): T[] => {
+  if (!rows) return [];
+  if (!refresh) return rows;
+  return rows.map((row) => {
+    const status = refresh[String(row.address).toLowerCase()];
+    if (!status) return row;
+    return { ...row, ...status } as T;
+  });
+};
 // EXISTING_CODE
@@ -0,0 +1,323 @@
+// Copyright 2016, 2026 The Authors. All rights reserved.
+// Use of this source code is governed by a license that can
+// be found in the LICENSE file.
+/*
+ * Parts of this file were auto generated. Edit only those parts of
+ * the code inside of 'EXISTING_CODE' tags.
+ */
+// === SECTION 1: Imports & Dependencies ===
+import { useCallback, useEffect, useMemo, useRef, useState } from 'react';
+
+import { GetPortfolioPage, Reload } from '@app';
+import { BaseTab, usePagination } from '@components';
+import { Action, ConfirmModal, ExportFormatModal } from '@components';
+import { createDetailPanel } from '@components';
+import { useFiltering, useSorting } from '@contexts';
+import {
+  DataFacetConfig,
+  buildFacetConfigs,
+  useActions,
+  useActiveFacet,
+  useEvent,
+  useFacetColumns,
+  refreshViewConfig,
+  useFacetForm,
+  usePayload,
+  useViewConfig,
+} from '@hooks';
+import { TabView } from '@layout';
+import { Group } from '@mantine/core';
+import { useHotkeys } from '@mantine/hooks';
+import { portfolio } from '@models';
+import { msgs, project, types } from '@models';
+import { Debugger, LogError, useErrorHandler } from '@utils';
+
+import { assertRouteConsistency } from '../routes';
+import { ROUTE } from './constants';
+
+export const Portfolio = () => {
+  // === SECTION 2: Hook Initialization ===
+  const renderCnt = useRef(0);
+  const createPayload = usePayload(ROUTE);
+  // === SECTION 2.5: Initial ViewConfig Load ===
+  const { config: viewConfig } = useViewConfig({ viewName: ROUTE });
+  assertRouteConsistency(ROUTE, viewConfig);
+  const [_configRefreshCount, setConfigRefreshCount] = useState(0);
+  const facetsFromConfig: DataFacetConfig[] = useMemo(
+    () => buildFacetConfigs(viewConfig),
+    [viewConfig],
+  );
+
+  const activeFacetHook = useActiveFacet({
+    facets: facetsFromConfig,
+    viewRoute: ROUTE,
+  });
+  const { availableFacets, getCurrentDataFacet } = activeFacetHook;
+
+  const [pageData, setPageData] = useState<portfolio.PortfolioPage | null>(null);
+  const viewStateKey = useMemo(
+    (): project.ViewStateKey => ({
+      viewName: ROUTE,
+      facetName: getCurrentDataFacet(),
+    }),
+    [getCurrentDataFacet],
+  );
+
+  const { error, handleError, clearError } = useErrorHandler();
+  const { pagination, setTotalItems, goToPage } = usePagination(viewStateKey);
+  const { sort } = useSorting(viewStateKey);
+  const { filter } = useFiltering(viewStateKey);
+
+  // === SECTION 3: Data Fetching ===
+  const fetchData = useCallback(async () => {
+    clearError();
+    try {
+      const result = await GetPortfolioPage(
+        createPayload(getCurrentDataFacet()),
+        pagination.currentPage * pagination.pageSize,
+        pagination.pageSize,
+        sort,
+        filter,
+      );
+      setPageData(result);
+      setTotalItems(result.totalItems || 0);
+    } catch (err: unknown) {
+      handleError(err, `Failed to fetch ${getCurrentDataFacet()}`);
+    }
+  }, [
+    clearError,
+    createPayload,
+    getCurrentDataFacet,
+    pagination.currentPage,
+    pagination.pageSize,
+    sort,
+    filter,
+    setTotalItems,
+    handleError,
+  ]);
+
+  const currentData = useMemo(() => {
+    if (!pageData) return [];
+    const facet = getCurrentDataFacet();
+    switch (facet) {
+      case types.DataFacet.ASSETS:
+        return pageData.assets || [];
+      case types.DataFacet.BALANCES:
+        return pageData.balances || [];
+      case types.DataFacet.STATEMENTS:
+        return pageData.statements || [];
+      default:
+        LogError('[Portfolio] unexpected facet=' + String(facet));
+        return [];
+    }
+  }, [pageData, getCurrentDataFacet]);
+
+  // === SECTION 4: Event Handling ===
+  useEvent(
+    msgs.EventType.DATA_LOADED,
+    (_message: string, payload?: Record<string, unknown>) => {
+      if (payload?.collection === ROUTE) {
+        const eventDataFacet = payload.dataFacet;
+        if (eventDataFacet === getCurrentDataFacet()) {
+          fetchData();
+        }
+      }
+    },
+  );
+
+  // Listen for active address/chain/contract/period changes to refresh data
+  useEvent(msgs.EventType.ADDRESS_CHANGED, fetchData);
+  useEvent(msgs.EventType.CHAIN_CHANGED, fetchData);
+  useEvent(msgs.EventType.PERIOD_CHANGED, fetchData);
+  useEvent(msgs.EventType.CONTRACT_CHANGED, () => {
+    fetchData();
+  });
+
+  // The per-address breakdown columns follow the active project's addresses
+  useEvent(msgs.EventType.PROJECT_OPENED, async () => {
+    await refreshViewConfig(ROUTE);
+    setConfigRefreshCount((prev) => prev + 1);
+    await fetchData();
+  });
+  useEvent(msgs.EventType.MANAGER, async () => {
+    await refreshViewConfig(ROUTE);
+    setConfigRefreshCount((prev) => prev + 1);
+    await fetchData();
+  });
+
+  useEffect(() => {
+    fetchData();
+  }, [fetchData]);
+
+  const handleReload = useCallback(async () => {
+    clearError();
+    try {
+      Reload(createPayload(getCurrentDataFacet())).then(() => {});
+    } catch (err: unknown) {
+      handleError(err, `Failed to reload ${getCurrentDataFacet()}`);
+    }
+  }, [clearError, getCurrentDataFacet, createPayload, handleError]);
+
+  useHotkeys([['mod+r', handleReload]]);
+
+  // === SECTION 5: Actions ===
+  const { handlers, config, exportFormatModal, confirmModal } = useActions({
+    collection: ROUTE,
+    viewStateKey,
+    pagination,
+    goToPage,
+    sort,
+    filter,
+    viewConfig,
+    pageData,
+    setPageData,
+    setTotalItems,
+    crudFunc: () => Promise.resolve(),
+    pageFunc: GetPortfolioPage,
+    pageClass: portfolio.PortfolioPage,
+    updateItem: undefined,
+    createPayload,
+    getCurrentDataFacet,
+  });
+  const headerActions = useMemo(() => {
+    if (!config.headerActions.length) return null;
+    return (
+      <Group gap="xs" style={{ flexShrink: 0 }}>
+        {config.headerActions.map((action) => {
+          const handlerKey =
+            `handle${action.type.charAt(0).toUpperCase() + action.type.slice(1)}` as keyof typeof handlers;
+          const handler = handlers[handlerKey] as () => void;
+          return (
+            <Action
+              key={action.type}
+              icon={
+                action.icon as keyof ReturnType<
+                  typeof import('@hooks').useIconSets
+                >
+              }
+              onClick={handler}
+              title={
+                action.requiresWallet && !config.isWalletConnected
+                  ? `${action.title} (requires wallet connection)`
+                  : action.title
+              }
+              hotkey={action.type === 'export' ? 'mod+x' : undefined}
+              size="sm"
+            />
+          );
+        })}
+      </Group>
+    );
+  }, [config.headerActions, config.isWalletConnected, handlers]);
+
+  // === SECTION 6: UI Configuration ===
+  const currentColumns = useFacetColumns(
+    viewConfig,
+    getCurrentDataFacet,
+    {
+      showActions: false,
+      actions: [],
+      getCanRemove: useCallback((_row: unknown) => false, []),
+    },
+    {},
+    pageData,
+    { rowActions: [] },
+  );
+
+  const detailPanel = useMemo(
+    () =>
+      createDetailPanel(
+        viewConfig,
+        getCurrentDataFacet,
+        {},
+        (_rowKey: string, _newValue: string, _txHash: string) => {},
+      ),
+    [viewConfig, getCurrentDataFacet],
+  );
+
+  const { isCanvas, node: formNode } = useFacetForm<Record<string, unknown>>({
+    viewConfig,
+    getCurrentDataFacet,
+    currentData: currentData as unknown as Record<string, unknown>[],
+    currentColumns:
+      currentColumns as unknown as import('@components').FormField<
+        Record<string, unknown>
+      >[],
+    viewName: ROUTE,
+  });
+
+  const perTabContent = useMemo(() => {
+    if (isCanvas && formNode) return formNode;
+    return (
+      <BaseTab<Record<string, unknown>>
+        data={currentData as unknown as Record<string, unknown>[]}
+        columns={currentColumns}
+        state={pageData?.state || types.StoreState.STALE}
+        error={error}
+        viewStateKey={viewStateKey}
+        headerActions={headerActions}
+        detailPanel={detailPanel}
+      />
+    );
+  }, [
+    currentData,
+    currentColumns,
+    pageData?.state,
+    error,
+    viewStateKey,
+    isCanvas,
+    formNode,
+    headerActions,
+    detailPanel,
+  ]);
+
+  const tabs = useMemo(
+    () =>
+      availableFacets.map((facetConfig: DataFacetConfig) => ({
+        key: facetConfig.id,
+        label: facetConfig.label,
+        value: facetConfig.id,
+        content: perTabContent,
+        dividerBefore: facetConfig.dividerBefore,
+        canClose: facetConfig.canClose,
+      })),
+    [availableFacets, perTabContent],
+  );
+
+  // === SECTION 7: Render ===
+  return (
+    <div className="mainView">
+      <TabView tabs={tabs} route={ROUTE} />
+      {error && (
+        <div>
+          <h3>{`Error fetching ${getCurrentDataFacet()}`}</h3>
+          <p>{error.message}</p>
+        </div>
+      )}
+      <Debugger
+        facetName={getCurrentDataFacet()}
+        rowActions={config.rowActions}
+        headerActions={config.headerActions}
+        count={++renderCnt.current}
+        state={pageData?.state || types.StoreState.STALE}
+        totalItems={pageData?.totalItems}
+      />
+      <ConfirmModal
+        opened={confirmModal.opened}
+        onClose={confirmModal.onClose}
+        onConfirm={confirmModal.onConfirm}
+        title={confirmModal.title}
+        message={confirmModal.message}
+        dialogKey={confirmModal.dialogKey}
+      />
+      <ExportFormatModal
+        opened={exportFormatModal.opened}
+        onClose={exportFormatModal.onClose}
+        onFormatSelected={exportFormatModal.onFormatSelected}
+      />
+    </div>
+  );
+};
+
+// EXISTING_CODE
+// EXISTING_CODE
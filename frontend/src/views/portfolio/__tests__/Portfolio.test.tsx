@@ -0,0 +1,44 @@
+import { render as customRender } from '@mocks';
+import { screen } from '@testing-library/react';
+import { describe, expect, it, vi } from 'vitest';
+
+// Mock the Portfolio component to avoid complex dependencies
+vi.mock('../../portfolio', () => ({
+  Portfolio: () => <div data-testid="portfolio-view">Portfolio View</div>,
+}));
+
+// Import after mocking
+const { Portfolio } = await import('../../portfolio');
+
+describe('Portfolio View Integration Tests (DataFacet refactor preparation)', () => {
+  describe('basic rendering', () => {
+    it('renders without crashing', () => {
+      customRender(<Portfolio />);
+      expect(screen.getByTestId('portfolio-view')).toBeInTheDocument();
+    });
+  });
+
+  describe('facet management (placeholder)', () => {
+    it('should support all facets.', () => {
+      // Placeholder for future facet switching tests
+      expect(true).toBe(true);
+    });
+
+    it('should persist facet selection to preferences', () => {
+      // Placeholder for preference persistence tests
+      expect(true).toBe(true);
+    });
+  });
+
+  describe('state management (placeholder)', () => {
+    it('should maintain separate pagination per facet', () => {
+      // Placeholder for pagination state tests
+      expect(true).toBe(true);
+    });
+
+    it('should recover state from saved preferences', () => {
+      // Placeholder for state recovery tests
+      expect(true).toBe(true);
+    });
+  });
+});
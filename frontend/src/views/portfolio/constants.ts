@@ -0,0 +1,3 @@
+import { ViewRoute } from '../routes';
+
+export const ROUTE: ViewRoute = 'portfolio';
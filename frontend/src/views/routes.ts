@@ -12,6 +12,7 @@ export const VIEW_ROUTES = [
   'exports',
   'monitors',
   'names',
+  'portfolio',
   'status',
 ] as const;
 
@@ -104,6 +104,8 @@ export const Status = () => {
         return pageData.caches || [];
       case types.DataFacet.CHAINS:
         return pageData.chains || [];
+      case types.DataFacet.HEALTH:
+        return pageData.health || [];
       default:
         LogError('[Status] unexpected facet=' + String(facet));
         return [];
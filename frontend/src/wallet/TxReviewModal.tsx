@@ -1,5 +1,7 @@
 import React, { useCallback, useMemo, useState } from 'react';
 
+import { ExportUnsignedTransaction } from '@app';
+
 import { StyledBadge, StyledButton, StyledModal } from '@components';
 import { useViewContext } from '@contexts';
 import { usePayload } from '@hooks';
@@ -184,6 +186,17 @@ export const TxReviewModal: React.FC<TransactionReviewModalProps> = ({
     }
   }, [preparedTx, onConfirm, editable, handlePrepareFromEditable]);
 
+  const handleExportUnsigned = useCallback(async () => {
+    if (!preparedTx?.unsigned) return;
+    try {
+      await ExportUnsignedTransaction(preparedTx.unsigned);
+    } catch (err) {
+      setError(
+        err instanceof Error ? err.message : 'Failed to export transaction',
+      );
+    }
+  }, [preparedTx]);
+
   const formatParameter = (input: {
     name: string;
     type: string;
@@ -402,6 +415,83 @@ export const TxReviewModal: React.FC<TransactionReviewModalProps> = ({
           </Card>
         )}
 
+        {/* Simulation */}
+        {preparedTx?.simulation && (
+          <Card withBorder>
+            <Stack gap="sm">
+              <Group justify="space-between">
+                <Text variant="primary" size="sm" fw={600}>
+                  Simulation
+                </Text>
+                <StyledBadge variant="light">
+                  {preparedTx.simulation.success ? 'succeeds' : 'unknown'}
+                </StyledBadge>
+              </Group>
+              {preparedTx.simulation.error && (
+                <Text variant="dimmed" size="sm">
+                  {preparedTx.simulation.error}
+                </Text>
+              )}
+              {(preparedTx.simulation.outputs || []).map((output, index) => (
+                <Group key={index} justify="space-between">
+                  <Text variant="primary" size="sm">
+                    {output.name || `output ${index}`}
+                  </Text>
+                  <Code>{String(output.value)}</Code>
+                </Group>
+              ))}
+            </Stack>
+          </Card>
+        )}
+
+        {/* Unsigned Transaction */}
+        {preparedTx && (preparedTx.unsigned || preparedTx.unsignedError) && (
+          <Card withBorder>
+            <Stack gap="sm">
+              <Group justify="space-between">
+                <Text variant="primary" size="sm" fw={600}>
+                  Sign Offline
+                </Text>
+                {preparedTx.unsigned && (
+                  <StyledBadge variant="light">
+                    {preparedTx.unsigned.type}
+                  </StyledBadge>
+                )}
+              </Group>
+              {preparedTx.unsigned ? (
+                <>
+                  <Group justify="space-between">
+                    <Text variant="primary" size="sm">
+                      Chain ID / Nonce
+                    </Text>
+                    <Text variant="primary" size="sm" fw={600}>
+                      {preparedTx.unsigned.chainId} /{' '}
+                      {preparedTx.unsigned.nonce}
+                    </Text>
+                  </Group>
+                  <Text variant="dimmed" size="xs">
+                    Signing Hash
+                  </Text>
+                  <Code block>{preparedTx.unsigned.signingHash}</Code>
+                  <Group justify="flex-end">
+                    <StyledButton
+                      variant="light"
+                      size="xs"
+                      onClick={handleExportUnsigned}
+                    >
+                      Export JSON
+                    </StyledButton>
+                  </Group>
+                </>
+              ) : (
+                <Text variant="dimmed" size="sm">
+                  {preparedTx.unsignedError}
+                </Text>
+              )}
+            </Stack>
+          </Card>
+        )}
+
         <Divider />
 
         {/* Error Display */}
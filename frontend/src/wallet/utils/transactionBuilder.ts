@@ -1,5 +1,5 @@
 import { PrepareTransaction } from '@app';
-import { types } from '@models';
+import { txbuilder, types } from '@models';
 
 export interface TransactionData {
   to: string;
@@ -20,6 +20,9 @@ export interface PreparedTransaction {
   value: string;
   gas?: string;
   gasPrice?: string;
+  unsigned?: txbuilder.UnsignedTx;
+  unsignedError?: string;
+  simulation?: txbuilder.Simulation;
 }
 
 /**
@@ -121,6 +124,9 @@ export const prepareTransaction = async (
       value: transactionData.value || '0',
       gas: estimatedGas,
       gasPrice: gasPrice,
+      unsigned: result.unsigned,
+      unsignedError: result.unsignedError,
+      simulation: result.simulation,
     };
   } catch (error) {
     throw new Error(
@@ -7,11 +7,15 @@ import {
   useState,
 } from 'react';
 
-import { GetUserPreferences, SetUserPreferences } from '@app';
+import {
+  CheckRpcEndpoint,
+  GetUserPreferences,
+  SetUserPreferences,
+} from '@app';
 import { FormField, WizardForm } from '@components';
 import { useIconSets } from '@hooks';
 import { ActionIcon, Card, Group, Tabs, Text } from '@mantine/core';
-import { preferences } from '@models';
+import { preferences, validation } from '@models';
 import { LogError, emitStatus } from '@utils';
 
 import { WizardStepProps } from '.';
@@ -30,6 +34,8 @@ export const StepChainInfo = ({
   const { Create, Delete } = useIconSets();
   const [chains, setChains] = useState<preferences.Chain[]>([]);
   const [activeTab, setActiveTab] = useState<string | null>('new');
+  const [capabilities, setCapabilities] =
+    useState<validation.RpcCapabilities | null>(null);
   const firstInputRef = useRef<HTMLInputElement>(null);
 
   useEffect(() => {
@@ -167,6 +173,13 @@ export const StepChainInfo = ({
         return false;
       }
 
+      const report = await CheckRpcEndpoint(rpcUrl, chainIdNum);
+      setCapabilities(report);
+      if (report.reachable && !report.chainIdMatches) {
+        emitStatus(report.error || 'The RPC endpoint serves a different chain');
+        return false;
+      }
+
       const newChain: preferences.Chain = {
         chain: chainName,
         chainId: chainIdNum,
@@ -326,9 +339,27 @@ export const StepChainInfo = ({
               onCancel={onCancel}
               submitText="Next"
             />
+            {capabilities && <RpcReport report={capabilities} />}
           </Tabs.Panel>
         </Tabs>
       </Card>
     </WizardForm>
   );
 };
+
+const RpcReport = ({ report }: { report: validation.RpcCapabilities }) => {
+  if (!report.reachable) {
+    return (
+      <Text size="sm" c="error" mt="xs">
+        {report.error || 'The RPC endpoint did not answer'}
+      </Text>
+    );
+  }
+  return (
+    <Text size="sm" c={report.chainIdMatches ? 'dimmed' : 'error'} mt="xs">
+      Chain {report.chainId} · {report.clientVersion || 'unknown client'} ·
+      block {report.headBlock} · traces {report.traces ? 'yes' : 'no'} ·
+      archive {report.archive ? 'yes' : 'no'} · {report.latencyMs}ms
+    </Text>
+  );
+};
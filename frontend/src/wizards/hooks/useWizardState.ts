@@ -113,8 +113,8 @@ export const useWizardState = () => {
 
     try {
       var chain = preferences.Chain.createFrom({
-        name: state.data.chainName,
-        id: state.data.chainId,
+        chain: state.data.chainName,
+        chainId: parseInt(state.data.chainId, 10),
         symbol: state.data.symbol,
         remoteExplorer: state.data.remoteExplorer,
         rpcProviders: [state.data.rpcUrl],
@@ -18,9 +18,16 @@ import {dresses} from '../models';
 import {exports} from '../models';
 import {monitors} from '../models';
 import {names} from '../models';
+import {portfolio} from '../models';
 import {projects} from '../models';
 import {status} from '../models';
 import {app} from '../models';
+import {bundle} from '../models';
+import {refresh} from '../models';
+import {rpcpool} from '../models';
+import {validation} from '../models';
+import {txbuilder} from '../models';
+import {typeddata} from '../models';
 
 export function AbisCrud(arg1:types.Payload,arg2:crud.Operation,arg3:any):Promise<void>;
 
@@ -28,12 +35,20 @@ export function AddAddressToProject(arg1:string):Promise<void>;
 
 export function AddAddressesToProject(arg1:string):Promise<void>;
 
+export function ApplyNamesImport(arg1:types.Payload,arg2:string,arg3:string):Promise<names.BulkBatch>;
+
+export function AutonameAddresses(arg1:types.Payload,arg2:Array<string>):Promise<string>;
+
 export function CancelFetches():Promise<number>;
 
 export function ChangeImageStorageLocation(arg1:string):Promise<void>;
 
 export function ChangeVisibility(arg1:types.Payload):Promise<void>;
 
+export function CheckRpcEndpoint(arg1:string,arg2:number):Promise<validation.RpcCapabilities>;
+
+export function CheckRpcProviders(arg1:string):Promise<Array<rpcpool.Check>>;
+
 export function ClearActiveProject():Promise<void>;
 
 export function ClearViewFacetState(arg1:project.ViewStateKey):Promise<void>;
@@ -56,8 +71,16 @@ export function ExecuteRowAction(arg1:types.RowActionPayload):Promise<void>;
 
 export function ExportData(arg1:types.Payload):Promise<void>;
 
+export function ExportProjectBundle(arg1:string):Promise<string>;
+
 export function ExportSkin(arg1:string):Promise<string>;
 
+export function ExportUnsignedTransaction(arg1:txbuilder.UnsignedTx):Promise<string>;
+
+export function FileExportBundle(arg1:menu.CallbackData):Promise<void>;
+
+export function FileImportBundle(arg1:menu.CallbackData):Promise<void>;
+
 export function FileNew(arg1:menu.CallbackData):Promise<void>;
 
 export function FileOpen(arg1:menu.CallbackData):Promise<void>;
@@ -90,6 +113,8 @@ export function GetAllDetailSectionStates():Promise<Record<string, boolean>>;
 
 export function GetAllSkins():Promise<Record<string, skin.Skin>>;
 
+export function GetApiServerURL():Promise<string>;
+
 export function GetAppId():Promise<preferences.Id>;
 
 export function GetAppPreferences():Promise<preferences.AppPreferences>;
@@ -156,6 +181,8 @@ export function GetExportsPage(arg1:types.Payload,arg2:number,arg3:number,arg4:s
 
 export function GetExportsSummary(arg1:types.Payload):Promise<types.Summary>;
 
+export function GetFiatCurrency():Promise<string>;
+
 export function GetFilename():Promise<project.Project>;
 
 export function GetFormat():Promise<string>;
@@ -174,6 +201,8 @@ export function GetLastView():Promise<string>;
 
 export function GetMarkdown(arg1:string,arg2:string,arg3:string):Promise<string>;
 
+export function GetMonitorRefresh():Promise<preferences.MonitorRefresh>;
+
 export function GetMonitorsBuckets(arg1:types.Payload):Promise<types.Buckets>;
 
 export function GetMonitorsConfig(arg1:types.Payload):Promise<types.ViewConfig>;
@@ -196,6 +225,14 @@ export function GetOpenProjects():Promise<Array<Record<string, any>>>;
 
 export function GetOrgPreferences():Promise<preferences.OrgPreferences>;
 
+export function GetPortfolioBuckets(arg1:types.Payload):Promise<types.Buckets>;
+
+export function GetPortfolioConfig(arg1:types.Payload):Promise<types.ViewConfig>;
+
+export function GetPortfolioPage(arg1:types.Payload,arg2:number,arg3:number,arg4:sdk.SortSpec,arg5:string):Promise<portfolio.PortfolioPage>;
+
+export function GetPortfolioSummary(arg1:types.Payload):Promise<types.Summary>;
+
 export function GetProjectAddress():Promise<base.Address>;
 
 export function GetProjectViewState(arg1:string):Promise<Record<string, project.ViewFacetState>>;
@@ -210,6 +247,8 @@ export function GetProjectsSummary(arg1:types.Payload):Promise<types.Summary>;
 
 export function GetRegisteredViews():Promise<Array<string>>;
 
+export function GetRpcHealth(arg1:string):Promise<Array<rpcpool.Check>>;
+
 export function GetSkin():Promise<string>;
 
 export function GetSkinByName(arg1:string):Promise<skin.Skin>;
@@ -236,8 +275,12 @@ export function HandleProjectsRowAction(arg1:types.RowActionPayload):Promise<voi
 
 export function HasActiveProject():Promise<boolean>;
 
+export function ImportProjectBundle(arg1:string,arg2:boolean):Promise<bundle.Report>;
+
 export function ImportSkin(arg1:string):Promise<void>;
 
+export function InspectTypedData(arg1:types.Payload,arg2:string):Promise<typeddata.Inspection>;
+
 export function IsDialogSilenced(arg1:string):Promise<boolean>;
 
 export function IsDisabled(arg1:string):Promise<boolean>;
@@ -266,8 +309,16 @@ export function OpenURL(arg1:string):Promise<void>;
 
 export function PrepareTransaction(arg1:types.Payload,arg2:app.PrepareTransactionRequest):Promise<app.PrepareTransactionResult>;
 
+export function PreviewNamesImport(arg1:string,arg2:string):Promise<names.BulkPreview>;
+
+export function ProbeBlooms(arg1:types.Payload,arg2:string,arg3:number,arg4:number,arg5:boolean):Promise<chunks.BloomProbeResult>;
+
+export function ReadContractTimeline(arg1:types.Payload,arg2:Array<number>,arg3:types.Period,arg4:number,arg5:number):Promise<contracts.ContractTimeline>;
+
 export function ReadToMe(arg1:types.Payload,arg2:string):Promise<string>;
 
+export function RefreshMonitorsNow(arg1:string):Promise<Array<refresh.Result>>;
+
 export function RegisterCollection(arg1:types.Collection):Promise<void>;
 
 export function Reload(arg1:types.Payload):Promise<void>;
@@ -288,6 +339,8 @@ export function ReloadMonitors(arg1:types.Payload):Promise<void>;
 
 export function ReloadNames(arg1:types.Payload):Promise<void>;
 
+export function ReloadPortfolio(arg1:types.Payload):Promise<void>;
+
 export function ReloadProjects(arg1:types.Payload):Promise<void>;
 
 export function ReloadSkins():Promise<void>;
@@ -298,6 +351,8 @@ export function RemoveAddressFromProject(arg1:string):Promise<void>;
 
 export function RestoreProjectContext(arg1:string):Promise<void>;
 
+export function RollbackNamesImport(arg1:types.Payload,arg2:string):Promise<void>;
+
 export function SaveBounds(arg1:number,arg2:number,arg3:number,arg4:number):Promise<void>;
 
 export function SaveProject():Promise<void>;
@@ -312,6 +367,8 @@ export function SetActivePeriod(arg1:types.Period):Promise<void>;
 
 export function SetActiveProjectPath(arg1:string):Promise<void>;
 
+export function SetApiPort(arg1:number):Promise<void>;
+
 export function SetAppPreferences(arg1:preferences.AppPreferences):Promise<void>;
 
 export function SetChain(arg1:preferences.Chain):Promise<void>;
@@ -326,6 +383,8 @@ export function SetDetailSectionState(arg1:string,arg2:boolean):Promise<void>;
 
 export function SetExportsMetric(arg1:string,arg2:string):Promise<void>;
 
+export function SetFiatCurrency(arg1:string):Promise<void>;
+
 export function SetFontScale(arg1:number):Promise<void>;
 
 export function SetFormat(arg1:string):Promise<void>;
@@ -342,8 +401,12 @@ export function SetLastView(arg1:string):Promise<string>;
 
 export function SetMenuCollapsed(arg1:boolean):Promise<void>;
 
+export function SetMonitorRefresh(arg1:boolean,arg2:number,arg3:Array<preferences.RefreshMonitor>):Promise<void>;
+
 export function SetOrgPreferences(arg1:preferences.OrgPreferences):Promise<void>;
 
+export function SetPriceFile(arg1:string):Promise<void>;
+
 export function SetProjectAddress(arg1:base.Address):Promise<void>;
 
 export function SetProjectViewState(arg1:string,arg2:Record<string, project.ViewFacetState>):Promise<void>;
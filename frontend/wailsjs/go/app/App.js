@@ -14,6 +14,14 @@ export function AddAddressesToProject(arg1) {
   return window['go']['app']['App']['AddAddressesToProject'](arg1);
 }
 
+export function ApplyNamesImport(arg1, arg2, arg3) {
+  return window['go']['app']['App']['ApplyNamesImport'](arg1, arg2, arg3);
+}
+
+export function AutonameAddresses(arg1, arg2) {
+  return window['go']['app']['App']['AutonameAddresses'](arg1, arg2);
+}
+
 export function CancelFetches() {
   return window['go']['app']['App']['CancelFetches']();
 }
@@ -26,6 +34,14 @@ export function ChangeVisibility(arg1) {
   return window['go']['app']['App']['ChangeVisibility'](arg1);
 }
 
+export function CheckRpcEndpoint(arg1, arg2) {
+  return window['go']['app']['App']['CheckRpcEndpoint'](arg1, arg2);
+}
+
+export function CheckRpcProviders(arg1) {
+  return window['go']['app']['App']['CheckRpcProviders'](arg1);
+}
+
 export function ClearActiveProject() {
   return window['go']['app']['App']['ClearActiveProject']();
 }
@@ -70,10 +86,26 @@ export function ExportData(arg1) {
   return window['go']['app']['App']['ExportData'](arg1);
 }
 
+export function ExportProjectBundle(arg1) {
+  return window['go']['app']['App']['ExportProjectBundle'](arg1);
+}
+
 export function ExportSkin(arg1) {
   return window['go']['app']['App']['ExportSkin'](arg1);
 }
 
+export function ExportUnsignedTransaction(arg1) {
+  return window['go']['app']['App']['ExportUnsignedTransaction'](arg1);
+}
+
+export function FileExportBundle(arg1) {
+  return window['go']['app']['App']['FileExportBundle'](arg1);
+}
+
+export function FileImportBundle(arg1) {
+  return window['go']['app']['App']['FileImportBundle'](arg1);
+}
+
 export function FileNew(arg1) {
   return window['go']['app']['App']['FileNew'](arg1);
 }
@@ -138,6 +170,10 @@ export function GetAllSkins() {
   return window['go']['app']['App']['GetAllSkins']();
 }
 
+export function GetApiServerURL() {
+  return window['go']['app']['App']['GetApiServerURL']();
+}
+
 export function GetAppId() {
   return window['go']['app']['App']['GetAppId']();
 }
@@ -270,6 +306,10 @@ export function GetExportsSummary(arg1) {
   return window['go']['app']['App']['GetExportsSummary'](arg1);
 }
 
+export function GetFiatCurrency() {
+  return window['go']['app']['App']['GetFiatCurrency']();
+}
+
 export function GetFilename() {
   return window['go']['app']['App']['GetFilename']();
 }
@@ -306,6 +346,10 @@ export function GetMarkdown(arg1, arg2, arg3) {
   return window['go']['app']['App']['GetMarkdown'](arg1, arg2, arg3);
 }
 
+export function GetMonitorRefresh() {
+  return window['go']['app']['App']['GetMonitorRefresh']();
+}
+
 export function GetMonitorsBuckets(arg1) {
   return window['go']['app']['App']['GetMonitorsBuckets'](arg1);
 }
@@ -350,6 +394,22 @@ export function GetOrgPreferences() {
   return window['go']['app']['App']['GetOrgPreferences']();
 }
 
+export function GetPortfolioBuckets(arg1) {
+  return window['go']['app']['App']['GetPortfolioBuckets'](arg1);
+}
+
+export function GetPortfolioConfig(arg1) {
+  return window['go']['app']['App']['GetPortfolioConfig'](arg1);
+}
+
+export function GetPortfolioPage(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['app']['App']['GetPortfolioPage'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function GetPortfolioSummary(arg1) {
+  return window['go']['app']['App']['GetPortfolioSummary'](arg1);
+}
+
 export function GetProjectAddress() {
   return window['go']['app']['App']['GetProjectAddress']();
 }
@@ -378,6 +438,10 @@ export function GetRegisteredViews() {
   return window['go']['app']['App']['GetRegisteredViews']();
 }
 
+export function GetRpcHealth(arg1) {
+  return window['go']['app']['App']['GetRpcHealth'](arg1);
+}
+
 export function GetSkin() {
   return window['go']['app']['App']['GetSkin']();
 }
@@ -430,10 +494,18 @@ export function HasActiveProject() {
   return window['go']['app']['App']['HasActiveProject']();
 }
 
+export function ImportProjectBundle(arg1, arg2) {
+  return window['go']['app']['App']['ImportProjectBundle'](arg1, arg2);
+}
+
 export function ImportSkin(arg1) {
   return window['go']['app']['App']['ImportSkin'](arg1);
 }
 
+export function InspectTypedData(arg1, arg2) {
+  return window['go']['app']['App']['InspectTypedData'](arg1, arg2);
+}
+
 export function IsDialogSilenced(arg1) {
   return window['go']['app']['App']['IsDialogSilenced'](arg1);
 }
@@ -490,10 +562,26 @@ export function PrepareTransaction(arg1, arg2) {
   return window['go']['app']['App']['PrepareTransaction'](arg1, arg2);
 }
 
+export function PreviewNamesImport(arg1, arg2) {
+  return window['go']['app']['App']['PreviewNamesImport'](arg1, arg2);
+}
+
+export function ProbeBlooms(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['app']['App']['ProbeBlooms'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function ReadContractTimeline(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['app']['App']['ReadContractTimeline'](arg1, arg2, arg3, arg4, arg5);
+}
+
 export function ReadToMe(arg1, arg2) {
   return window['go']['app']['App']['ReadToMe'](arg1, arg2);
 }
 
+export function RefreshMonitorsNow(arg1) {
+  return window['go']['app']['App']['RefreshMonitorsNow'](arg1);
+}
+
 export function RegisterCollection(arg1) {
   return window['go']['app']['App']['RegisterCollection'](arg1);
 }
@@ -534,6 +622,10 @@ export function ReloadNames(arg1) {
   return window['go']['app']['App']['ReloadNames'](arg1);
 }
 
+export function ReloadPortfolio(arg1) {
+  return window['go']['app']['App']['ReloadPortfolio'](arg1);
+}
+
 export function ReloadProjects(arg1) {
   return window['go']['app']['App']['ReloadProjects'](arg1);
 }
@@ -554,6 +646,10 @@ export function RestoreProjectContext(arg1) {
   return window['go']['app']['App']['RestoreProjectContext'](arg1);
 }
 
+export function RollbackNamesImport(arg1, arg2) {
+  return window['go']['app']['App']['RollbackNamesImport'](arg1, arg2);
+}
+
 export function SaveBounds(arg1, arg2, arg3, arg4) {
   return window['go']['app']['App']['SaveBounds'](arg1, arg2, arg3, arg4);
 }
@@ -582,6 +678,10 @@ export function SetActiveProjectPath(arg1) {
   return window['go']['app']['App']['SetActiveProjectPath'](arg1);
 }
 
+export function SetApiPort(arg1) {
+  return window['go']['app']['App']['SetApiPort'](arg1);
+}
+
 export function SetAppPreferences(arg1) {
   return window['go']['app']['App']['SetAppPreferences'](arg1);
 }
@@ -610,6 +710,10 @@ export function SetExportsMetric(arg1, arg2) {
   return window['go']['app']['App']['SetExportsMetric'](arg1, arg2);
 }
 
+export function SetFiatCurrency(arg1) {
+  return window['go']['app']['App']['SetFiatCurrency'](arg1);
+}
+
 export function SetFontScale(arg1) {
   return window['go']['app']['App']['SetFontScale'](arg1);
 }
@@ -642,10 +746,18 @@ export function SetMenuCollapsed(arg1) {
   return window['go']['app']['App']['SetMenuCollapsed'](arg1);
 }
 
+export function SetMonitorRefresh(arg1, arg2, arg3) {
+  return window['go']['app']['App']['SetMonitorRefresh'](arg1, arg2, arg3);
+}
+
 export function SetOrgPreferences(arg1) {
   return window['go']['app']['App']['SetOrgPreferences'](arg1);
 }
 
+export function SetPriceFile(arg1) {
+  return window['go']['app']['App']['SetPriceFile'](arg1);
+}
+
 export function SetProjectAddress(arg1) {
   return window['go']['app']['App']['SetProjectAddress'](arg1);
 }
@@ -51,6 +51,7 @@ export namespace app {
 	    from: string;
 	    to: string;
 	    value: string;
+	    feeModel?: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new PrepareTransactionRequest(source);
@@ -63,6 +64,7 @@ export namespace app {
 	        this.from = source["from"];
 	        this.to = source["to"];
 	        this.value = source["value"];
+	        this.feeModel = source["feeModel"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -89,6 +91,9 @@ export namespace app {
 	    gasEstimate: string;
 	    gasPrice: string;
 	    error?: string;
+	    unsigned?: txbuilder.UnsignedTx;
+	    unsignedError?: string;
+	    simulation?: txbuilder.Simulation;
 	
 	    static createFrom(source: any = {}) {
 	        return new PrepareTransactionResult(source);
@@ -101,7 +106,28 @@ export namespace app {
 	        this.gasEstimate = source["gasEstimate"];
 	        this.gasPrice = source["gasPrice"];
 	        this.error = source["error"];
-	    }
+	        this.unsigned = this.convertValues(source["unsigned"], txbuilder.UnsignedTx);
+	        this.unsignedError = source["unsignedError"];
+	        this.simulation = this.convertValues(source["simulation"], txbuilder.Simulation);
+	    }
+	
+	convertValues(a: any, classs: any, asMap: boolean = false): any {
+	    if (!a) {
+	        return a;
+	    }
+	    if (a.slice && a.map) {
+	        return (a as any[]).map(elem => this.convertValues(elem, classs));
+	    } else if ("object" === typeof a) {
+	        if (asMap) {
+	            for (const key of Object.keys(a)) {
+	                a[key] = new classs(a[key]);
+	            }
+	            return a;
+	        }
+	        return new classs(a);
+	    }
+	    return a;
+	}
 	}
 	export class UserInfoStatus {
 	    missingNameEmail: boolean;
@@ -149,12 +175,210 @@ export namespace base {
 
 }
 
+export namespace bundle {
+	
+	export class Conflict {
+	    kind: string;
+	    key: string;
+	    local: string;
+	    bundled: string;
+	    resolution: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Conflict(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.kind = source["kind"];
+	        this.key = source["key"];
+	        this.local = source["local"];
+	        this.bundled = source["bundled"];
+	        this.resolution = source["resolution"];
+	    }
+	}
+	export class Entry {
+	    kind: string;
+	    key: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Entry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.kind = source["kind"];
+	        this.key = source["key"];
+	    }
+	}
+	export class Failure {
+	    kind: string;
+	    key: string;
+	    error: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Failure(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.kind = source["kind"];
+	        this.key = source["key"];
+	        this.error = source["error"];
+	    }
+	}
+	export class Report {
+	    projectPath: string;
+	    added: Entry[];
+	    unchanged: Entry[];
+	    conflicts: Conflict[];
+	    failed: Failure[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Report(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.projectPath = source["projectPath"];
+	        this.added = this.convertValues(source["added"], Entry);
+	        this.unchanged = this.convertValues(source["unchanged"], Entry);
+	        this.conflicts = this.convertValues(source["conflicts"], Conflict);
+	        this.failed = this.convertValues(source["failed"], Failure);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
 export namespace chunks {
 	
+	export class BloomProbe {
+	    range: string;
+	    checked: boolean;
+	    appearances: number;
+	    falsePositive: boolean;
+	    expectedRate: number;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new BloomProbe(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.range = source["range"];
+	        this.checked = source["checked"];
+	        this.appearances = source["appearances"];
+	        this.falsePositive = source["falsePositive"];
+	        this.expectedRate = source["expectedRate"];
+	        this.error = source["error"];
+	    }
+	}
+	export class BloomProbeResult {
+	    address: string;
+	    firstBlock: number;
+	    lastBlock: number;
+	    confirmed: boolean;
+	    nChunks: number;
+	    nHits: number;
+	    nFalsePositives: number;
+	    falsePositiveRate: number;
+	    hits: BloomProbe[];
+	
+	    static createFrom(source: any = {}) {
+	        return new BloomProbeResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.address = source["address"];
+	        this.firstBlock = source["firstBlock"];
+	        this.lastBlock = source["lastBlock"];
+	        this.confirmed = source["confirmed"];
+	        this.nChunks = source["nChunks"];
+	        this.nHits = source["nHits"];
+	        this.nFalsePositives = source["nFalsePositives"];
+	        this.falsePositiveRate = source["falsePositiveRate"];
+	        this.hits = this.convertValues(source["hits"], BloomProbe);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Integrity {
+	    range: string;
+	    status: string;
+	    indexStatus: string;
+	    bloomStatus: string;
+	    indexHash: string;
+	    localIndexHash: string;
+	    indexSize: number;
+	    localIndexSize: number;
+	    bloomHash: string;
+	    localBloomHash: string;
+	    bloomSize: number;
+	    localBloomSize: number;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Integrity(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.range = source["range"];
+	        this.status = source["status"];
+	        this.indexStatus = source["indexStatus"];
+	        this.bloomStatus = source["bloomStatus"];
+	        this.indexHash = source["indexHash"];
+	        this.localIndexHash = source["localIndexHash"];
+	        this.indexSize = source["indexSize"];
+	        this.localIndexSize = source["localIndexSize"];
+	        this.bloomHash = source["bloomHash"];
+	        this.localBloomHash = source["localBloomHash"];
+	        this.bloomSize = source["bloomSize"];
+	        this.localBloomSize = source["localBloomSize"];
+	        this.error = source["error"];
+	    }
+	}
 	export class ChunksPage {
 	    facet: types.DataFacet;
 	    blooms: types.ChunkBloom[];
 	    index: types.ChunkIndex[];
+	    integrity: Integrity[];
 	    manifest: types.Manifest[];
 	    stats: types.ChunkStats[];
 	    totalItems: number;
@@ -170,6 +394,7 @@ export namespace chunks {
 	        this.facet = source["facet"];
 	        this.blooms = this.convertValues(source["blooms"], types.ChunkBloom);
 	        this.index = this.convertValues(source["index"], types.ChunkIndex);
+	        this.integrity = this.convertValues(source["integrity"], Integrity);
 	        this.manifest = this.convertValues(source["manifest"], types.Manifest);
 	        this.stats = this.convertValues(source["stats"], types.ChunkStats);
 	        this.totalItems = source["totalItems"];
@@ -200,24 +425,61 @@ export namespace chunks {
 
 export namespace comparitoor {
 	
+	export class Appearance {
+	    blockNumber: number;
+	    transactionIndex: number;
+	    hash: base.Hash;
+	    status: string;
+	    chifra: boolean;
+	    etherscan: boolean;
+	    covalent: boolean;
+	    alchemy: boolean;
+	    nSources: number;
+	    missing: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Appearance(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.blockNumber = source["blockNumber"];
+	        this.transactionIndex = source["transactionIndex"];
+	        this.hash = this.convertValues(source["hash"], base.Hash);
+	        this.status = source["status"];
+	        this.chifra = source["chifra"];
+	        this.etherscan = source["etherscan"];
+	        this.covalent = source["covalent"];
+	        this.alchemy = source["alchemy"];
+	        this.nSources = source["nSources"];
+	        this.missing = source["missing"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 	export class ComparitoorPage {
 	    facet: types.DataFacet;
+	    appearance: Appearance[];
 	    transaction: types.Transaction[];
 	    totalItems: number;
 	    expectedTotal: number;
 	    state: types.StoreState;
-	    chifra: types.Transaction[];
-	    chifraCount: number;
-	    etherscan: types.Transaction[];
-	    etherscanCount: number;
-	    covalent: types.Transaction[];
-	    covalentCount: number;
-	    alchemy: types.Transaction[];
-	    alchemyCount: number;
-	    overlapCount: number;
-	    unionCount: number;
-	    intersectionCount: number;
-	    overlapDetails?: Record<string, number>;
 	
 	    static createFrom(source: any = {}) {
 	        return new ComparitoorPage(source);
@@ -226,22 +488,11 @@ export namespace comparitoor {
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.facet = source["facet"];
+	        this.appearance = this.convertValues(source["appearance"], Appearance);
 	        this.transaction = this.convertValues(source["transaction"], types.Transaction);
 	        this.totalItems = source["totalItems"];
 	        this.expectedTotal = source["expectedTotal"];
 	        this.state = source["state"];
-	        this.chifra = this.convertValues(source["chifra"], types.Transaction);
-	        this.chifraCount = source["chifraCount"];
-	        this.etherscan = this.convertValues(source["etherscan"], types.Transaction);
-	        this.etherscanCount = source["etherscanCount"];
-	        this.covalent = this.convertValues(source["covalent"], types.Transaction);
-	        this.covalentCount = source["covalentCount"];
-	        this.alchemy = this.convertValues(source["alchemy"], types.Transaction);
-	        this.alchemyCount = source["alchemyCount"];
-	        this.overlapCount = source["overlapCount"];
-	        this.unionCount = source["unionCount"];
-	        this.intersectionCount = source["intersectionCount"];
-	        this.overlapDetails = source["overlapDetails"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -267,6 +518,62 @@ export namespace comparitoor {
 
 export namespace contracts {
 	
+	export class TimelinePoint {
+	    block: number;
+	    timestamp: number;
+	    label: string;
+	    results: Record<string, any>;
+	    errors?: Record<string, string>;
+	
+	    static createFrom(source: any = {}) {
+	        return new TimelinePoint(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.block = source["block"];
+	        this.timestamp = source["timestamp"];
+	        this.label = source["label"];
+	        this.results = source["results"];
+	        this.errors = source["errors"];
+	    }
+	}
+	export class ContractTimeline {
+	    address: string;
+	    period?: types.Period;
+	    keys: string[];
+	    points: TimelinePoint[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ContractTimeline(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.address = source["address"];
+	        this.period = source["period"];
+	        this.keys = source["keys"];
+	        this.points = this.convertValues(source["points"], TimelinePoint);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 	export class ContractsPage {
 	    facet: types.DataFacet;
 	    contracts: types.Contract[];
@@ -427,12 +734,99 @@ export namespace dresses {
 
 export namespace exports {
 	
+	export class FiatValue {
+	    currency: string;
+	    price: number;
+	    value: number;
+	    source: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new FiatValue(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.currency = source["currency"];
+	        this.price = source["price"];
+	        this.value = source["value"];
+	        this.source = source["source"];
+	    }
+	}
+	export class Gain {
+	    asset: base.Address;
+	    assetName?: string;
+	    symbol: string;
+	    decimals: number;
+	    blockNumber: number;
+	    transactionIndex: number;
+	    transactionHash: base.Hash;
+	    dateAcquired: number;
+	    various: boolean;
+	    dateSold: number;
+	    term: string;
+	    method: string;
+	    // Go type: base
+	    quantity: any;
+	    quantityEth: string;
+	    // Go type: base
+	    unmatched: any;
+	    proceeds: number;
+	    costBasis: number;
+	    realizedGain: number;
+	    unrealizedGain: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Gain(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.asset = this.convertValues(source["asset"], base.Address);
+	        this.assetName = source["assetName"];
+	        this.symbol = source["symbol"];
+	        this.decimals = source["decimals"];
+	        this.blockNumber = source["blockNumber"];
+	        this.transactionIndex = source["transactionIndex"];
+	        this.transactionHash = this.convertValues(source["transactionHash"], base.Hash);
+	        this.dateAcquired = source["dateAcquired"];
+	        this.various = source["various"];
+	        this.dateSold = source["dateSold"];
+	        this.term = source["term"];
+	        this.method = source["method"];
+	        this.quantity = this.convertValues(source["quantity"], null);
+	        this.quantityEth = source["quantityEth"];
+	        this.unmatched = this.convertValues(source["unmatched"], null);
+	        this.proceeds = source["proceeds"];
+	        this.costBasis = source["costBasis"];
+	        this.realizedGain = source["realizedGain"];
+	        this.unrealizedGain = source["unrealizedGain"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 	export class ExportsPage {
 	    facet: types.DataFacet;
 	    approvallogs: types.Log[];
 	    approvaltxs: types.Transaction[];
 	    assets: types.Statement[];
 	    balances: types.Token[];
+	    gains: Gain[];
 	    logs: types.Log[];
 	    openapprovals: types.Approval[];
 	    receipts: types.Receipt[];
@@ -444,6 +838,8 @@ export namespace exports {
 	    totalItems: number;
 	    expectedTotal: number;
 	    state: types.StoreState;
+	    currency?: string;
+	    fiat?: FiatValue[];
 	
 	    static createFrom(source: any = {}) {
 	        return new ExportsPage(source);
@@ -456,6 +852,7 @@ export namespace exports {
 	        this.approvaltxs = this.convertValues(source["approvaltxs"], types.Transaction);
 	        this.assets = this.convertValues(source["assets"], types.Statement);
 	        this.balances = this.convertValues(source["balances"], types.Token);
+	        this.gains = this.convertValues(source["gains"], Gain);
 	        this.logs = this.convertValues(source["logs"], types.Log);
 	        this.openapprovals = this.convertValues(source["openapprovals"], types.Approval);
 	        this.receipts = this.convertValues(source["receipts"], types.Receipt);
@@ -467,6 +864,8 @@ export namespace exports {
 	        this.totalItems = source["totalItems"];
 	        this.expectedTotal = source["expectedTotal"];
 	        this.state = source["state"];
+	        this.currency = source["currency"];
+	        this.fiat = this.convertValues(source["fiat"], FiatValue);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -754,12 +1153,33 @@ export namespace model {
 
 export namespace monitors {
 	
-	export class MonitorsPage {
-	    facet: types.DataFacet;
-	    monitors: types.Monitor[];
+	export class RefreshStatus {
+	    refreshScheduled: boolean;
+	    lastRefresh?: string;
+	    nextRefresh?: string;
+	    newRecords: number;
+	    refreshError?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RefreshStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.refreshScheduled = source["refreshScheduled"];
+	        this.lastRefresh = source["lastRefresh"];
+	        this.nextRefresh = source["nextRefresh"];
+	        this.newRecords = source["newRecords"];
+	        this.refreshError = source["refreshError"];
+	    }
+	}
+	export class MonitorsPage {
+	    facet: types.DataFacet;
+	    monitors: types.Monitor[];
 	    totalItems: number;
 	    expectedTotal: number;
 	    state: types.StoreState;
+	    refresh?: Record<string, RefreshStatus>;
 	
 	    static createFrom(source: any = {}) {
 	        return new MonitorsPage(source);
@@ -772,6 +1192,425 @@ export namespace monitors {
 	        this.totalItems = source["totalItems"];
 	        this.expectedTotal = source["expectedTotal"];
 	        this.state = source["state"];
+	        this.refresh = this.convertValues(source["refresh"], RefreshStatus, true);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace msgs {
+	
+	export enum EventType {
+	    STATUS = "statusbar:status",
+	    ERROR = "statusbar:error",
+	    MANAGER = "manager:change",
+	    PROJECT_MODAL = "project:modal",
+	    ADDRESS_CHANGED = "address:changed",
+	    CHAIN_CHANGED = "chain:changed",
+	    CONTRACT_CHANGED = "contract:changed",
+	    PERIOD_CHANGED = "period:changed",
+	    DATA_LOADED = "data:loaded",
+	    DATA_RELOADED = "data:reloaded",
+	    TAB_CYCLE = "hotkey:tab-cycle",
+	    IMAGES_CHANGED = "images:changed",
+	    PROJECT_OPENED = "project:opened",
+	    ROW_ACTION = "action:row",
+	    FACET_CHANGED = "facet:changed",
+	    PROJECT_CLOSED = "project:closed",
+	    PROJECT_SWITCHED = "project:switched",
+	    AUTONAME = "names:autoname",
+	}
+
+}
+
+export namespace names {
+	
+	export class BulkChange {
+	    op: crud.Operation;
+	    address: string;
+	    before?: types.Name;
+	    after: types.Name;
+	
+	    static createFrom(source: any = {}) {
+	        return new BulkChange(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.op = source["op"];
+	        this.address = source["address"];
+	        this.before = this.convertValues(source["before"], types.Name);
+	        this.after = this.convertValues(source["after"], types.Name);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class BulkBatch {
+	    id: string;
+	    appliedAt: string;
+	    changes: BulkChange[];
+	    rolledBack: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new BulkBatch(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.appliedAt = source["appliedAt"];
+	        this.changes = this.convertValues(source["changes"], BulkChange);
+	        this.rolledBack = source["rolledBack"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class BulkItem {
+	    line: number;
+	    address: string;
+	    name: string;
+	    tags: string;
+	    source: string;
+	    action: string;
+	    reason?: string;
+	    collision?: string;
+	    existing?: types.Name;
+	
+	    static createFrom(source: any = {}) {
+	        return new BulkItem(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.line = source["line"];
+	        this.address = source["address"];
+	        this.name = source["name"];
+	        this.tags = source["tags"];
+	        this.source = source["source"];
+	        this.action = source["action"];
+	        this.reason = source["reason"];
+	        this.collision = source["collision"];
+	        this.existing = this.convertValues(source["existing"], types.Name);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class BulkPreview {
+	    items: BulkItem[];
+	    creates: number;
+	    updates: number;
+	    skips: number;
+	    invalid: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new BulkPreview(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.items = this.convertValues(source["items"], BulkItem);
+	        this.creates = source["creates"];
+	        this.updates = source["updates"];
+	        this.skips = source["skips"];
+	        this.invalid = source["invalid"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class NamesPage {
+	    facet: types.DataFacet;
+	    names: types.Name[];
+	    totalItems: number;
+	    expectedTotal: number;
+	    state: types.StoreState;
+	
+	    static createFrom(source: any = {}) {
+	        return new NamesPage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.facet = source["facet"];
+	        this.names = this.convertValues(source["names"], types.Name);
+	        this.totalItems = source["totalItems"];
+	        this.expectedTotal = source["expectedTotal"];
+	        this.state = source["state"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace portfolio {
+	
+	export class Holding {
+	    asset: base.Address;
+	    assetName?: string;
+	    symbol: string;
+	    decimals: number;
+	    // Go type: base
+	    balance: any;
+	    balanceEth: string;
+	    spotPrice: number;
+	    value: number;
+	    holders: number;
+	    lastBlock: number;
+	    breakdown: Record<string, string>;
+	
+	    static createFrom(source: any = {}) {
+	        return new Holding(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.asset = this.convertValues(source["asset"], base.Address);
+	        this.assetName = source["assetName"];
+	        this.symbol = source["symbol"];
+	        this.decimals = source["decimals"];
+	        this.balance = this.convertValues(source["balance"], null);
+	        this.balanceEth = source["balanceEth"];
+	        this.spotPrice = source["spotPrice"];
+	        this.value = source["value"];
+	        this.holders = source["holders"];
+	        this.lastBlock = source["lastBlock"];
+	        this.breakdown = source["breakdown"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Movement {
+	    accountedFor: base.Address;
+	    accountedForName?: string;
+	    // Go type: base
+	    amountIn?: any;
+	    // Go type: base
+	    amountOut?: any;
+	    asset: base.Address;
+	    assetName?: string;
+	    // Go type: base
+	    begBal: any;
+	    blockNumber: number;
+	    // Go type: base
+	    correctAmountIn?: any;
+	    // Go type: base
+	    correctAmountOut?: any;
+	    // Go type: base
+	    correctBegBalIn?: any;
+	    // Go type: base
+	    correctBegBalOut?: any;
+	    // Go type: base
+	    correctEndBalIn?: any;
+	    // Go type: base
+	    correctEndBalOut?: any;
+	    correctingReasons?: string;
+	    decimals: number;
+	    // Go type: base
+	    endBal: any;
+	    // Go type: base
+	    gasOut?: any;
+	    // Go type: base
+	    internalIn?: any;
+	    // Go type: base
+	    internalOut?: any;
+	    logIndex: number;
+	    // Go type: base
+	    minerBaseRewardIn?: any;
+	    // Go type: base
+	    minerNephewRewardIn?: any;
+	    // Go type: base
+	    minerTxFeeIn?: any;
+	    // Go type: base
+	    minerUncleRewardIn?: any;
+	    // Go type: base
+	    prefundIn?: any;
+	    // Go type: base
+	    prevBal?: any;
+	    priceSource: string;
+	    recipient: base.Address;
+	    recipientName?: string;
+	    // Go type: base
+	    selfDestructIn?: any;
+	    // Go type: base
+	    selfDestructOut?: any;
+	    sender: base.Address;
+	    senderName?: string;
+	    // Go type: base
+	    spotPrice: any;
+	    symbol: string;
+	    timestamp: number;
+	    transactionHash: base.Hash;
+	    transactionIndex: number;
+	    calcs?: types.StatementCalcs;
+	    correctionId: number;
+	    holder: base.Address;
+	    statementId: number;
+    internal: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Movement(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.accountedFor = this.convertValues(source["accountedFor"], base.Address);
+	        this.accountedForName = source["accountedForName"];
+	        this.amountIn = this.convertValues(source["amountIn"], null);
+	        this.amountOut = this.convertValues(source["amountOut"], null);
+	        this.asset = this.convertValues(source["asset"], base.Address);
+	        this.assetName = source["assetName"];
+	        this.begBal = this.convertValues(source["begBal"], null);
+	        this.blockNumber = source["blockNumber"];
+	        this.correctAmountIn = this.convertValues(source["correctAmountIn"], null);
+	        this.correctAmountOut = this.convertValues(source["correctAmountOut"], null);
+	        this.correctBegBalIn = this.convertValues(source["correctBegBalIn"], null);
+	        this.correctBegBalOut = this.convertValues(source["correctBegBalOut"], null);
+	        this.correctEndBalIn = this.convertValues(source["correctEndBalIn"], null);
+	        this.correctEndBalOut = this.convertValues(source["correctEndBalOut"], null);
+	        this.correctingReasons = source["correctingReasons"];
+	        this.decimals = source["decimals"];
+	        this.endBal = this.convertValues(source["endBal"], null);
+	        this.gasOut = this.convertValues(source["gasOut"], null);
+	        this.internalIn = this.convertValues(source["internalIn"], null);
+	        this.internalOut = this.convertValues(source["internalOut"], null);
+	        this.logIndex = source["logIndex"];
+	        this.minerBaseRewardIn = this.convertValues(source["minerBaseRewardIn"], null);
+	        this.minerNephewRewardIn = this.convertValues(source["minerNephewRewardIn"], null);
+	        this.minerTxFeeIn = this.convertValues(source["minerTxFeeIn"], null);
+	        this.minerUncleRewardIn = this.convertValues(source["minerUncleRewardIn"], null);
+	        this.prefundIn = this.convertValues(source["prefundIn"], null);
+	        this.prevBal = this.convertValues(source["prevBal"], null);
+	        this.priceSource = source["priceSource"];
+	        this.recipient = this.convertValues(source["recipient"], base.Address);
+	        this.recipientName = source["recipientName"];
+	        this.selfDestructIn = this.convertValues(source["selfDestructIn"], null);
+	        this.selfDestructOut = this.convertValues(source["selfDestructOut"], null);
+	        this.sender = this.convertValues(source["sender"], base.Address);
+	        this.senderName = source["senderName"];
+	        this.spotPrice = this.convertValues(source["spotPrice"], null);
+	        this.symbol = source["symbol"];
+	        this.timestamp = source["timestamp"];
+	        this.transactionHash = this.convertValues(source["transactionHash"], base.Hash);
+	        this.transactionIndex = source["transactionIndex"];
+	        this.calcs = this.convertValues(source["calcs"], types.StatementCalcs);
+	        this.correctionId = source["correctionId"];
+	        this.holder = this.convertValues(source["holder"], base.Address);
+	        this.statementId = source["statementId"];
+        this.internal = source["internal"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -792,50 +1631,25 @@ export namespace monitors {
 		    return a;
 		}
 	}
-
-}
-
-export namespace msgs {
-	
-	export enum EventType {
-	    STATUS = "statusbar:status",
-	    ERROR = "statusbar:error",
-	    MANAGER = "manager:change",
-	    PROJECT_MODAL = "project:modal",
-	    ADDRESS_CHANGED = "address:changed",
-	    CHAIN_CHANGED = "chain:changed",
-	    CONTRACT_CHANGED = "contract:changed",
-	    PERIOD_CHANGED = "period:changed",
-	    DATA_LOADED = "data:loaded",
-	    DATA_RELOADED = "data:reloaded",
-	    TAB_CYCLE = "hotkey:tab-cycle",
-	    IMAGES_CHANGED = "images:changed",
-	    PROJECT_OPENED = "project:opened",
-	    ROW_ACTION = "action:row",
-	    FACET_CHANGED = "facet:changed",
-	    PROJECT_CLOSED = "project:closed",
-	    PROJECT_SWITCHED = "project:switched",
-	}
-
-}
-
-export namespace names {
-	
-	export class NamesPage {
+	export class PortfolioPage {
 	    facet: types.DataFacet;
-	    names: types.Name[];
+	    assets: Holding[];
+	    balances: Holding[];
+	    statements: Movement[];
 	    totalItems: number;
 	    expectedTotal: number;
 	    state: types.StoreState;
 	
 	    static createFrom(source: any = {}) {
-	        return new NamesPage(source);
+	        return new PortfolioPage(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.facet = source["facet"];
-	        this.names = this.convertValues(source["names"], types.Name);
+	        this.assets = this.convertValues(source["assets"], Holding);
+	        this.balances = this.convertValues(source["balances"], Holding);
+	        this.statements = this.convertValues(source["statements"], Movement);
 	        this.totalItems = source["totalItems"];
 	        this.expectedTotal = source["expectedTotal"];
 	        this.state = source["state"];
@@ -896,6 +1710,56 @@ export namespace preferences {
 	        this.isActive = source["isActive"];
 	    }
 	}
+	export class RefreshMonitor {
+	    chain: string;
+	    address: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RefreshMonitor(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.chain = source["chain"];
+	        this.address = source["address"];
+	    }
+	}
+	export class MonitorRefresh {
+	    enabled: boolean;
+	    intervalMinutes?: number;
+	    monitors: RefreshMonitor[];
+	    results?: refresh.Result[];
+	
+	    static createFrom(source: any = {}) {
+	        return new MonitorRefresh(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.intervalMinutes = source["intervalMinutes"];
+	        this.monitors = this.convertValues(source["monitors"], RefreshMonitor);
+	        this.results = this.convertValues(source["results"], refresh.Result);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 	export class AppPreferences {
 	    version: string;
 	    name: string;
@@ -913,6 +1777,11 @@ export namespace preferences {
 	    silencedDialogs: Record<string, boolean>;
 	    chunksMetrics?: Record<string, string>;
 	    exportsMetrics?: Record<string, string>;
+	    comparitoorSources?: Record<string, string>;
+	    fiatCurrency?: string;
+	    priceFile?: string;
+	    apiPort?: number;
+	    monitorRefresh?: MonitorRefresh;
 	    sectionStates: Record<string, boolean>;
 	    bounds?: Bounds;
 	    fontScale: number;
@@ -940,6 +1809,11 @@ export namespace preferences {
 	        this.silencedDialogs = source["silencedDialogs"];
 	        this.chunksMetrics = source["chunksMetrics"];
 	        this.exportsMetrics = source["exportsMetrics"];
+	        this.comparitoorSources = source["comparitoorSources"];
+	        this.fiatCurrency = source["fiatCurrency"];
+	        this.priceFile = source["priceFile"];
+	        this.apiPort = source["apiPort"];
+	        this.monitorRefresh = this.convertValues(source["monitorRefresh"], MonitorRefresh);
 	        this.sectionStates = source["sectionStates"];
 	        this.bounds = this.convertValues(source["bounds"], Bounds);
 	        this.fontScale = source["fontScale"];
@@ -1279,6 +2153,92 @@ export namespace prompt {
 
 }
 
+export namespace refresh {
+	
+	export class Result {
+	    chain: string;
+	    address: string;
+	    // Go type: time
+	    lastRun: any;
+	    // Go type: time
+	    nextRun: any;
+	    nRecords: number;
+	    newRecords: number;
+	    failures: number;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Result(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.chain = source["chain"];
+	        this.address = source["address"];
+	        this.lastRun = this.convertValues(source["lastRun"], null);
+	        this.nextRun = this.convertValues(source["nextRun"], null);
+	        this.nRecords = source["nRecords"];
+	        this.newRecords = source["newRecords"];
+	        this.failures = source["failures"];
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace rpcpool {
+	
+	export class Check {
+	    chain: string;
+	    url: string;
+	    checkedAt: number;
+	    healthy: boolean;
+	    selected: boolean;
+	    latencyMs: number;
+	    chainId: number;
+	    headBlock: number;
+	    lag: number;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Check(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.chain = source["chain"];
+	        this.url = source["url"];
+	        this.checkedAt = source["checkedAt"];
+	        this.healthy = source["healthy"];
+	        this.selected = source["selected"];
+	        this.latencyMs = source["latencyMs"];
+	        this.chainId = source["chainId"];
+	        this.headBlock = source["headBlock"];
+	        this.lag = source["lag"];
+	        this.error = source["error"];
+	    }
+	}
+
+}
+
 export namespace sdk {
 	
 	export class SortSpec {
@@ -1385,6 +2345,7 @@ export namespace status {
 	    facet: types.DataFacet;
 	    caches: types.CacheItem[];
 	    chains: types.Chain[];
+	    health: rpcpool.Check[];
 	    status: types.Status[];
 	    totalItems: number;
 	    expectedTotal: number;
@@ -1399,6 +2360,7 @@ export namespace status {
 	        this.facet = source["facet"];
 	        this.caches = this.convertValues(source["caches"], types.CacheItem);
 	        this.chains = this.convertValues(source["chains"], types.Chain);
+	        this.health = this.convertValues(source["health"], rpcpool.Check);
 	        this.status = this.convertValues(source["status"], types.Status);
 	        this.totalItems = source["totalItems"];
 	        this.expectedTotal = source["expectedTotal"];
@@ -1426,6 +2388,174 @@ export namespace status {
 
 }
 
+export namespace txbuilder {
+	
+	export class Simulation {
+	    success: boolean;
+	    outputs?: types.Parameter[];
+	    returnData?: string;
+	    reverted?: boolean;
+	    revertReason?: string;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Simulation(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.outputs = this.convertValues(source["outputs"], types.Parameter);
+	        this.returnData = source["returnData"];
+	        this.reverted = source["reverted"];
+	        this.revertReason = source["revertReason"];
+	        this.error = source["error"];
+	    }
+	
+	convertValues(a: any, classs: any, asMap: boolean = false): any {
+	    if (!a) {
+	        return a;
+	    }
+	    if (a.slice && a.map) {
+	        return (a as any[]).map(elem => this.convertValues(elem, classs));
+	    } else if ("object" === typeof a) {
+	        if (asMap) {
+	            for (const key of Object.keys(a)) {
+	                a[key] = new classs(a[key]);
+	            }
+	            return a;
+	        }
+	        return new classs(a);
+	    }
+	    return a;
+	}
+	}
+	export class UnsignedTx {
+	    type: string;
+	    chainId: number;
+	    nonce: number;
+	    from: string;
+	    to?: string;
+	    value: string;
+	    data: string;
+	    gas: string;
+	    gasPrice?: string;
+	    maxFeePerGas?: string;
+	    maxPriorityFeePerGas?: string;
+	    payload: string;
+	    signingHash: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new UnsignedTx(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.type = source["type"];
+	        this.chainId = source["chainId"];
+	        this.nonce = source["nonce"];
+	        this.from = source["from"];
+	        this.to = source["to"];
+	        this.value = source["value"];
+	        this.data = source["data"];
+	        this.gas = source["gas"];
+	        this.gasPrice = source["gasPrice"];
+	        this.maxFeePerGas = source["maxFeePerGas"];
+	        this.maxPriorityFeePerGas = source["maxPriorityFeePerGas"];
+	        this.payload = source["payload"];
+	        this.signingHash = source["signingHash"];
+	    }
+	}
+
+}
+
+export namespace typeddata {
+	
+	export class Field {
+	    name: string;
+	    type: string;
+	    value?: string;
+	    addressName?: string;
+	    fields?: Field[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Field(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.type = source["type"];
+	        this.value = source["value"];
+	        this.addressName = source["addressName"];
+	        this.fields = this.convertValues(source["fields"], Field);
+	    }
+	
+	convertValues(a: any, classs: any, asMap: boolean = false): any {
+	    if (!a) {
+	        return a;
+	    }
+	    if (a.slice && a.map) {
+	        return (a as any[]).map(elem => this.convertValues(elem, classs));
+	    } else if ("object" === typeof a) {
+	        if (asMap) {
+	            for (const key of Object.keys(a)) {
+	                a[key] = new classs(a[key]);
+	            }
+	            return a;
+	        }
+	        return new classs(a);
+	    }
+	    return a;
+	}
+	}
+	export class Inspection {
+	    primaryType: string;
+	    encodedType: string;
+	    domainSeparator: string;
+	    structHash: string;
+	    signingHash: string;
+	    domain: Field[];
+	    message: Field[];
+	    warnings?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Inspection(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.primaryType = source["primaryType"];
+	        this.encodedType = source["encodedType"];
+	        this.domainSeparator = source["domainSeparator"];
+	        this.structHash = source["structHash"];
+	        this.signingHash = source["signingHash"];
+	        this.domain = this.convertValues(source["domain"], Field);
+	        this.message = this.convertValues(source["message"], Field);
+	        this.warnings = source["warnings"];
+	    }
+	
+	convertValues(a: any, classs: any, asMap: boolean = false): any {
+	    if (!a) {
+	        return a;
+	    }
+	    if (a.slice && a.map) {
+	        return (a as any[]).map(elem => this.convertValues(elem, classs));
+	    } else if ("object" === typeof a) {
+	        if (asMap) {
+	            for (const key of Object.keys(a)) {
+	                a[key] = new classs(a[key]);
+	            }
+	            return a;
+	        }
+	        return new classs(a);
+	    }
+	    return a;
+	}
+	}
+
+}
+
 export namespace types {
 	
 	export enum DataFacet {
@@ -1433,6 +2563,7 @@ export namespace types {
 	    INDEX = "index",
 	    BLOOMS = "blooms",
 	    MANIFEST = "manifest",
+	    INTEGRITY = "integrity",
 	    ALL = "all",
 	    CUSTOM = "custom",
 	    PREFUND = "prefund",
@@ -1458,6 +2589,7 @@ export namespace types {
 	    ASSETS = "assets",
 	    ASSETCHARTS = "assetcharts",
 	    BALANCES = "balances",
+	    GAINS = "gains",
 	    TRANSFERS = "transfers",
 	    OPENAPPROVALS = "openapprovals",
 	    APPROVALTXS = "approvaltxs",
@@ -1472,11 +2604,13 @@ export namespace types {
 	    STATUS = "status",
 	    CACHES = "caches",
 	    CHAINS = "chains",
+	    HEALTH = "health",
 	}
 	export enum StoreState {
 	    STALE = "stale",
 	    FETCHING = "fetching",
 	    LOADED = "loaded",
+	    CACHED = "cached",
 	}
 	export enum Period {
 	    BLOCKLY = "blockly",
@@ -3021,6 +4155,7 @@ export namespace types {
 	    targetSwitch?: boolean;
 	    format?: string;
 	    projectPath?: string;
+	    sort?: sdk.SortSpec;
 	
 	    static createFrom(source: any = {}) {
 	        return new Payload(source);
@@ -3039,7 +4174,26 @@ export namespace types {
 	        this.targetSwitch = source["targetSwitch"];
 	        this.format = source["format"];
 	        this.projectPath = source["projectPath"];
+	        this.sort = this.convertValues(source["sort"], sdk.SortSpec);
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
 	export class ProjectPayload {
 	    hasProject: boolean;
@@ -3214,6 +4368,7 @@ export namespace types {
 	    targetSwitch?: boolean;
 	    format?: string;
 	    projectPath?: string;
+	    sort?: sdk.SortSpec;
 	    rowData: Record<string, any>;
 	    rowAction?: RowActionConfig;
 	    contextValues?: Record<string, any>;
@@ -3235,6 +4390,7 @@ export namespace types {
 	        this.targetSwitch = source["targetSwitch"];
 	        this.format = source["format"];
 	        this.projectPath = source["projectPath"];
+	        this.sort = this.convertValues(source["sort"], sdk.SortSpec);
 	        this.rowData = source["rowData"];
 	        this.rowAction = this.convertValues(source["rowAction"], RowActionConfig);
 	        this.contextValues = source["contextValues"];
@@ -4420,3 +5576,40 @@ export namespace utils {
 
 }
 
+export namespace validation {
+	
+	export class RpcCapabilities {
+	    url: string;
+	    reachable: boolean;
+	    chainId: number;
+	    expectedChainId: number;
+	    chainIdMatches: boolean;
+	    clientVersion: string;
+	    headBlock: number;
+	    traces: boolean;
+	    archive: boolean;
+	    latencyMs: number;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RpcCapabilities(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.url = source["url"];
+	        this.reachable = source["reachable"];
+	        this.chainId = source["chainId"];
+	        this.expectedChainId = source["expectedChainId"];
+	        this.chainIdMatches = source["chainIdMatches"];
+	        this.clientVersion = source["clientVersion"];
+	        this.headBlock = source["headBlock"];
+	        this.traces = source["traces"];
+	        this.archive = source["archive"];
+	        this.latencyMs = source["latencyMs"];
+	        this.error = source["error"];
+	    }
+	}
+
+}
+
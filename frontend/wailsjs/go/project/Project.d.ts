@@ -72,5 +72,3 @@ export function SetViewFacetState(arg1:project.ViewStateKey,arg2:project.ViewFac
 export function SetViewStates(arg1:string,arg2:Record<string, project.ViewFacetState>):Promise<void>;
 
 export function String():Promise<string>;
-
-export function UnmarshalJSON(arg1:Array<number>):Promise<void>;
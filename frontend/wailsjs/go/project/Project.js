@@ -137,7 +137,3 @@ export function SetViewStates(arg1, arg2) {
 export function String() {
   return window['go']['project']['Project']['String']();
 }
-
-export function UnmarshalJSON(arg1) {
-  return window['go']['project']['Project']['UnmarshalJSON'](arg1);
-}
@@ -10,6 +10,7 @@ package main
 import (
 	"embed"
 	"fmt"
+	"os"
 
 	"github.com/TrueBlocks/trueblocks-explorer/app"
 	"github.com/TrueBlocks/trueblocks-explorer/pkg/msgs"
@@ -24,6 +25,7 @@ import (
 	"github.com/TrueBlocks/trueblocks-explorer/pkg/types/exports"
 	"github.com/TrueBlocks/trueblocks-explorer/pkg/types/monitors"
 	"github.com/TrueBlocks/trueblocks-explorer/pkg/types/names"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types/portfolio"
 	"github.com/TrueBlocks/trueblocks-explorer/pkg/types/projects"
 	"github.com/TrueBlocks/trueblocks-explorer/pkg/types/status"
 	//
@@ -38,6 +40,10 @@ var assets embed.FS
 
 func main() {
 	preferences.LoadIdentifiers(assets)
+	// `export` runs a batch export without opening the window
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		os.Exit(app.RunExport(assets, os.Args[2:], os.Stdout, os.Stderr))
+	}
 	a, menu := app.NewApp(assets)
 
 	opts := options.App{
@@ -59,6 +65,7 @@ func main() {
 			&project.Project{},
 			&projects.ProjectsCollection{},
 			&exports.ExportsCollection{},
+			&portfolio.PortfolioCollection{},
 			&monitors.MonitorsCollection{},
 			&abis.AbisCollection{},
 			&names.NamesCollection{},
@@ -0,0 +1,153 @@
+// Package apiserver is an optional HTTP server, bound to 127.0.0.1, that exposes the
+// application's collections as a JSON API so that scripts and other tools can read the
+// same data the frontend shows without the window being open.
+//
+// Every registered collection is available under /api/{collection}:
+//
+//	GET /api/views                               the registered collections
+//	GET /api/{collection}/config                 the view's ViewConfig
+//	GET /api/{collection}/{facet}/page           a page of rows (first, pageSize, sort, filter)
+//	GET /api/{collection}/{facet}/summary        the facet's summary
+//	GET /api/{collection}/{facet}/buckets        the facet's chart buckets
+//	POST /api/{collection}/{facet}/export        the facet exported as csv, txt or json
+//	GET /api/events                              Server-Sent Events for data loads and reloads
+//
+// The payload of every call is built from the query parameters chain, address, contract,
+// period and format; anything left out is taken from the active project. Export is a POST
+// because it writes a file. Requests from web pages (any request with an Origin header)
+// are refused.
+package apiserver
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
+)
+
+// DefaultPort is the first port the server tries when none is configured
+const DefaultPort = 8190
+
+// Backend is what the server serves. The application implements it.
+type Backend interface {
+	// Views returns the names of the registered collections
+	Views() []string
+	// Collection returns the collection the payload names or nil if there is none
+	Collection(payload *types.Payload) types.Collection
+	// Page returns a page of the payload's facet, as the frontend would receive it
+	Page(payload *types.Payload, first, pageSize int, sort sdk.SortSpec, filter string) (types.Page, error)
+	// Complete fills in the parts of the payload the caller left out from the active project
+	Complete(payload *types.Payload)
+}
+
+// Server serves a Backend over HTTP
+type Server struct {
+	server  *http.Server
+	backend Backend
+	events  *broker
+	port    int
+	running bool
+	mutex   sync.Mutex
+}
+
+// NewServer creates a server for backend. It does not listen until Start is called.
+func NewServer(backend Backend) *Server {
+	return &Server{
+		backend: backend,
+		events:  newBroker(),
+	}
+}
+
+// Start listens on 127.0.0.1 at the first free port at or above port (DefaultPort if zero)
+func (s *Server) Start(port int) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.running {
+		return nil // Already running
+	}
+	if s.backend == nil {
+		return fmt.Errorf("api server requires a backend")
+	}
+
+	if port <= 0 {
+		port = DefaultPort
+	}
+	ln, err := listen(port)
+	if err != nil {
+		return fmt.Errorf("failed to find available port: %w", err)
+	}
+	s.port = ln.Addr().(*net.TCPAddr).Port
+
+	s.events.start()
+	server := &http.Server{
+		Handler:           LocalOnlyMiddleware(s.Handler()),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	s.server = server
+	s.running = true
+
+	go func() {
+		log.Printf("API server started at http://127.0.0.1:%d/api/", s.port)
+		if err := server.Serve(ln); err != http.ErrServerClosed {
+			log.Printf("API server error: %v", err)
+		}
+		s.mutex.Lock()
+		if s.server == server { // a restarted server is not ours to mark stopped
+			s.running = false
+		}
+		s.mutex.Unlock()
+	}()
+
+	return nil
+}
+
+// Stop closes the event streams and gracefully shuts the server down
+func (s *Server) Stop() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if !s.running || s.server == nil {
+		return nil // Not running, nothing to do
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	log.Printf("Stopping API server on port %d", s.port)
+	s.events.stop()
+	if err := s.server.Shutdown(ctx); err != nil {
+		return fmt.Errorf("error shutting down api server: %w", err)
+	}
+
+	s.running = false
+	return nil
+}
+
+// GetBaseURL returns the URL the API is served under, or an empty string if the server is
+// not running
+func (s *Server) GetBaseURL() string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if !s.running {
+		return ""
+	}
+	return fmt.Sprintf("http://127.0.0.1:%d/api/", s.port)
+}
+
+// listen binds the first free port in the hundred starting at basePort
+func listen(basePort int) (net.Listener, error) {
+	for port := basePort; port < basePort+100; port++ {
+		if ln, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port)); err == nil {
+			return ln, nil
+		}
+	}
+	return nil, fmt.Errorf("no available ports found in range %d-%d", basePort, basePort+100)
+}
@@ -0,0 +1,229 @@
+package apiserver
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/msgs"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
+)
+
+type fakePage struct {
+	Facet    types.DataFacet `json:"facet"`
+	First    int             `json:"first"`
+	PageSize int             `json:"pageSize"`
+	Sort     sdk.SortSpec    `json:"sort"`
+	Filter   string          `json:"filter"`
+	Chain    string          `json:"chain"`
+}
+
+func (p *fakePage) GetFacet() types.DataFacet  { return p.Facet }
+func (p *fakePage) GetTotalItems() int         { return 0 }
+func (p *fakePage) GetExpectedTotal() int      { return 0 }
+func (p *fakePage) GetState() types.StoreState { return types.StateLoaded }
+
+type fakeCollection struct {
+	exportPath string
+}
+
+func (c *fakeCollection) GetPage(*types.Payload, int, int, sdk.SortSpec, string) (types.Page, error) {
+	return nil, nil
+}
+func (c *fakeCollection) FetchByFacet(*types.Payload)     {}
+func (c *fakeCollection) Reset(*types.Payload)            {}
+func (c *fakeCollection) NeedsUpdate(*types.Payload) bool { return false }
+func (c *fakeCollection) GetSummary(payload *types.Payload) types.Summary {
+	return types.Summary{TotalCount: 7}
+}
+func (c *fakeCollection) ExportData(payload *types.Payload) (string, error) {
+	return c.exportPath, os.WriteFile(c.exportPath, []byte("a,b\n1,2\n"), 0644)
+}
+func (c *fakeCollection) ChangeVisibility(*types.Payload) error      { return nil }
+func (c *fakeCollection) AccumulateItem(interface{}, *types.Summary) {}
+func (c *fakeCollection) ResetSummary()                              {}
+func (c *fakeCollection) GetBuckets(*types.Payload) (*types.Buckets, error) {
+	return &types.Buckets{}, nil
+}
+func (c *fakeCollection) GetConfig() (*types.ViewConfig, error) {
+	return &types.ViewConfig{
+		ViewName: "things",
+		Facets:   map[string]types.FacetConfig{"all": {Name: "All"}},
+	}, nil
+}
+
+type fakeBackend struct {
+	collection *fakeCollection
+}
+
+func (b *fakeBackend) Views() []string { return []string{"things"} }
+func (b *fakeBackend) Collection(payload *types.Payload) types.Collection {
+	return b.collection
+}
+func (b *fakeBackend) Complete(payload *types.Payload) {
+	if payload.ActiveChain == "" {
+		payload.ActiveChain = "mainnet"
+	}
+}
+func (b *fakeBackend) Page(payload *types.Payload, first, pageSize int, sort sdk.SortSpec, filter string) (types.Page, error) {
+	return &fakePage{Facet: payload.DataFacet, First: first, PageSize: pageSize, Sort: sort, Filter: filter, Chain: payload.ActiveChain}, nil
+}
+
+func newTestServer(t *testing.T) *httptest.Server {
+	backend := &fakeBackend{collection: &fakeCollection{exportPath: filepath.Join(t.TempDir(), "things-all.csv")}}
+	s := NewServer(backend)
+	s.events.start()
+	t.Cleanup(s.events.stop)
+	ts := httptest.NewServer(LocalOnlyMiddleware(s.Handler()))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func getJSON(t *testing.T, url string, v any) int {
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(v))
+	return resp.StatusCode
+}
+
+func TestRoutes(t *testing.T) {
+	ts := newTestServer(t)
+
+	var views []string
+	assert.Equal(t, http.StatusOK, getJSON(t, ts.URL+"/api/views", &views))
+	assert.Equal(t, []string{"things"}, views)
+
+	var cfg types.ViewConfig
+	assert.Equal(t, http.StatusOK, getJSON(t, ts.URL+"/api/things/config", &cfg))
+	assert.Equal(t, "things", cfg.ViewName)
+
+	var page fakePage
+	assert.Equal(t, http.StatusOK, getJSON(t, ts.URL+"/api/things/all/page?first=10&pageSize=5&sort=name,-value&filter=abc", &page))
+	assert.Equal(t, fakePage{
+		Facet: "all", First: 10, PageSize: 5, Filter: "abc", Chain: "mainnet",
+		Sort: sdk.SortSpec{Fields: []string{"name", "value"}, Order: []sdk.SortOrder{sdk.Asc, sdk.Dec}},
+	}, page)
+
+	var summary types.Summary
+	assert.Equal(t, http.StatusOK, getJSON(t, ts.URL+"/api/things/all/summary", &summary))
+	assert.Equal(t, 7, summary.TotalCount)
+
+	var buckets types.Buckets
+	assert.Equal(t, http.StatusOK, getJSON(t, ts.URL+"/api/things/all/buckets", &buckets))
+
+	resp, err := http.Post(ts.URL+"/api/things/all/export", "", nil)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "text/csv; charset=utf-8", resp.Header.Get("Content-Type"))
+	body, _ := bufio.NewReader(resp.Body).ReadString(0)
+	assert.Equal(t, "a,b\n1,2\n", body)
+}
+
+func TestErrors(t *testing.T) {
+	ts := newTestServer(t)
+
+	var body map[string]string
+	assert.Equal(t, http.StatusNotFound, getJSON(t, ts.URL+"/api/nothing/config", &body))
+	assert.Contains(t, body["error"], "unknown collection")
+	assert.Equal(t, http.StatusNotFound, getJSON(t, ts.URL+"/api/things/none/page", &body))
+	assert.Contains(t, body["error"], "unknown facet")
+	assert.Equal(t, http.StatusBadRequest, getJSON(t, ts.URL+"/api/things/all/page?pageSize=0", &body))
+
+	resp, err := http.Post(ts.URL+"/api/things/all/export?format=xls", "", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp, err = http.Get(ts.URL + "/api/things/all/export")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode, "export writes a file so it must be posted")
+
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+"/api/views", nil)
+	req.Host = "attacker.example.com"
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	req, _ = http.NewRequest(http.MethodPost, ts.URL+"/api/things/all/export", nil)
+	req.Header.Set("Origin", "https://attacker.example.com")
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	assert.Empty(t, resp.Header.Get("Access-Control-Allow-Origin"))
+}
+
+func TestEventStream(t *testing.T) {
+	ts := newTestServer(t)
+
+	resp, err := http.Get(ts.URL + "/api/events?collection=things")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	reader := bufio.NewReader(resp.Body)
+	line, err := reader.ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, ": connected\n", line)
+
+	msgs.EmitStatus("not streamed")
+	msgs.EmitLoaded(types.DataLoadedPayload{Payload: types.Payload{Collection: "others"}})
+	msgs.EmitLoaded(types.DataLoadedPayload{Payload: types.Payload{Collection: "things", DataFacet: "all"}, CurrentCount: 3})
+
+	lines := make(chan string)
+	go func() {
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				close(lines)
+				return
+			}
+			if strings.TrimSpace(line) != "" {
+				lines <- strings.TrimSpace(line)
+			}
+		}
+	}()
+
+	read := func() string {
+		select {
+		case line := <-lines:
+			return line
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for an event")
+			return ""
+		}
+	}
+	assert.Equal(t, "event: data:loaded", read())
+	data := read()
+	assert.True(t, strings.HasPrefix(data, "data: "))
+	var loaded types.DataLoadedPayload
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(data, "data: ")), &loaded))
+	assert.Equal(t, "things", loaded.Collection)
+	assert.Equal(t, 3, loaded.CurrentCount)
+}
+
+func TestIsLoopbackHost(t *testing.T) {
+	for host, want := range map[string]bool{
+		"127.0.0.1:8190": true,
+		"localhost:8190": true,
+		"[::1]:8190":     true,
+		"127.0.0.1":      true,
+		"example.com":    false,
+		"10.0.0.1:8190":  false,
+	} {
+		assert.Equal(t, want, isLoopbackHost(host), host)
+	}
+}
@@ -0,0 +1,151 @@
+package apiserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/msgs"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
+)
+
+// streamedEvents are the events forwarded to /api/events
+var streamedEvents = map[msgs.EventType]bool{
+	msgs.EventDataLoaded:   true,
+	msgs.EventDataReloaded: true,
+}
+
+// event is one message on the stream
+type event struct {
+	name       msgs.EventType
+	collection string
+	data       []byte
+}
+
+// broker fans the application's data events out to the connected event streams. A
+// client that falls behind misses events rather than holding up the application.
+type broker struct {
+	clients map[chan event]bool
+	untap   func()
+	done    chan struct{}
+	mutex   sync.Mutex
+}
+
+func newBroker() *broker {
+	return &broker{clients: make(map[chan event]bool)}
+}
+
+func (b *broker) start() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.untap != nil {
+		return
+	}
+	b.done = make(chan struct{})
+	b.untap = msgs.Tap(b.publish)
+}
+
+func (b *broker) stop() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.untap == nil {
+		return
+	}
+	b.untap()
+	b.untap = nil
+	close(b.done)
+}
+
+// publish is the msgs tap. It must not block.
+func (b *broker) publish(eventType msgs.EventType, optionalData ...interface{}) {
+	if !streamedEvents[eventType] {
+		return
+	}
+
+	ev := event{name: eventType}
+	var data interface{}
+	if len(optionalData) > 0 {
+		ev.collection, _ = optionalData[0].(string)
+	}
+	if len(optionalData) > 1 {
+		data = optionalData[1]
+	}
+	switch p := data.(type) {
+	case types.DataLoadedPayload:
+		ev.collection = p.Collection
+	case types.Payload:
+		ev.collection = p.Collection
+	}
+	bytes, err := json.Marshal(data)
+	if err != nil {
+		return
+	}
+	ev.data = bytes
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	for ch := range b.clients {
+		select {
+		case ch <- ev:
+		default:
+		}
+	}
+}
+
+func (b *broker) subscribe() (chan event, <-chan struct{}) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	ch := make(chan event, 64)
+	b.clients[ch] = true
+	return ch, b.done
+}
+
+func (b *broker) unsubscribe(ch chan event) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	delete(b.clients, ch)
+}
+
+// ServeHTTP streams events as Server-Sent Events until the client goes away or the server
+// stops. The optional collection query parameter limits the stream to one collection.
+func (b *broker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("streaming is not supported"))
+		return
+	}
+	only := r.URL.Query().Get("collection")
+
+	ch, done := b.subscribe()
+	defer b.unsubscribe(ch)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	_, _ = fmt.Fprint(w, ": connected\n\n")
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(30 * time.Second)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-done:
+			return
+		case <-keepAlive.C:
+			_, _ = fmt.Fprint(w, ": keep-alive\n\n")
+			flusher.Flush()
+		case ev := <-ch:
+			if only != "" && ev.collection != only {
+				continue
+			}
+			_, _ = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", ev.name, ev.data)
+			flusher.Flush()
+		}
+	}
+}
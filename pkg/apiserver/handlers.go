@@ -0,0 +1,218 @@
+package apiserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
+)
+
+const defaultPageSize = 50
+
+// Handler returns the server's routes. It is exported so the API can be mounted elsewhere
+// or exercised in tests without listening on a port.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/views", s.handleViews)
+	mux.HandleFunc("GET /api/events", s.events.ServeHTTP)
+	mux.HandleFunc("GET /api/{collection}/config", s.handleConfig)
+	mux.HandleFunc("GET /api/{collection}/{facet}/page", s.handlePage)
+	mux.HandleFunc("GET /api/{collection}/{facet}/summary", s.handleSummary)
+	mux.HandleFunc("GET /api/{collection}/{facet}/buckets", s.handleBuckets)
+	mux.HandleFunc("POST /api/{collection}/{facet}/export", s.handleExport)
+	return mux
+}
+
+// bucketer is implemented by the collections that chart their facets
+type bucketer interface {
+	GetBuckets(payload *types.Payload) (*types.Buckets, error)
+}
+
+func (s *Server) handleViews(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.backend.Views())
+}
+
+func (s *Server) handleConfig(w http.ResponseWriter, r *http.Request) {
+	_, collection, ok := s.resolve(w, r, false)
+	if !ok {
+		return
+	}
+	cfg, err := collection.GetConfig()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, cfg)
+}
+
+func (s *Server) handlePage(w http.ResponseWriter, r *http.Request) {
+	payload, _, ok := s.resolve(w, r, true)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	first, err := intParam(query.Get("first"), 0)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("first: %w", err))
+		return
+	}
+	pageSize, err := intParam(query.Get("pageSize"), defaultPageSize)
+	if err != nil || pageSize <= 0 {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("pageSize must be a positive number"))
+		return
+	}
+
+	page, err := s.backend.Page(payload, first, pageSize, parseSort(query.Get("sort")), query.Get("filter"))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, page)
+}
+
+func (s *Server) handleSummary(w http.ResponseWriter, r *http.Request) {
+	payload, collection, ok := s.resolve(w, r, true)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, collection.GetSummary(payload))
+}
+
+func (s *Server) handleBuckets(w http.ResponseWriter, r *http.Request) {
+	payload, collection, ok := s.resolve(w, r, true)
+	if !ok {
+		return
+	}
+	b, ok := collection.(bucketer)
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("%s has no buckets", payload.Collection))
+		return
+	}
+	buckets, err := b.GetBuckets(payload)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, buckets)
+}
+
+var contentTypes = map[string]string{
+	"csv":  "text/csv; charset=utf-8",
+	"txt":  "text/tab-separated-values; charset=utf-8",
+	"json": "application/json",
+}
+
+func (s *Server) handleExport(w http.ResponseWriter, r *http.Request) {
+	payload, collection, ok := s.resolve(w, r, true)
+	if !ok {
+		return
+	}
+	if payload.Format == "" {
+		payload.Format = "csv"
+	}
+	contentType, ok := contentTypes[payload.Format]
+	if !ok {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("unsupported format: %s", payload.Format))
+		return
+	}
+
+	path, err := collection.ExportData(payload)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filepath.Base(path)))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
+
+// resolve builds the request's payload and finds its collection, writing a 404 and
+// returning false if the collection (or, when withFacet is set, the facet) is unknown
+func (s *Server) resolve(w http.ResponseWriter, r *http.Request, withFacet bool) (*types.Payload, types.Collection, bool) {
+	query := r.URL.Query()
+	payload := &types.Payload{
+		Collection:     r.PathValue("collection"),
+		ActiveChain:    query.Get("chain"),
+		ActiveAddress:  query.Get("address"),
+		ActiveContract: query.Get("contract"),
+		ActivePeriod:   types.Period(query.Get("period")),
+		Format:         query.Get("format"),
+	}
+	if withFacet {
+		payload.DataFacet = types.DataFacet(r.PathValue("facet"))
+	}
+
+	if !slices.Contains(s.backend.Views(), payload.Collection) {
+		writeError(w, http.StatusNotFound, fmt.Errorf("unknown collection: %s", payload.Collection))
+		return nil, nil, false
+	}
+	s.backend.Complete(payload)
+
+	collection := s.backend.Collection(payload)
+	if collection == nil {
+		writeError(w, http.StatusNotFound, fmt.Errorf("unknown collection: %s", payload.Collection))
+		return nil, nil, false
+	}
+	if withFacet {
+		cfg, err := collection.GetConfig()
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return nil, nil, false
+		}
+		if _, ok := cfg.Facets[string(payload.DataFacet)]; !ok {
+			writeError(w, http.StatusNotFound, fmt.Errorf("unknown facet: %s/%s", payload.Collection, payload.DataFacet))
+			return nil, nil, false
+		}
+	}
+	return payload, collection, true
+}
+
+// parseSort reads a comma separated list of fields, each descending if prefixed with -
+func parseSort(value string) sdk.SortSpec {
+	var spec sdk.SortSpec
+	for _, field := range strings.Split(value, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		order := sdk.Asc
+		if strings.HasPrefix(field, "-") {
+			field, order = field[1:], sdk.Dec
+		}
+		spec.Fields = append(spec.Fields, field)
+		spec.Order = append(spec.Order, order)
+	}
+	return spec
+}
+
+func intParam(value string, def int) (int, error) {
+	if value == "" {
+		return def, nil
+	}
+	return strconv.Atoi(value)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
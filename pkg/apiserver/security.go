@@ -0,0 +1,38 @@
+package apiserver
+
+import (
+	"net"
+	"net/http"
+	"strings"
+)
+
+// LocalOnlyMiddleware refuses requests whose Host header does not name the loopback
+// interface, and requests that carry an Origin header. The server only listens on
+// 127.0.0.1, but a web page can still reach it through a DNS name that resolves there,
+// or post to it directly. Scripts and command line tools send no Origin, browsers do, so
+// the API is closed to web pages while staying open to local tools. No CORS headers are
+// sent for the same reason.
+func LocalOnlyMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isLoopbackHost(r.Host) || r.Header.Get("Origin") != "" {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		next.ServeHTTP(w, r)
+	})
+}
+
+func isLoopbackHost(hostport string) bool {
+	host := hostport
+	if h, _, err := net.SplitHostPort(hostport); err == nil {
+		host = h
+	}
+	host = strings.Trim(host, "[]")
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
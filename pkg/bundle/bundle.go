@@ -0,0 +1,331 @@
+// Package bundle packs a project, and the local data that makes it useful on another
+// machine, into a single zip archive: the project file itself, the custom names of its
+// addresses, the ABIs of its contracts, the monitors it relies on and its .Exports
+// folder. Import merges an archive back into a local installation, reporting every entry
+// that differs from what is already there.
+//
+// The archive holds:
+//
+//	manifest.json                  what the archive holds and the format it was written in
+//	project.tbx                    the project file
+//	names.json                     custom names for the project's addresses
+//	monitors.json                  the monitors of the project's addresses
+//	abis/<chain>/<address>.json    the cached ABIs of the project's contracts
+//	exports/<path>                 the contents of the project's .Exports folder
+package bundle
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+)
+
+// FormatVersion is the version of the archive layout this build writes
+const FormatVersion = "1.0"
+
+// Extension is the file extension of project bundles
+const Extension = ".zip"
+
+const (
+	manifestEntry = "manifest.json"
+	projectEntry  = "project.tbx"
+	namesEntry    = "names.json"
+	monitorsEntry = "monitors.json"
+	abisPrefix    = "abis/"
+	exportsPrefix = "exports/"
+)
+
+// Limits on what Read will unpack, so that a hostile archive cannot exhaust memory
+const (
+	MaxEntrySize int64 = 256 << 20 // the largest single entry
+	MaxTotalSize int64 = 1 << 30   // all entries together
+)
+
+// ErrNotABundle is returned for an archive without a manifest
+var ErrNotABundle = errors.New("not a project bundle")
+
+// ErrTooLarge is returned for an archive whose entries exceed MaxEntrySize or MaxTotalSize
+var ErrTooLarge = errors.New("bundle is too large")
+
+// Manifest describes the contents of a bundle
+type Manifest struct {
+	Version  string    `json:"version"`
+	Project  string    `json:"project"`
+	Created  time.Time `json:"created"`
+	Names    int       `json:"names"`
+	Abis     int       `json:"abis"`
+	Monitors int       `json:"monitors"`
+	Exports  int       `json:"exports"`
+}
+
+// Abi is the cached ABI file of one contract on one chain
+type Abi struct {
+	Chain   string
+	Address base.Address
+	Data    []byte
+}
+
+// Monitor is a monitored address on one chain
+type Monitor struct {
+	Chain   string       `json:"chain"`
+	Address base.Address `json:"address"`
+	Name    string       `json:"name,omitempty"`
+	Deleted bool         `json:"deleted,omitempty"`
+}
+
+// File is one file of the exports folder, its path relative to that folder
+type File struct {
+	Path string
+	Data []byte
+}
+
+// Bundle is the decoded contents of an archive
+type Bundle struct {
+	Manifest Manifest
+	Project  []byte
+	Names    []sdk.Name
+	Abis     []Abi
+	Monitors []Monitor
+	Exports  []File
+}
+
+// Write writes the bundle to w as a zip archive. The manifest's counts and version are
+// filled in from the bundle's contents.
+func Write(w io.Writer, b *Bundle) error {
+	b.Manifest.Version = FormatVersion
+	if b.Manifest.Created.IsZero() {
+		b.Manifest.Created = time.Now().UTC()
+	}
+	b.Manifest.Names = len(b.Names)
+	b.Manifest.Abis = len(b.Abis)
+	b.Manifest.Monitors = len(b.Monitors)
+	b.Manifest.Exports = len(b.Exports)
+
+	zw := zip.NewWriter(w)
+	if err := writeJSON(zw, manifestEntry, b.Manifest); err != nil {
+		return err
+	}
+	if err := writeEntry(zw, projectEntry, b.Project); err != nil {
+		return err
+	}
+	if err := writeJSON(zw, namesEntry, b.Names); err != nil {
+		return err
+	}
+	if err := writeJSON(zw, monitorsEntry, b.Monitors); err != nil {
+		return err
+	}
+	for _, abi := range b.Abis {
+		name := abisPrefix + abi.Chain + "/" + abi.Address.Hex() + ".json"
+		if err := writeEntry(zw, name, abi.Data); err != nil {
+			return err
+		}
+	}
+	for _, f := range b.Exports {
+		rel, err := cleanPath(f.Path)
+		if err != nil {
+			return err
+		}
+		if err := writeEntry(zw, exportsPrefix+rel, f.Data); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// WriteFile writes the bundle to the archive at path, replacing any file already there
+func WriteFile(path string, b *Bundle) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := Write(f, b); err != nil {
+		_ = f.Close()
+		_ = os.Remove(path)
+		return err
+	}
+	return f.Close()
+}
+
+// Read decodes the archive in r. Entries the layout does not know are ignored, and
+// entries whose paths would escape their folder are refused, as are archives that unpack
+// to more than MaxEntrySize per entry or MaxTotalSize in all.
+func Read(r io.ReaderAt, size int64) (*Bundle, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrNotABundle, err)
+	}
+
+	b := &Bundle{}
+	foundManifest := false
+	remaining := MaxTotalSize
+	for _, entry := range zr.File {
+		if entry.FileInfo().IsDir() {
+			continue
+		}
+		data, err := readEntry(entry, min(MaxEntrySize, remaining))
+		if err != nil {
+			return nil, err
+		}
+		remaining -= int64(len(data))
+
+		switch name := entry.Name; {
+		case name == manifestEntry:
+			if err := json.Unmarshal(data, &b.Manifest); err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			foundManifest = true
+		case name == projectEntry:
+			b.Project = data
+		case name == namesEntry:
+			if err := json.Unmarshal(data, &b.Names); err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+		case name == monitorsEntry:
+			if err := json.Unmarshal(data, &b.Monitors); err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+		case strings.HasPrefix(name, abisPrefix):
+			abi, err := parseAbiEntry(name, data)
+			if err != nil {
+				return nil, err
+			}
+			b.Abis = append(b.Abis, abi)
+		case strings.HasPrefix(name, exportsPrefix):
+			rel, err := cleanPath(strings.TrimPrefix(name, exportsPrefix))
+			if err != nil {
+				return nil, err
+			}
+			b.Exports = append(b.Exports, File{Path: rel, Data: data})
+		}
+	}
+
+	if !foundManifest {
+		return nil, ErrNotABundle
+	}
+	if b.Manifest.Version != FormatVersion {
+		return nil, fmt.Errorf("bundle format %s is not supported (this version reads %s)", b.Manifest.Version, FormatVersion)
+	}
+	if len(b.Project) == 0 {
+		return nil, fmt.Errorf("%w: the archive has no project file", ErrNotABundle)
+	}
+	return b, nil
+}
+
+// ReadFile decodes the archive at path
+func ReadFile(path string) (*Bundle, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	return Read(f, info.Size())
+}
+
+// ReadExports returns every file under dir, with paths relative to it. A missing folder
+// has no files.
+func ReadExports(dir string) ([]File, error) {
+	files := []File{}
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) && p == dir {
+				return fs.SkipAll
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		files = append(files, File{Path: filepath.ToSlash(rel), Data: data})
+		return nil
+	})
+	return files, err
+}
+
+func writeJSON(zw *zip.Writer, name string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return writeEntry(zw, name, data)
+}
+
+func writeEntry(zw *zip.Writer, name string, data []byte) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
+
+// readEntry returns the contents of entry, refusing one that unpacks to more than limit
+// bytes. The size in the entry's header is checked first but not trusted.
+func readEntry(entry *zip.File, limit int64) ([]byte, error) {
+	if entry.UncompressedSize64 > uint64(limit) {
+		return nil, fmt.Errorf("%s: %w", entry.Name, ErrTooLarge)
+	}
+	rc, err := entry.Open()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", entry.Name, err)
+	}
+	defer func() { _ = rc.Close() }()
+	data, err := io.ReadAll(io.LimitReader(rc, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", entry.Name, err)
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("%s: %w", entry.Name, ErrTooLarge)
+	}
+	return data, nil
+}
+
+// parseAbiEntry splits abis/<chain>/<address>.json into its chain and address
+func parseAbiEntry(name string, data []byte) (Abi, error) {
+	parts := strings.Split(strings.TrimPrefix(name, abisPrefix), "/")
+	if len(parts) != 2 || path.Ext(parts[1]) != ".json" {
+		return Abi{}, fmt.Errorf("unexpected ABI entry %s", name)
+	}
+	if chain := parts[0]; chain == "" || chain == "." || chain == ".." || strings.ContainsAny(chain, `\:`) {
+		return Abi{}, fmt.Errorf("unsafe path in bundle: %s", name)
+	}
+	hex := strings.TrimSuffix(parts[1], ".json")
+	if !base.IsValidAddress(hex) {
+		return Abi{}, fmt.Errorf("ABI entry %s is not named for an address", name)
+	}
+	return Abi{Chain: parts[0], Address: base.HexToAddress(hex), Data: data}, nil
+}
+
+// cleanPath returns a slash separated path that stays inside the folder it is relative to
+func cleanPath(rel string) (string, error) {
+	cleaned := path.Clean(filepath.ToSlash(rel))
+	if cleaned == "." || path.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, "../") || strings.Contains(cleaned, ":") {
+		return "", fmt.Errorf("unsafe path in bundle: %s", rel)
+	}
+	return cleaned, nil
+}
@@ -0,0 +1,221 @@
+package bundle
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	alice = base.HexToAddress("0xf503017d7baf7fbc0fff7492b751025c6a78179b")
+	token = base.HexToAddress("0x6b175474e89094c44da98b954eedeac495271d0f")
+)
+
+type fakeStore struct {
+	names    map[base.Address]*sdk.Name
+	abis     map[string][]byte
+	monitors map[string]bool
+	saved    []string
+	failing  map[string]bool
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{names: map[base.Address]*sdk.Name{}, abis: map[string][]byte{}, monitors: map[string]bool{}}
+}
+
+func (s *fakeStore) LookupName(addr base.Address) (*sdk.Name, bool) {
+	n, ok := s.names[addr]
+	return n, ok
+}
+
+func (s *fakeStore) SaveName(name *sdk.Name, exists bool) error {
+	s.names[name.Address] = name
+	s.saved = append(s.saved, name.Address.Hex())
+	return nil
+}
+
+func (s *fakeStore) ReadAbi(chain string, addr base.Address) ([]byte, bool) {
+	data, ok := s.abis[chain+addr.Hex()]
+	return data, ok
+}
+
+func (s *fakeStore) WriteAbi(chain string, addr base.Address, data []byte) error {
+	s.abis[chain+addr.Hex()] = data
+	return nil
+}
+
+func (s *fakeStore) HasMonitor(chain string, addr base.Address) bool {
+	return s.monitors[chain+addr.Hex()]
+}
+
+func (s *fakeStore) CreateMonitor(chain string, addr base.Address) error {
+	if s.failing[chain+addr.Hex()] {
+		return errors.New("no rpc")
+	}
+	s.monitors[chain+addr.Hex()] = true
+	return nil
+}
+
+func testBundle() *Bundle {
+	return &Bundle{
+		Manifest: Manifest{Project: "work.tbx"},
+		Project:  []byte(`{"version":"v6.5.1","name":"work"}`),
+		Names: []sdk.Name{
+			{Address: alice, Name: "Alice", Tags: "friends"},
+			{Address: token, Name: "Dai", Symbol: "DAI", Decimals: 18},
+		},
+		Abis:     []Abi{{Chain: "mainnet", Address: token, Data: []byte(`[{"type":"function","name":"totalSupply"}]`)}},
+		Monitors: []Monitor{{Chain: "mainnet", Address: alice, Name: "Alice"}, {Chain: "mainnet", Address: token}},
+		Exports:  []File{{Path: "exports-statements.csv", Data: []byte("a,b\n1,2\n")}, {Path: "old/notes.txt", Data: []byte("x")}},
+	}
+}
+
+func TestWriteReadRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, testBundle()))
+
+	b, err := Read(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	assert.Equal(t, FormatVersion, b.Manifest.Version)
+	assert.Equal(t, 2, b.Manifest.Names)
+	assert.Equal(t, 2, b.Manifest.Exports)
+	assert.Equal(t, testBundle().Project, b.Project)
+	require.Len(t, b.Names, 2)
+	assert.Equal(t, "Dai", b.Names[1].Name)
+	require.Len(t, b.Abis, 1)
+	assert.Equal(t, token, b.Abis[0].Address)
+	assert.Equal(t, "mainnet", b.Abis[0].Chain)
+	assert.Len(t, b.Monitors, 2)
+	assert.ElementsMatch(t, []string{"exports-statements.csv", "old/notes.txt"}, []string{b.Exports[0].Path, b.Exports[1].Path})
+}
+
+func TestReadRefusesUnsafeAndForeignArchives(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, _ := zw.Create("hello.txt")
+	_, _ = w.Write([]byte("hi"))
+	require.NoError(t, zw.Close())
+	_, err := Read(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.ErrorIs(t, err, ErrNotABundle)
+
+	buf.Reset()
+	require.NoError(t, Write(&buf, &Bundle{Project: []byte("{}")}))
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	var evil bytes.Buffer
+	zw = zip.NewWriter(&evil)
+	for _, f := range zr.File {
+		require.NoError(t, zw.Copy(f))
+	}
+	w, _ = zw.Create("exports/../../escape.txt")
+	_, _ = w.Write([]byte("gotcha"))
+	require.NoError(t, zw.Close())
+	_, err = Read(bytes.NewReader(evil.Bytes()), int64(evil.Len()))
+	assert.ErrorContains(t, err, "unsafe path")
+}
+
+func TestAbiEntryChainStaysInTheCache(t *testing.T) {
+	addr := "0x0000000000000000000000000000000000000001"
+	for _, chain := range []string{"", ".", "..", `..\x`, "c:"} {
+		_, err := parseAbiEntry(abisPrefix+chain+"/"+addr+".json", nil)
+		assert.Error(t, err, chain)
+	}
+	abi, err := parseAbiEntry(abisPrefix+"mainnet/"+addr+".json", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "mainnet", abi.Chain)
+}
+
+func TestReadEntryIsLimited(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, _ := zw.Create("exports/big.csv")
+	_, _ = w.Write(bytes.Repeat([]byte("x"), 100))
+	require.NoError(t, zw.Close())
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	_, err = readEntry(zr.File[0], 99)
+	assert.ErrorIs(t, err, ErrTooLarge)
+	data, err := readEntry(zr.File[0], 100)
+	require.NoError(t, err)
+	assert.Len(t, data, 100)
+}
+
+func TestImportReportsMonitorsItCannotCreate(t *testing.T) {
+	store := newFakeStore()
+	store.failing = map[string]bool{"mainnet" + token.Hex(): true}
+
+	report, err := Import(testBundle(), store, Options{ProjectPath: filepath.Join(t.TempDir(), "work.tbx")})
+	require.NoError(t, err)
+	assert.Contains(t, report.Added, Entry{Kind: KindMonitor, Key: "mainnet/" + alice.Hex()})
+	assert.Equal(t, []Failure{{Kind: KindMonitor, Key: "mainnet/" + token.Hex(), Error: "no rpc"}}, report.Failed)
+	assert.Contains(t, report.String(), "1 failed")
+}
+
+func TestImportAddsNewAndReportsConflicts(t *testing.T) {
+	dir := t.TempDir()
+	projectPath := filepath.Join(dir, "work.tbx")
+	store := newFakeStore()
+	store.names[alice] = &sdk.Name{Address: alice, Name: "Alice", Tags: "friends"}
+	store.names[token] = &sdk.Name{Address: token, Name: "My Dai", Symbol: "DAI", Decimals: 18}
+	store.monitors["mainnet"+alice.Hex()] = true
+
+	exportsDir := types.ExportsFolder(projectPath)
+	require.NoError(t, os.MkdirAll(exportsDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(exportsDir, "exports-statements.csv"), []byte("local"), 0644))
+
+	report, err := Import(testBundle(), store, Options{ProjectPath: projectPath})
+	require.NoError(t, err)
+
+	assert.Contains(t, report.Added, Entry{Kind: KindProject, Key: "work.tbx"})
+	assert.Contains(t, report.Added, Entry{Kind: KindAbi, Key: "mainnet/" + token.Hex()})
+	assert.Contains(t, report.Added, Entry{Kind: KindExport, Key: "old/notes.txt"})
+	assert.Contains(t, report.Unchanged, Entry{Kind: KindName, Key: alice.Hex()})
+	assert.Contains(t, report.Unchanged, Entry{Kind: KindMonitor, Key: "mainnet/" + alice.Hex()})
+	assert.Contains(t, report.Added, Entry{Kind: KindMonitor, Key: "mainnet/" + token.Hex()})
+	assert.True(t, store.monitors["mainnet"+token.Hex()], "missing monitors are created")
+	assert.Empty(t, report.Failed)
+
+	require.Len(t, report.Conflicts, 2)
+	assert.Equal(t, Conflict{Kind: KindName, Key: token.Hex(), Local: "My Dai (DAI)", Bundled: "Dai (DAI)", Resolution: KeptLocal}, report.Conflicts[0])
+	assert.Equal(t, KindExport, report.Conflicts[1].Kind)
+
+	assert.Empty(t, store.saved, "nothing new needed saving and conflicts keep local names")
+	assert.Equal(t, "My Dai", store.names[token].Name)
+	local, err := os.ReadFile(filepath.Join(exportsDir, "exports-statements.csv"))
+	require.NoError(t, err)
+	assert.Equal(t, "local", string(local))
+	assert.FileExists(t, filepath.Join(exportsDir, "old", "notes.txt"))
+	assert.FileExists(t, projectPath)
+	assert.Contains(t, report.String(), "2 conflicts")
+}
+
+func TestImportOverwriteUsesTheBundle(t *testing.T) {
+	projectPath := filepath.Join(t.TempDir(), "work.tbx")
+	require.NoError(t, os.WriteFile(projectPath, []byte(`{"name":"mine"}`), 0644))
+	store := newFakeStore()
+	store.names[token] = &sdk.Name{Address: token, Name: "My Dai"}
+	store.abis["mainnet"+token.Hex()] = []byte("[]")
+
+	report, err := Import(testBundle(), store, Options{ProjectPath: projectPath, Overwrite: true})
+	require.NoError(t, err)
+	require.Len(t, report.Conflicts, 3)
+	for _, c := range report.Conflicts {
+		assert.Equal(t, UsedBundled, c.Resolution)
+	}
+	assert.Equal(t, "Dai", store.names[token].Name)
+	assert.Contains(t, string(store.abis["mainnet"+token.Hex()]), "totalSupply")
+	data, err := os.ReadFile(projectPath)
+	require.NoError(t, err)
+	assert.Equal(t, testBundle().Project, data)
+}
@@ -0,0 +1,241 @@
+package bundle
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+)
+
+// Kind names the kind of entry a report line is about
+type Kind string
+
+const (
+	KindProject Kind = "project"
+	KindName    Kind = "name"
+	KindAbi     Kind = "abi"
+	KindMonitor Kind = "monitor"
+	KindExport  Kind = "export"
+)
+
+// Resolutions of a conflict
+const (
+	KeptLocal   = "kept local"
+	UsedBundled = "used bundled"
+)
+
+// Store is the local installation a bundle is imported into
+type Store interface {
+	// LookupName returns the local custom name of addr, if there is one
+	LookupName(addr base.Address) (*sdk.Name, bool)
+	// SaveName creates (or, if exists, updates) a custom name
+	SaveName(name *sdk.Name, exists bool) error
+	// ReadAbi returns the cached ABI of addr on chain, if there is one
+	ReadAbi(chain string, addr base.Address) ([]byte, bool)
+	// WriteAbi caches data as the ABI of addr on chain
+	WriteAbi(chain string, addr base.Address, data []byte) error
+	// HasMonitor reports whether addr is monitored on chain
+	HasMonitor(chain string, addr base.Address) bool
+	// CreateMonitor starts monitoring addr on chain
+	CreateMonitor(chain string, addr base.Address) error
+}
+
+// Options control how a bundle is merged
+type Options struct {
+	// ProjectPath is where the bundled project file is written. Its exports go to the
+	// .Exports folder beside it.
+	ProjectPath string
+	// Overwrite resolves conflicts in favour of the bundle; otherwise local data is kept
+	Overwrite bool
+}
+
+// Entry identifies one item of a bundle
+type Entry struct {
+	Kind Kind   `json:"kind"`
+	Key  string `json:"key"`
+}
+
+// Conflict is an item that differs between the bundle and the local installation
+type Conflict struct {
+	Kind       Kind   `json:"kind"`
+	Key        string `json:"key"`
+	Local      string `json:"local"`
+	Bundled    string `json:"bundled"`
+	Resolution string `json:"resolution"`
+}
+
+// Failure is an item that could not be imported. It does not stop the rest of the import.
+type Failure struct {
+	Kind  Kind   `json:"kind"`
+	Key   string `json:"key"`
+	Error string `json:"error"`
+}
+
+// Report says what an import did
+type Report struct {
+	ProjectPath string     `json:"projectPath"`
+	Added       []Entry    `json:"added"`
+	Unchanged   []Entry    `json:"unchanged"`
+	Conflicts   []Conflict `json:"conflicts"`
+	Failed      []Failure  `json:"failed"`
+}
+
+// String summarises the report for the status bar and logs
+func (r *Report) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "imported %s: %d added, %d unchanged, %d conflicts, %d failed", filepath.Base(r.ProjectPath), len(r.Added), len(r.Unchanged), len(r.Conflicts), len(r.Failed))
+	for _, c := range r.Conflicts {
+		fmt.Fprintf(&sb, "\n  %s %s: local %q, bundled %q (%s)", c.Kind, c.Key, c.Local, c.Bundled, c.Resolution)
+	}
+	for _, f := range r.Failed {
+		fmt.Fprintf(&sb, "\n  %s %s: %s", f.Kind, f.Key, f.Error)
+	}
+	return sb.String()
+}
+
+// Import merges b into store and writes its project and exports under opts.ProjectPath.
+// Items that are new are added and identical items are left alone. Items that differ are
+// reported as conflicts and resolved by opts.Overwrite. Monitors the local installation
+// lacks are created; one that cannot be is reported as failed and the import goes on.
+func Import(b *Bundle, store Store, opts Options) (*Report, error) {
+	if opts.ProjectPath == "" {
+		return nil, fmt.Errorf("no path given for the imported project")
+	}
+
+	report := &Report{
+		ProjectPath: opts.ProjectPath,
+		Added:       []Entry{},
+		Unchanged:   []Entry{},
+		Conflicts:   []Conflict{},
+		Failed:      []Failure{},
+	}
+	resolution := KeptLocal
+	if opts.Overwrite {
+		resolution = UsedBundled
+	}
+
+	// A differing local file is only described by its size; the contents are not useful in a report
+	mergeFile := func(kind Kind, key, path string, data []byte) error {
+		local, err := os.ReadFile(path)
+		switch {
+		case err == nil && bytes.Equal(local, data):
+			report.Unchanged = append(report.Unchanged, Entry{Kind: kind, Key: key})
+			return nil
+		case err == nil:
+			report.Conflicts = append(report.Conflicts, Conflict{
+				Kind: kind, Key: key, Resolution: resolution,
+				Local: fmt.Sprintf("%d bytes", len(local)), Bundled: fmt.Sprintf("%d bytes", len(data)),
+			})
+			if !opts.Overwrite {
+				return nil
+			}
+		case !os.IsNotExist(err):
+			return err
+		default:
+			report.Added = append(report.Added, Entry{Kind: kind, Key: key})
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		return os.WriteFile(path, data, 0644)
+	}
+
+	if err := mergeFile(KindProject, filepath.Base(opts.ProjectPath), opts.ProjectPath, b.Project); err != nil {
+		return report, fmt.Errorf("project: %w", err)
+	}
+
+	for i := range b.Names {
+		bundled := &b.Names[i]
+		key := bundled.Address.Hex()
+		local, exists := store.LookupName(bundled.Address)
+		switch {
+		case !exists:
+			report.Added = append(report.Added, Entry{Kind: KindName, Key: key})
+		case sameName(local, bundled):
+			report.Unchanged = append(report.Unchanged, Entry{Kind: KindName, Key: key})
+			continue
+		default:
+			report.Conflicts = append(report.Conflicts, Conflict{
+				Kind: KindName, Key: key, Resolution: resolution,
+				Local: describeName(local), Bundled: describeName(bundled),
+			})
+			if !opts.Overwrite {
+				continue
+			}
+		}
+		if err := store.SaveName(bundled, exists); err != nil {
+			return report, fmt.Errorf("name %s: %w", key, err)
+		}
+	}
+
+	for _, abi := range b.Abis {
+		key := abi.Chain + "/" + abi.Address.Hex()
+		local, exists := store.ReadAbi(abi.Chain, abi.Address)
+		switch {
+		case !exists:
+			report.Added = append(report.Added, Entry{Kind: KindAbi, Key: key})
+		case bytes.Equal(bytes.TrimSpace(local), bytes.TrimSpace(abi.Data)):
+			report.Unchanged = append(report.Unchanged, Entry{Kind: KindAbi, Key: key})
+			continue
+		default:
+			report.Conflicts = append(report.Conflicts, Conflict{
+				Kind: KindAbi, Key: key, Resolution: resolution,
+				Local: fmt.Sprintf("%d bytes", len(local)), Bundled: fmt.Sprintf("%d bytes", len(abi.Data)),
+			})
+			if !opts.Overwrite {
+				continue
+			}
+		}
+		if err := store.WriteAbi(abi.Chain, abi.Address, abi.Data); err != nil {
+			return report, fmt.Errorf("abi %s: %w", key, err)
+		}
+	}
+
+	for _, m := range b.Monitors {
+		if m.Deleted {
+			continue
+		}
+		key := m.Chain + "/" + m.Address.Hex()
+		if store.HasMonitor(m.Chain, m.Address) {
+			report.Unchanged = append(report.Unchanged, Entry{Kind: KindMonitor, Key: key})
+		} else if err := store.CreateMonitor(m.Chain, m.Address); err != nil {
+			report.Failed = append(report.Failed, Failure{Kind: KindMonitor, Key: key, Error: err.Error()})
+		} else {
+			report.Added = append(report.Added, Entry{Kind: KindMonitor, Key: key})
+		}
+	}
+
+	exportsDir := types.ExportsFolder(opts.ProjectPath)
+	for _, f := range b.Exports {
+		rel, err := cleanPath(f.Path)
+		if err != nil {
+			return report, err
+		}
+		if err := mergeFile(KindExport, rel, filepath.Join(exportsDir, filepath.FromSlash(rel)), f.Data); err != nil {
+			return report, fmt.Errorf("export %s: %w", rel, err)
+		}
+	}
+
+	return report, nil
+}
+
+func sameName(a, b *sdk.Name) bool {
+	return a.Name == b.Name && a.Tags == b.Tags && a.Symbol == b.Symbol && a.Decimals == b.Decimals && a.Source == b.Source
+}
+
+func describeName(n *sdk.Name) string {
+	desc := n.Name
+	if n.Symbol != "" {
+		desc += " (" + n.Symbol + ")"
+	}
+	if n.Tags != "" {
+		desc += " [" + n.Tags + "]"
+	}
+	return desc
+}
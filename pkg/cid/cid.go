@@ -0,0 +1,166 @@
+// Package cid computes the IPFS content identifier a file would be given by `ipfs add`
+// with its default settings, without an IPFS node.
+//
+// Those defaults are CIDv0, 256KiB chunks and a balanced DAG of dag-pb nodes holding
+// unixfs data, at most 174 links to a node. A file that fits in one chunk is a single
+// leaf; a larger one has its leaves grouped, 174 at a time, into parents until one root
+// remains. The CID is the base58 encoding of the sha2-256 multihash of the root node.
+package cid
+
+import (
+	"crypto/sha256"
+	"io"
+	"math/big"
+	"os"
+)
+
+// ChunkSize is the number of bytes of the file held by each leaf
+const ChunkSize = 256 * 1024
+
+// LinksPerNode is the most children a parent node may have
+const LinksPerNode = 174
+
+// protobuf wire types
+const (
+	wireVarint = 0
+	wireBytes  = 2
+)
+
+// unixfs data type of a file
+const unixfsFile = 2
+
+// node is what a parent needs to know about a child
+type node struct {
+	hash     []byte // sha2-256 multihash of the encoded node
+	fileSize uint64 // bytes of the file below the node
+	treeSize uint64 // bytes of the encoded node and every node below it
+}
+
+// File returns the CID of the file at path
+func File(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	return Reader(f)
+}
+
+// Reader returns the CID of everything read from r
+func Reader(r io.Reader) (string, error) {
+	buf := make([]byte, ChunkSize)
+	level := []node{}
+	for {
+		n, err := io.ReadFull(r, buf)
+		if n > 0 || len(level) == 0 && err == io.EOF {
+			level = append(level, leaf(buf[:n]))
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+
+	for len(level) > 1 {
+		next := make([]node, 0, (len(level)+LinksPerNode-1)/LinksPerNode)
+		for start := 0; start < len(level); start += LinksPerNode {
+			end := min(start+LinksPerNode, len(level))
+			next = append(next, parent(level[start:end]))
+		}
+		level = next
+	}
+	// A file of more than one chunk always has a parent, even if it has only one child
+	return base58(level[0].hash), nil
+}
+
+func leaf(data []byte) node {
+	fs := appendKey(nil, 1, wireVarint)
+	fs = appendVarint(fs, unixfsFile)
+	if len(data) > 0 {
+		fs = appendBytesField(fs, 2, data)
+	}
+	fs = appendKey(fs, 3, wireVarint)
+	fs = appendVarint(fs, uint64(len(data)))
+
+	encoded := appendBytesField(nil, 1, fs)
+	return node{hash: multihash(encoded), fileSize: uint64(len(data)), treeSize: uint64(len(encoded))}
+}
+
+func parent(children []node) node {
+	var fileSize, treeSize uint64
+	for _, c := range children {
+		fileSize += c.fileSize
+	}
+
+	fs := appendKey(nil, 1, wireVarint)
+	fs = appendVarint(fs, unixfsFile)
+	fs = appendKey(fs, 3, wireVarint)
+	fs = appendVarint(fs, fileSize)
+	for _, c := range children {
+		fs = appendKey(fs, 4, wireVarint)
+		fs = appendVarint(fs, c.fileSize)
+	}
+
+	// dag-pb puts the links before the data; a link's name is always written, even empty
+	var encoded []byte
+	for _, c := range children {
+		link := appendBytesField(nil, 1, c.hash)
+		link = appendBytesField(link, 2, nil)
+		link = appendKey(link, 3, wireVarint)
+		link = appendVarint(link, c.treeSize)
+		encoded = appendBytesField(encoded, 2, link)
+		treeSize += c.treeSize
+	}
+	encoded = appendBytesField(encoded, 1, fs)
+
+	return node{hash: multihash(encoded), fileSize: fileSize, treeSize: treeSize + uint64(len(encoded))}
+}
+
+func multihash(data []byte) []byte {
+	sum := sha256.Sum256(data)
+	return append([]byte{0x12, 0x20}, sum[:]...)
+}
+
+func appendKey(b []byte, field, wire int) []byte {
+	return appendVarint(b, uint64(field<<3|wire))
+}
+
+func appendVarint(b []byte, v uint64) []byte {
+	for v >= 0x80 {
+		b = append(b, byte(v)|0x80)
+		v >>= 7
+	}
+	return append(b, byte(v))
+}
+
+func appendBytesField(b []byte, field int, data []byte) []byte {
+	b = appendKey(b, field, wireBytes)
+	b = appendVarint(b, uint64(len(data)))
+	return append(b, data...)
+}
+
+const alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// base58 encodes b with the bitcoin alphabet, as CIDv0 does
+func base58(b []byte) string {
+	n := new(big.Int).SetBytes(b)
+	radix := big.NewInt(58)
+	mod := new(big.Int)
+	out := []byte{}
+	for n.Sign() > 0 {
+		n.DivMod(n, radix, mod)
+		out = append(out, alphabet[mod.Int64()])
+	}
+	for _, c := range b {
+		if c != 0 {
+			break
+		}
+		out = append(out, alphabet[0])
+	}
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return string(out)
+}
@@ -0,0 +1,64 @@
+package cid
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReaderMatchesIpfsAdd(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"empty", "", "QmbFMke1KXqnYyBBWxB74N4c5SBnJMVAiMNRcGu6x1AwQH"},
+		{"hello world", "hello world", "Qmf412jQZiuVUtdgnB36FXFX7xg5V6KEbSJ4dpQuhkLyfD"},
+		{"hello world newline", "hello world\n", "QmT78zSuBmuS4z925WZfrqQ1qHaJ56DQaTfyMUF7F8ff5o"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Reader(strings.NewReader(tt.data))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// The expected CIDs were produced by the balanced importer ipfs add uses
+func TestLargeFilesMatchIpfsAdd(t *testing.T) {
+	tests := []struct {
+		size int
+		want string
+	}{
+		{ChunkSize + 1, "Qma3Rwemxp1HW2F9M1hxYaTcxMGKS7R7yMRoTHktNozGEN"},
+		{ChunkSize*LinksPerNode + 5, "QmVxWx8VEW3N4QRGciJDh31BjRHnk4Jjj69UbRearbZiGk"},
+		{ChunkSize*200 + 77, "QmZABxj3oLpHxnXhxefj6FhUf6a74aAv1xCGZcFncrb1HW"},
+	}
+	for _, tt := range tests {
+		data := make([]byte, tt.size)
+		for i := range data {
+			data[i] = byte(i * 7)
+		}
+		got, err := Reader(bytes.NewReader(data))
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, got, "size %d", tt.size)
+	}
+}
+
+func TestFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hello.txt")
+	require.NoError(t, os.WriteFile(path, []byte("hello world"), 0644))
+
+	got, err := File(path)
+	require.NoError(t, err)
+	assert.Equal(t, "Qmf412jQZiuVUtdgnB36FXFX7xg5V6KEbSJ4dpQuhkLyfD", got)
+
+	_, err = File(filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,263 @@
+// Package costbasis matches each disposal of an asset against the lots in which the asset
+// was acquired to compute realized and unrealized gains. Lots are consumed first in, first
+// out (FIFO), last in, first out (LIFO), or highest cost first (HIFO).
+package costbasis
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+)
+
+// Method is the order in which lots are consumed by a disposal
+type Method string
+
+const (
+	FIFO Method = "fifo"
+	LIFO Method = "lifo"
+	HIFO Method = "hifo"
+)
+
+// Methods lists the supported methods, default first
+var Methods = []Method{FIFO, LIFO, HIFO}
+
+// ParseMethod returns the method named by s (case insensitive). An empty string is FIFO.
+func ParseMethod(s string) (Method, error) {
+	if s == "" {
+		return FIFO, nil
+	}
+	m := Method(strings.ToLower(strings.TrimSpace(s)))
+	for _, known := range Methods {
+		if m == known {
+			return m, nil
+		}
+	}
+	return "", fmt.Errorf("unknown cost basis method %q (want fifo, lifo, or hifo)", s)
+}
+
+// Term is the holding period of a disposal for tax purposes
+type Term string
+
+const (
+	ShortTerm Term = "short"
+	LongTerm  Term = "long"
+)
+
+// longTermSeconds is how long a lot must be held before its disposal is long term
+const longTermSeconds = 365 * 24 * 60 * 60
+
+// Event is one change in an address's holding of an asset. In and Out are in the asset's
+// smallest unit; Price is in USD per whole unit at the time of the event.
+type Event struct {
+	Asset     base.Address
+	Decimals  uint64
+	Block     base.Blknum
+	TxIndex   base.Txnum
+	Timestamp base.Timestamp
+	Hash      base.Hash
+	In        *big.Int
+	Out       *big.Int
+	Price     float64
+}
+
+// Lot is a quantity of an asset acquired at one time and price
+type Lot struct {
+	Block    base.Blknum
+	Acquired base.Timestamp
+	Quantity *big.Int
+	UnitCost float64
+	seq      int
+}
+
+// Disposal is the part of one disposing event that falls in a single holding term. A
+// disposal whose lots were acquired on different dates has Various set and Acquired zero.
+//
+// Unmatched is the quantity disposed beyond every known lot (usually because the history
+// starts after the asset was acquired). It is reported as short term with no cost basis.
+// Unrealized is the gain on the lots still open after the disposal, valued at the
+// disposal's price; it is a snapshot, so summing it across disposals is meaningless.
+type Disposal struct {
+	Event      Event
+	Term       Term
+	Quantity   *big.Int
+	Acquired   base.Timestamp
+	Various    bool
+	Proceeds   float64
+	CostBasis  float64
+	Realized   float64
+	Unrealized float64
+	Unmatched  *big.Int
+}
+
+// Book tracks the open lots of every asset
+type Book struct {
+	method Method
+	lots   map[base.Address][]*Lot
+	seq    int
+}
+
+// NewBook returns an empty book that consumes lots using method
+func NewBook(method Method) *Book {
+	return &Book{method: method, lots: make(map[base.Address][]*Lot)}
+}
+
+// Compute applies events in chain order to a new book and returns every disposal
+func Compute(method Method, events []Event) []Disposal {
+	sorted := make([]Event, len(events))
+	copy(sorted, events)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Block != sorted[j].Block {
+			return sorted[i].Block < sorted[j].Block
+		}
+		return sorted[i].TxIndex < sorted[j].TxIndex
+	})
+
+	book := NewBook(method)
+	ret := make([]Disposal, 0)
+	for _, e := range sorted {
+		ret = append(ret, book.Apply(e)...)
+	}
+	return ret
+}
+
+// Open returns copies of the asset's open lots in acquisition order
+func (b *Book) Open(asset base.Address) []Lot {
+	ret := make([]Lot, 0, len(b.lots[asset]))
+	for _, lot := range b.lots[asset] {
+		cp := *lot
+		cp.Quantity = new(big.Int).Set(lot.Quantity)
+		ret = append(ret, cp)
+	}
+	return ret
+}
+
+// Apply records an event. Anything acquired becomes a new lot before anything disposed is
+// matched, and the disposals (at most one per term, plus one for any unmatched quantity)
+// are returned.
+func (b *Book) Apply(e Event) []Disposal {
+	if e.In != nil && e.In.Sign() > 0 {
+		b.seq++
+		b.lots[e.Asset] = append(b.lots[e.Asset], &Lot{
+			Block:    e.Block,
+			Acquired: e.Timestamp,
+			Quantity: new(big.Int).Set(e.In),
+			UnitCost: e.Price,
+			seq:      b.seq,
+		})
+	}
+	if e.Out == nil || e.Out.Sign() <= 0 {
+		return nil
+	}
+
+	byTerm := make(map[Term]*Disposal)
+	remaining := new(big.Int).Set(e.Out)
+	for remaining.Sign() > 0 {
+		lot := b.next(e.Asset)
+		if lot == nil {
+			break
+		}
+
+		take := new(big.Int).Set(lot.Quantity)
+		if take.Cmp(remaining) > 0 {
+			take.Set(remaining)
+		}
+		lot.Quantity.Sub(lot.Quantity, take)
+		remaining.Sub(remaining, take)
+
+		term := ShortTerm
+		if int64(e.Timestamp)-int64(lot.Acquired) > longTermSeconds {
+			term = LongTerm
+		}
+		d := byTerm[term]
+		if d == nil {
+			d = &Disposal{Event: e, Term: term, Quantity: new(big.Int), Acquired: lot.Acquired}
+			byTerm[term] = d
+		} else if d.Acquired != lot.Acquired {
+			d.Various = true
+		}
+		units := toUnits(take, e.Decimals)
+		d.Quantity.Add(d.Quantity, take)
+		d.Proceeds += units * e.Price
+		d.CostBasis += units * lot.UnitCost
+	}
+	b.prune(e.Asset)
+
+	ret := make([]Disposal, 0, 3)
+	for _, term := range []Term{ShortTerm, LongTerm} {
+		if d := byTerm[term]; d != nil {
+			if d.Various {
+				d.Acquired = 0
+			}
+			ret = append(ret, *d)
+		}
+	}
+	if remaining.Sign() > 0 {
+		units := toUnits(remaining, e.Decimals)
+		ret = append(ret, Disposal{
+			Event:     e,
+			Term:      ShortTerm,
+			Quantity:  new(big.Int).Set(remaining),
+			Proceeds:  units * e.Price,
+			Unmatched: new(big.Int).Set(remaining),
+		})
+	}
+
+	unrealized := 0.0
+	for _, lot := range b.lots[e.Asset] {
+		unrealized += toUnits(lot.Quantity, e.Decimals) * (e.Price - lot.UnitCost)
+	}
+	for i := range ret {
+		ret[i].Realized = ret[i].Proceeds - ret[i].CostBasis
+		ret[i].Unrealized = unrealized
+	}
+	return ret
+}
+
+// next returns the open lot the book's method consumes first, or nil if there is none
+func (b *Book) next(asset base.Address) *Lot {
+	var best *Lot
+	for _, lot := range b.lots[asset] {
+		if lot.Quantity.Sign() <= 0 {
+			continue
+		}
+		if best == nil {
+			best = lot
+			continue
+		}
+		switch b.method {
+		case LIFO:
+			if lot.seq > best.seq {
+				best = lot
+			}
+		case HIFO:
+			if lot.UnitCost > best.UnitCost {
+				best = lot
+			}
+		default:
+			if lot.seq < best.seq {
+				best = lot
+			}
+		}
+	}
+	return best
+}
+
+// prune drops the asset's exhausted lots
+func (b *Book) prune(asset base.Address) {
+	open := b.lots[asset][:0]
+	for _, lot := range b.lots[asset] {
+		if lot.Quantity.Sign() > 0 {
+			open = append(open, lot)
+		}
+	}
+	b.lots[asset] = open
+}
+
+func toUnits(quantity *big.Int, decimals uint64) float64 {
+	scale := new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil))
+	units, _ := new(big.Float).Quo(new(big.Float).SetInt(quantity), scale).Float64()
+	return units
+}
@@ -0,0 +1,88 @@
+package costbasis
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const day = 24 * 60 * 60
+
+var asset = base.HexToAddress("0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48")
+
+func buy(block uint64, ts int64, qty int64, price float64) Event {
+	return Event{Asset: asset, Block: base.Blknum(block), Timestamp: base.Timestamp(ts), In: big.NewInt(qty), Price: price}
+}
+
+func sell(block uint64, ts int64, qty int64, price float64) Event {
+	return Event{Asset: asset, Block: base.Blknum(block), Timestamp: base.Timestamp(ts), Out: big.NewInt(qty), Price: price}
+}
+
+func TestMethodsChooseDifferentLots(t *testing.T) {
+	events := []Event{
+		sell(4, 40*day, 1, 25), // deliberately out of order; Compute sorts by block
+		buy(1, 10*day, 1, 10),
+		buy(2, 20*day, 1, 30),
+		buy(3, 30*day, 1, 20),
+	}
+
+	cases := []struct {
+		method Method
+		basis  float64
+	}{
+		{FIFO, 10},
+		{LIFO, 20},
+		{HIFO, 30},
+	}
+	for _, tc := range cases {
+		t.Run(string(tc.method), func(t *testing.T) {
+			disposals := Compute(tc.method, events)
+			require.Len(t, disposals, 1)
+			d := disposals[0]
+			assert.Equal(t, 25.0, d.Proceeds)
+			assert.Equal(t, tc.basis, d.CostBasis)
+			assert.Equal(t, 25-tc.basis, d.Realized)
+			// Two lots remain; their combined basis is 60 less the lot that was sold
+			assert.InDelta(t, 2*25-(60-tc.basis), d.Unrealized, 1e-9)
+		})
+	}
+}
+
+func TestDisposalSplitsByTermAndReportsUnmatched(t *testing.T) {
+	disposals := Compute(FIFO, []Event{
+		buy(1, 0, 2, 10),
+		buy(2, 100*day, 2, 12),
+		buy(3, 200*day, 1, 14),
+		sell(4, 400*day, 6, 20),
+	})
+	require.Len(t, disposals, 3)
+
+	short, long, unmatched := disposals[0], disposals[1], disposals[2]
+	assert.Equal(t, LongTerm, long.Term)
+	assert.Equal(t, int64(2), long.Quantity.Int64())
+	assert.Equal(t, base.Timestamp(0), long.Acquired)
+	assert.False(t, long.Various)
+
+	assert.Equal(t, ShortTerm, short.Term)
+	assert.Equal(t, int64(3), short.Quantity.Int64())
+	assert.True(t, short.Various, "lots acquired on different dates")
+	assert.Equal(t, 2*12.0+14, short.CostBasis)
+
+	assert.Equal(t, int64(1), unmatched.Unmatched.Int64())
+	assert.Equal(t, 0.0, unmatched.CostBasis)
+	assert.Equal(t, 20.0, unmatched.Realized)
+}
+
+func TestParseMethod(t *testing.T) {
+	m, err := ParseMethod("")
+	require.NoError(t, err)
+	assert.Equal(t, FIFO, m)
+	m, err = ParseMethod(" HIFO ")
+	require.NoError(t, err)
+	assert.Equal(t, HIFO, m)
+	_, err = ParseMethod("average")
+	assert.Error(t, err)
+}
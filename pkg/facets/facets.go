@@ -8,6 +8,7 @@ import (
 
 	"github.com/TrueBlocks/trueblocks-explorer/pkg/msgs"
 	"github.com/TrueBlocks/trueblocks-explorer/pkg/progress"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/sorting"
 	"github.com/TrueBlocks/trueblocks-explorer/pkg/store"
 	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
 
@@ -39,6 +40,16 @@ type Facet[T any] struct {
 	buckets         *types.Buckets
 	bucketsMu       sync.RWMutex
 	useMapKey       bool
+	version         uint64
+	cache           *pageCache[T]
+}
+
+// pageCache holds the filtered and sorted rows from the last GetPage so that paging
+// through the same query does not repeat the work
+type pageCache[T any] struct {
+	filterKey string
+	sortKey   string
+	items     []T
 }
 
 func NewFacet[T any](
@@ -82,10 +93,14 @@ func (r *Facet[T]) NeedsUpdate() bool {
 	return state == types.StateStale
 }
 
+// Reset drops the view and the store's rows and snapshot, so the next fetch rebuilds
+// everything. An incremental store that is only marked stale keeps its rows instead and
+// fetches just the newer ones.
 func (r *Facet[T]) Reset() {
 	r.mutex.Lock()
 	r.view = r.view[:0]
 	r.expectedCnt = 0
+	r.invalidate()
 	storeToReset := r.store
 	r.mutex.Unlock()
 
@@ -136,7 +151,7 @@ var ErrAlreadyLoading = errors.New("already loading")
 func (r *Facet[T]) FetchFacet() error {
 	currentState := r.GetState()
 
-	if currentState == types.StateFetching {
+	if currentState == types.StateFetching || currentState == types.StateCached {
 		return ErrAlreadyLoading
 	}
 
@@ -145,6 +160,12 @@ func (r *Facet[T]) FetchFacet() error {
 		return nil
 	}
 
+	r.startFetch()
+	return nil
+}
+
+// startFetch runs the store's Fetch in the background, reporting progress as it goes
+func (r *Facet[T]) startFetch() {
 	go func() {
 		ticker := time.NewTicker(progress.MaxWaitTime / 2)
 		defer ticker.Stop()
@@ -174,22 +195,38 @@ func (r *Facet[T]) FetchFacet() error {
 			}
 		}
 	}()
-
-	return nil
 }
 
+// GetPage returns one window of the view after filtering and sorting it. The filtered,
+// sorted rows are cached under (filterKey, sortSpec), where filterKey identifies the
+// filter (normally the filter text the filter function was built from), so paging
+// through the same query only slices the cached rows. The cache is dropped whenever
+// the view changes.
 func (r *Facet[T]) GetPage(
 	first, pageSize int,
+	filterKey string,
 	filter FilterFunc[T],
 	sortSpec sdk.SortSpec,
 	sortFunc func([]T, sdk.SortSpec) error,
 ) (*PageResult[T], error) {
+	sortKey := sortCacheKey(sortSpec)
+
+	r.mutex.RLock()
+	cache := r.cache
+	state := r.GetState()
+	r.mutex.RUnlock()
+
+	if cache != nil && cache.filterKey == filterKey && cache.sortKey == sortKey {
+		return paginate(cache.items, first, pageSize, state), nil
+	}
+
 	r.mutex.RLock()
 	data := make([]T, len(r.view))
 	for i, ptr := range r.view {
 		data[i] = *ptr
 	}
-	state := r.GetState()
+	version := r.version
+	state = r.GetState()
 	r.mutex.RUnlock()
 
 	if len(data) == 0 {
@@ -202,6 +239,7 @@ func (r *Facet[T]) GetPage(
 			for i, ptr := range r.view {
 				data[i] = *ptr
 			}
+			version = r.version
 			state = r.GetState()
 			r.mutex.RUnlock()
 		} else if r.NeedsUpdate() {
@@ -230,29 +268,57 @@ func (r *Facet[T]) GetPage(
 		}
 	}
 
+	// Only keep the result if the view did not change while we were working on it
+	if len(data) > 0 {
+		r.mutex.Lock()
+		if r.version == version {
+			r.cache = &pageCache[T]{
+				filterKey: filterKey,
+				sortKey:   sortKey,
+				items:     filteredData,
+			}
+		}
+		r.mutex.Unlock()
+	}
+
+	return paginate(filteredData, first, pageSize, state), nil
+}
+
+// paginate copies one window out of items. The copy keeps callers that decorate the
+// returned rows from writing into the cached rows.
+func paginate[T any](items []T, first, pageSize int, state types.StoreState) *PageResult[T] {
 	// Normalize pagination parameters
 	start := max(0, first)
 	end := start + max(0, pageSize)
 
 	// Handle out-of-bounds cases
-	if start >= len(filteredData) {
+	if start >= len(items) {
 		start, end = 0, 0
 	} else {
-		end = min(end, len(filteredData))
+		end = min(end, len(items))
 	}
 
-	var paginatedData []T
-	if start < end {
-		paginatedData = filteredData[start:end]
-	} else {
-		paginatedData = []T{}
-	}
+	paginatedData := make([]T, end-start)
+	copy(paginatedData, items[start:end])
 
 	return &PageResult[T]{
 		Items:      paginatedData,
-		TotalItems: len(filteredData),
+		TotalItems: len(items),
 		State:      state,
-	}, nil
+	}
+}
+
+// sortCacheKey renders a sort spec in a form suitable for comparing cache entries
+func sortCacheKey(spec sdk.SortSpec) string {
+	spec = sorting.Normalize(spec)
+	return fmt.Sprintf("%v|%v", spec.Fields, spec.Order)
+}
+
+// invalidate drops the cached page rows and marks the view as changed. The caller
+// must hold the write lock.
+func (r *Facet[T]) invalidate() {
+	r.version++
+	r.cache = nil
 }
 
 func (r *Facet[T]) GetStore() *store.Store[T] {
@@ -268,6 +334,7 @@ func (r *Facet[T]) SyncWithStore() {
 	storeItems := store.GetItems(r.useMapKey)
 
 	r.mutex.Lock()
+	r.invalidate()
 	r.view = make([]*T, 0, len(storeItems))
 	for i := range storeItems {
 		itemPtr := storeItems[i]
@@ -304,6 +371,7 @@ func (r *Facet[T]) OnNewItem(item *T, index int) {
 	// Add to view
 	r.mutex.Lock()
 	r.view = append(r.view, item)
+	r.invalidate()
 	currentCount := len(r.view)
 	expectedTotal := r.store.GetExpectedTotal()
 	r.mutex.Unlock()
@@ -318,6 +386,7 @@ func (r *Facet[T]) OnStateChanged(state types.StoreState, reason string) {
 		r.expectedCnt = 0
 		r.mutex.Lock()
 		r.view = r.view[:0]
+		r.invalidate()
 		r.mutex.Unlock()
 		if r.summaryProvider != nil {
 			r.summaryProvider.ResetSummary()
@@ -328,11 +397,38 @@ func (r *Facet[T]) OnStateChanged(state types.StoreState, reason string) {
 		r.expectedCnt = 0
 		r.mutex.Lock()
 		r.view = r.view[:0]
+		r.invalidate()
 		r.mutex.Unlock()
 		if r.summaryProvider != nil {
 			r.summaryProvider.ResetSummary()
 		}
 
+	case types.StateCached:
+		// The store kept what it had and is fetching only newer rows, which arrive
+		// through OnNewItem. The view only needs rebuilding if it no longer matches
+		// the store (a snapshot was just loaded, or stray rows were trimmed).
+		r.mutex.RLock()
+		before := len(r.view)
+		r.mutex.RUnlock()
+		r.SyncWithStore()
+		r.mutex.RLock()
+		changed := len(r.view) != before
+		r.mutex.RUnlock()
+		if changed {
+			r.ClearBuckets()
+			if r.summaryProvider != nil {
+				r.summaryProvider.ResetSummary()
+				r.mutex.RLock()
+				for _, item := range r.view {
+					r.summaryProvider.AccumulateItem(item, &types.Summary{})
+				}
+				r.mutex.RUnlock()
+			}
+		}
+		r.mutex.Lock()
+		r.expectedCnt = r.store.GetExpectedTotal()
+		r.mutex.Unlock()
+
 	case types.StateLoaded:
 		r.SyncWithStore()
 		r.mutex.RLock()
@@ -374,6 +470,7 @@ func (r *Facet[T]) ForEvery(actionFunc func(itemMatched *T) (error, bool), match
 	if matchCount > 0 {
 		r.view = filteredData
 		r.expectedCnt = len(r.view)
+		r.invalidate()
 	}
 
 	return matchCount, nil
@@ -387,5 +484,10 @@ func (r *Facet[T]) ExportData(payload *types.Payload, typeName string) (string,
 		data[i] = *ptr
 	}
 	r.mutex.RUnlock()
+	if payload != nil && payload.Sort != nil {
+		if err := sorting.SortBy(data, *payload.Sort); err != nil {
+			return "", fmt.Errorf("error sorting data: %w", err)
+		}
+	}
 	return types.ExportData(data, payload, typeName)
 }
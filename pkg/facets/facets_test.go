@@ -167,21 +167,21 @@ func TestFacetPagination(t *testing.T) {
 		return facet.GetState() == types.StateLoaded
 	}, "facet to be loaded (TestFacetPagination)")
 
-	page1, err := facet.GetPage(0, 2, nil, sdk.SortSpec{}, nil)
+	page1, err := facet.GetPage(0, 2, "", nil, sdk.SortSpec{}, nil)
 	assert.NoError(err, "GetPage failed")
 	assert.Len(page1.Items, 2, "Expected 2 items in first page")
 	assert.Equal(5, page1.TotalItems, "Expected total items to be 5")
 	assert.Equal(types.StateLoaded, page1.State, "Expected page state to be StateLoaded")
 
-	page2, err := facet.GetPage(2, 2, nil, sdk.SortSpec{}, nil)
+	page2, err := facet.GetPage(2, 2, "", nil, sdk.SortSpec{}, nil)
 	assert.NoError(err, "GetPage failed")
 	assert.Len(page2.Items, 2, "Expected 2 items in second page")
 
-	page3, err := facet.GetPage(4, 2, nil, sdk.SortSpec{}, nil)
+	page3, err := facet.GetPage(4, 2, "", nil, sdk.SortSpec{}, nil)
 	assert.NoError(err, "GetPage failed")
 	assert.Len(page3.Items, 1, "Expected 1 item in last page")
 
-	pageEmpty, err := facet.GetPage(10, 2, nil, sdk.SortSpec{}, nil)
+	pageEmpty, err := facet.GetPage(10, 2, "", nil, sdk.SortSpec{}, nil)
 	assert.NoError(err, "GetPage failed")
 	assert.Len(pageEmpty.Items, 0, "Expected 0 items for out of bounds page")
 }
@@ -203,7 +203,7 @@ func TestFacetPageWithFilter(t *testing.T) {
 		return item.Value >= 30
 	}
 
-	page, err := facet.GetPage(0, 5, filterFunc, sdk.SortSpec{}, nil)
+	page, err := facet.GetPage(0, 5, "value>=30", filterFunc, sdk.SortSpec{}, nil)
 	assert.NoError(err, "GetPage with filter failed")
 
 	assert.Len(page.Items, 3, "Expected 3 filtered items")
@@ -230,7 +230,7 @@ func TestFacetSorting(t *testing.T) {
 		return nil
 	}
 
-	page, err := facet.GetPage(0, 5, nil, sdk.SortSpec{}, sortFunc)
+	page, err := facet.GetPage(0, 5, "", nil, sdk.SortSpec{}, sortFunc)
 	assert.NoError(err, "GetPage with sort failed")
 
 	assert.Len(page.Items, 5, "Expected 5 items")
@@ -238,6 +238,67 @@ func TestFacetSorting(t *testing.T) {
 	assert.Equal(10, page.Items[4].Value, "Expected last item value to be 10")
 }
 
+func TestFacetPageCache(t *testing.T) {
+	assert := assert.New(t)
+
+	testStore := createTestStore()
+	facet := createTestFacet(testStore)
+
+	err := facet.FetchFacet()
+	assert.NoError(err, "Load failed")
+
+	waitForCondition(t, 5*time.Second, facet, func() bool {
+		return facet.GetState() == types.StateLoaded
+	}, "facet to be loaded (TestFacetPageCache)")
+
+	sorts := 0
+	sortFunc := func(items []TestItem, spec sdk.SortSpec) error {
+		sorts++
+		slices.SortFunc(items, func(a, b TestItem) int {
+			return cmp.Compare(b.Value, a.Value)
+		})
+		return nil
+	}
+	filterFunc := func(item *TestItem) bool {
+		return item.Value >= 20
+	}
+	spec := sdk.SortSpec{Fields: []string{"value"}, Order: []sdk.SortOrder{sdk.Dec}}
+
+	page1, err := facet.GetPage(0, 2, "value>=20", filterFunc, spec, sortFunc)
+	assert.NoError(err, "GetPage failed")
+	page2, err := facet.GetPage(2, 2, "value>=20", filterFunc, spec, sortFunc)
+	assert.NoError(err, "GetPage failed")
+	assert.Equal(1, sorts, "Expected the second page to come from the cache")
+	assert.Equal([]int{50, 40}, []int{page1.Items[0].Value, page1.Items[1].Value})
+	assert.Equal([]int{30, 20}, []int{page2.Items[0].Value, page2.Items[1].Value})
+	assert.Equal(4, page2.TotalItems)
+
+	page2.Items[0].Value = 999
+	again, err := facet.GetPage(2, 2, "value>=20", filterFunc, spec, sortFunc)
+	assert.NoError(err, "GetPage failed")
+	assert.Equal(30, again.Items[0].Value, "Changing a returned row must not change the cache")
+
+	_, err = facet.GetPage(0, 2, "", nil, spec, sortFunc)
+	assert.NoError(err, "GetPage failed")
+	assert.Equal(2, sorts, "Expected a different filter to miss the cache")
+
+	facet.OnNewItem(&TestItem{ID: 6, Name: "Item 6", Value: 60}, 5)
+	page, err := facet.GetPage(0, 2, "", nil, spec, sortFunc)
+	assert.NoError(err, "GetPage failed")
+	assert.Equal(3, sorts, "Expected a new item to invalidate the cache")
+	assert.Equal(60, page.Items[0].Value)
+	assert.Equal(6, page.TotalItems)
+
+	_, err = facet.ForEvery(func(*TestItem) (error, bool) { return nil, true }, func(item *TestItem) bool {
+		return item.Value == 60
+	})
+	assert.NoError(err, "ForEvery failed")
+	page, err = facet.GetPage(0, 2, "", nil, spec, sortFunc)
+	assert.NoError(err, "GetPage failed")
+	assert.Equal(4, sorts, "Expected removing an item to invalidate the cache")
+	assert.Equal(50, page.Items[0].Value)
+}
+
 func TestFacetSortingError(t *testing.T) {
 	assert := assert.New(t)
 
@@ -255,7 +316,7 @@ func TestFacetSortingError(t *testing.T) {
 		return errors.New("sort error")
 	}
 
-	_, err = facet.GetPage(0, 5, nil, sdk.SortSpec{}, sortFuncError)
+	_, err = facet.GetPage(0, 5, "", nil, sdk.SortSpec{}, sortFuncError)
 	assert.Error(err, "Expected GetPage to return error when sort function fails")
 	assert.EqualError(err, "error sorting data: sort error", "Expected specific error message")
 }
@@ -281,6 +342,36 @@ func TestFacetObserverInterface(t *testing.T) {
 	assert.Equal(types.StateLoaded, facet.GetState(), "Expected state to be StateLoaded with data")
 }
 
+func TestFacetCachedState(t *testing.T) {
+	testStore := createTestStore()
+	facet := createTestFacet(testStore)
+
+	testStore.AddItem(&TestItem{ID: 1, Name: "Cached1", Value: 10}, 0)
+	testStore.AddItem(&TestItem{ID: 2, Name: "Cached2", Value: 20}, 1)
+	facet.OnStateChanged(types.StateStale, "snapshot loaded")
+	assert.Equal(t, 0, facet.Count(), "Stale state should clear the view")
+
+	testStore.ChangeState(types.StateCached, "Test cached")
+	assert.Equal(t, types.StateCached, facet.GetState(), "Expected state to be StateCached")
+	assert.Equal(t, 2, facet.Count(), "Cached state should show the store's snapshot")
+	assert.False(t, facet.NeedsUpdate(), "Cached facet is refreshing and should not need update")
+	assert.ErrorIs(t, facet.FetchFacet(), ErrAlreadyLoading, "Cached facet should report it is already loading")
+}
+
+func TestFacetResetClearsIncrementalStore(t *testing.T) {
+	testStore := createTestStore()
+	facet := createTestFacet(testStore)
+
+	testStore.AddItem(&TestItem{ID: 1, Name: "Loaded1", Value: 10}, 0)
+	testStore.ChangeState(types.StateLoaded, "Test loaded")
+	testStore.SetIncremental(true)
+
+	facet.Reset()
+	assert.Equal(t, 0, testStore.Count(), "Reset rebuilds an incremental store from scratch")
+	assert.Equal(t, types.StateStale, facet.GetState())
+	assert.True(t, facet.NeedsUpdate())
+}
+
 func TestFacetForEvery(t *testing.T) {
 	assert := assert.New(t)
 
@@ -374,15 +465,15 @@ func TestFacetEdgeCases(t *testing.T) {
 	testStore := createTestStore()
 	facet := createTestFacet(testStore)
 
-	page, err := facet.GetPage(0, 10, nil, sdk.SortSpec{}, nil)
+	page, err := facet.GetPage(0, 10, "", nil, sdk.SortSpec{}, nil)
 	assert.NoError(err, "GetPage should not fail with empty data")
 	assert.Len(page.Items, 0, "Expected 0 items with empty data")
 	assert.Equal(0, page.TotalItems, "Expected total items to be 0")
 
-	_, err = facet.GetPage(-1, 5, nil, sdk.SortSpec{}, nil)
+	_, err = facet.GetPage(-1, 5, "", nil, sdk.SortSpec{}, nil)
 	assert.NoError(err, "GetPage should handle negative start")
 
-	page, err = facet.GetPage(0, 0, nil, sdk.SortSpec{}, nil)
+	page, err = facet.GetPage(0, 0, "", nil, sdk.SortSpec{}, nil)
 	assert.NoError(err, "GetPage should handle zero page size")
 	assert.Len(page.Items, 0, "Expected 0 items with zero page size")
 }
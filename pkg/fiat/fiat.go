@@ -0,0 +1,201 @@
+// Package fiat values asset quantities in a chosen currency. Prices come from the
+// statements' on-chain spot prices (which are in USD) when they are present and from a
+// local price file otherwise.
+//
+// A price file is a CSV with a header row and the columns asset, date, price and,
+// optionally, currency. Asset is a token address or a symbol; date is YYYY-MM-DD or a
+// Unix timestamp; price is the price of one whole unit. Rows whose currency is empty are
+// taken to be in the converter's currency. A row whose asset is USD gives the price of
+// one US dollar, which is how spot prices are converted to a currency other than USD.
+package fiat
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+)
+
+// DefaultCurrency is the currency of the on-chain spot prices
+const DefaultCurrency = "USD"
+
+// Where a price came from
+const (
+	SourceSpot = "spot" // the statement's spot price, converted if need be
+	SourceFile = "file" // the local price file
+	SourceNone = ""     // no price is known
+)
+
+// Quote is the price of one whole unit of an asset at some moment
+type Quote struct {
+	Currency string
+	Price    float64
+	Source   string
+}
+
+// Known reports whether the quote carries a price
+func (q Quote) Known() bool {
+	return q.Source != SourceNone
+}
+
+type point struct {
+	ts    int64
+	price float64
+}
+
+// Converter prices assets in a single currency
+type Converter struct {
+	currency string
+	prices   map[string][]point
+}
+
+// NewConverter returns a converter for currency (USD if empty) with no price file
+func NewConverter(currency string) *Converter {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+	return &Converter{currency: currency, prices: make(map[string][]point)}
+}
+
+// Currency returns the code of the currency the converter prices in
+func (c *Converter) Currency() string {
+	return c.currency
+}
+
+// LoadFile adds the prices in the file at path to the converter
+func (c *Converter) LoadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := c.Load(f); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// Load adds the prices read from a CSV price file to the converter
+func (c *Converter) Load(r io.Reader) error {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		return err
+	}
+	cols := make(map[string]int, len(header))
+	for i, name := range header {
+		cols[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"asset", "date", "price"} {
+		if _, ok := cols[required]; !ok {
+			return fmt.Errorf("price file has no %s column", required)
+		}
+	}
+
+	field := func(record []string, name string) string {
+		if i, ok := cols[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return err
+		}
+		if currency := field(record, "currency"); currency != "" && !strings.EqualFold(currency, c.currency) {
+			continue
+		}
+		ts, err := parseDate(field(record, "date"))
+		if err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		price, err := strconv.ParseFloat(field(record, "price"), 64)
+		if err != nil {
+			return fmt.Errorf("line %d: invalid price %q", line, field(record, "price"))
+		}
+		key := assetKey(field(record, "asset"))
+		c.prices[key] = append(c.prices[key], point{ts: ts, price: price})
+	}
+
+	for key := range c.prices {
+		points := c.prices[key]
+		sort.SliceStable(points, func(i, j int) bool { return points[i].ts < points[j].ts })
+	}
+	return nil
+}
+
+// Price returns the price of one whole unit of the asset at ts. A positive spotUSD is
+// preferred, converted to the converter's currency; otherwise the most recent price in the
+// file at or before ts, looked up by address and then by symbol.
+func (c *Converter) Price(asset base.Address, symbol string, ts base.Timestamp, spotUSD float64) Quote {
+	if spotUSD > 0 {
+		if c.currency == DefaultCurrency {
+			return Quote{Currency: c.currency, Price: spotUSD, Source: SourceSpot}
+		}
+		if rate, ok := c.lookup(DefaultCurrency, ts); ok {
+			return Quote{Currency: c.currency, Price: spotUSD * rate, Source: SourceSpot}
+		}
+	}
+
+	if price, ok := c.lookup(asset.Hex(), ts); ok {
+		return Quote{Currency: c.currency, Price: price, Source: SourceFile}
+	}
+	if symbol != "" {
+		if price, ok := c.lookup(symbol, ts); ok {
+			return Quote{Currency: c.currency, Price: price, Source: SourceFile}
+		}
+	}
+	return Quote{Currency: c.currency, Source: SourceNone}
+}
+
+// Value returns the value of units whole units of the asset at ts along with the quote used
+func (c *Converter) Value(asset base.Address, symbol string, ts base.Timestamp, spotUSD, units float64) (float64, Quote) {
+	quote := c.Price(asset, symbol, ts, spotUSD)
+	return units * quote.Price, quote
+}
+
+func (c *Converter) lookup(asset string, ts base.Timestamp) (float64, bool) {
+	points := c.prices[assetKey(asset)]
+	i := sort.Search(len(points), func(i int) bool { return points[i].ts > int64(ts) })
+	if i == 0 {
+		return 0, false
+	}
+	return points[i-1].price, true
+}
+
+// assetKey folds addresses to lower case and symbols to upper case so either spelling matches
+func assetKey(asset string) string {
+	if strings.HasPrefix(strings.ToLower(asset), "0x") {
+		return strings.ToLower(asset)
+	}
+	return strings.ToUpper(asset)
+}
+
+func parseDate(s string) (int64, error) {
+	if ts, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return ts, nil
+	}
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid date %q (want YYYY-MM-DD or a Unix timestamp)", s)
+	}
+	return t.Unix(), nil
+}
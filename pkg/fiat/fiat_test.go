@@ -0,0 +1,65 @@
+package fiat
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const priceFile = `asset,date,price,currency
+0xA0b86991c6218b36c1d19d4a2e9eb0ce3606eb48,2024-01-01,0.91,EUR
+USD,2024-01-01,0.90,EUR
+USD,2024-02-01,0.95,EUR
+dai,2024-01-01,0.92,
+0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48,2024-01-01,1.00,USD
+`
+
+var usdc = base.HexToAddress("0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48")
+
+func ts(date string) base.Timestamp {
+	v, _ := parseDate(date)
+	return base.Timestamp(v)
+}
+
+func TestSpotPricesArePreferred(t *testing.T) {
+	c := NewConverter("eur")
+	require.NoError(t, c.Load(strings.NewReader(priceFile)))
+	assert.Equal(t, "EUR", c.Currency())
+
+	q := c.Price(usdc, "USDC", ts("2024-01-15"), 2.0)
+	assert.Equal(t, SourceSpot, q.Source)
+	assert.InDelta(t, 1.80, q.Price, 1e-9, "spot price converted at the January rate")
+
+	q = c.Price(usdc, "USDC", ts("2024-03-01"), 2.0)
+	assert.InDelta(t, 1.90, q.Price, 1e-9, "the most recent rate applies")
+
+	usd := NewConverter("")
+	q = usd.Price(usdc, "USDC", ts("2024-01-15"), 2.0)
+	assert.Equal(t, Quote{Currency: "USD", Price: 2.0, Source: SourceSpot}, q)
+}
+
+func TestPriceFileFallback(t *testing.T) {
+	c := NewConverter("EUR")
+	require.NoError(t, c.Load(strings.NewReader(priceFile)))
+
+	q := c.Price(usdc, "USDC", ts("2024-01-02"), 0)
+	assert.Equal(t, SourceFile, q.Source)
+	assert.InDelta(t, 0.91, q.Price, 1e-9, "rows in other currencies are ignored")
+
+	value, q := c.Value(base.HexToAddress("0x6b175474e89094c44da98b954eedeac495271d0f"), "DAI", ts("2024-06-01"), 0, 10)
+	assert.Equal(t, SourceFile, q.Source, "falls back to the symbol")
+	assert.InDelta(t, 9.2, value, 1e-9)
+
+	q = c.Price(usdc, "USDC", ts("2023-12-31"), 0)
+	assert.False(t, q.Known(), "nothing is known before the first price")
+}
+
+func TestLoadRejectsBadFiles(t *testing.T) {
+	c := NewConverter("USD")
+	assert.Error(t, c.Load(strings.NewReader("asset,price\nETH,1\n")))
+	assert.Error(t, c.Load(strings.NewReader("asset,date,price\nETH,yesterday,1\n")))
+	assert.NoError(t, c.Load(strings.NewReader("")))
+}
@@ -25,6 +25,7 @@ type WriteRequest struct {
 	FilePath string
 	Data     []byte
 	Priority Priority
+	Remove   bool // delete the file instead of writing Data
 	ErrChan  chan error
 }
 
@@ -2,6 +2,7 @@ package filewriter
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -65,6 +66,26 @@ func (w *Writer) WriteFile(filePath string, data []byte, priority Priority) erro
 	}
 }
 
+// RemoveFile deletes filePath after dropping any write of it still waiting to be flushed,
+// so a queued write cannot bring the file back. A file that does not exist is not an error.
+func (w *Writer) RemoveFile(filePath string) error {
+	errChan := make(chan error, 1)
+
+	req := WriteRequest{
+		FilePath: filePath,
+		Priority: Immediate,
+		Remove:   true,
+		ErrChan:  errChan,
+	}
+
+	select {
+	case w.writeChan <- req:
+		return <-errChan
+	case <-w.ctx.Done():
+		return fmt.Errorf("writer is shutting down")
+	}
+}
+
 func (w *Writer) Shutdown() error {
 	close(w.shutdownChan)
 
@@ -108,7 +129,9 @@ func (w *Writer) writerLoop() {
 	for {
 		select {
 		case req := <-w.writeChan:
-			if req.Priority == Immediate {
+			if req.Remove {
+				w.doRemove(req)
+			} else if req.Priority == Immediate {
 				w.doImmediateWrite(req)
 			} else {
 				w.handleBatchedWrite(req)
@@ -138,6 +161,23 @@ func (w *Writer) doImmediateWrite(req WriteRequest) {
 	req.ErrChan <- err
 }
 
+func (w *Writer) doRemove(req WriteRequest) {
+	if pending, exists := w.pending[req.FilePath]; exists {
+		pending.ErrChan <- nil
+		delete(w.pending, req.FilePath)
+	}
+
+	err := os.Remove(req.FilePath)
+	if errors.Is(err, os.ErrNotExist) {
+		err = nil
+	}
+	if err != nil {
+		atomic.AddInt64(&w.metrics.Errors, 1)
+	}
+
+	req.ErrChan <- err
+}
+
 func (w *Writer) handleBatchedWrite(req WriteRequest) {
 	if existing, exists := w.pending[req.FilePath]; exists {
 		existing.ErrChan <- nil
@@ -165,7 +205,9 @@ func (w *Writer) flushAllWrites() {
 	for {
 		select {
 		case req := <-w.writeChan:
-			if req.Priority == OnShutdown || req.Priority == Batched {
+			if req.Remove {
+				w.doRemove(req)
+			} else if req.Priority == OnShutdown || req.Priority == Batched {
 				w.pending[req.FilePath] = &req
 			} else {
 				w.doImmediateWrite(req)
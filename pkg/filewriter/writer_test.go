@@ -152,3 +152,40 @@ func TestPriorityHandling(t *testing.T) {
 		t.Error("Expected immediate writes to be processed")
 	}
 }
+
+func TestRemoveDropsPendingWrite(t *testing.T) {
+	tempDir := t.TempDir()
+	testFile := filepath.Join(tempDir, "test.txt")
+
+	writer := NewWriterWithConfig(Config{
+		BatchInterval:   time.Hour,
+		ChannelBuffer:   10,
+		ShutdownTimeout: 100 * time.Millisecond,
+	})
+	writer.Start()
+
+	if err := writer.WriteFile(testFile, []byte("old"), Immediate); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- writer.WriteFile(testFile, []byte("queued"), Batched)
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	if err := writer.RemoveFile(testFile); err != nil {
+		t.Fatalf("RemoveFile failed: %v", err)
+	}
+	if err := <-done; err != nil {
+		t.Errorf("Dropped write reported %v", err)
+	}
+	if err := writer.RemoveFile(testFile); err != nil {
+		t.Errorf("Removing a missing file failed: %v", err)
+	}
+
+	_ = writer.Shutdown()
+	if _, err := os.Stat(testFile); !os.IsNotExist(err) {
+		t.Error("A write queued before the removal brought the file back")
+	}
+}
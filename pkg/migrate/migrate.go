@@ -0,0 +1,283 @@
+// Package migrate upgrades versioned JSON files (projects and preferences) one format
+// version at a time.
+//
+// Each kind of file has a Registry: its current version and an ordered chain of Steps,
+// each of which upgrades a decoded document from one version to the next and describes
+// what it changed. A file is read, planned against the registry, and every step from its
+// version to the current one is applied in order. DryRun reports what would change
+// without keeping it; Upgrade backs the original file up before handing back the
+// upgraded document. A file whose version is newer than the registry's current version
+// is refused with a TooNewError rather than being rewritten in an older format.
+package migrate
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// VersionKey is the field of the document that holds its format version
+const VersionKey = "version"
+
+// Step upgrades a document from one version to the next. Apply changes doc in place and
+// returns a line for each change it made; the version field is updated for it.
+type Step struct {
+	From        string
+	To          string
+	Description string
+	Apply       func(doc map[string]any) ([]string, error)
+}
+
+// StepReport says what one step did, or would do, to a document
+type StepReport struct {
+	From        string   `json:"from"`
+	To          string   `json:"to"`
+	Description string   `json:"description"`
+	Changes     []string `json:"changes"`
+}
+
+// Report says what migrating a document did, or would do
+type Report struct {
+	Kind   string       `json:"kind"`
+	File   string       `json:"file,omitempty"`
+	From   string       `json:"from"`
+	To     string       `json:"to"`
+	Steps  []StepReport `json:"steps"`
+	Backup string       `json:"backup,omitempty"`
+}
+
+// NeedsMigration reports whether the document was (or would be) changed
+func (r *Report) NeedsMigration() bool {
+	return len(r.Steps) > 0
+}
+
+// String describes the report for logs and dry runs
+func (r *Report) String() string {
+	name := r.Kind
+	if r.File != "" {
+		name = fmt.Sprintf("%s %s", r.Kind, r.File)
+	}
+	if !r.NeedsMigration() {
+		return fmt.Sprintf("%s is up to date (%s)", name, r.To)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s: %s -> %s", name, r.From, r.To)
+	for _, step := range r.Steps {
+		fmt.Fprintf(&sb, "\n  %s -> %s: %s", step.From, step.To, step.Description)
+		for _, change := range step.Changes {
+			fmt.Fprintf(&sb, "\n    - %s", change)
+		}
+	}
+	if r.Backup != "" {
+		fmt.Fprintf(&sb, "\n  original saved to %s", r.Backup)
+	}
+	return sb.String()
+}
+
+// TooNewError is returned for a file written by a newer version of the application
+type TooNewError struct {
+	Kind    string
+	Version string
+	Current string
+}
+
+func (e *TooNewError) Error() string {
+	return fmt.Sprintf("this %s was written by a newer version of the application (format %s, but this version reads up to %s); please upgrade", e.Kind, e.Version, e.Current)
+}
+
+// ErrUnknownVersion is returned for a version that is neither in the registry nor newer
+// than its current version
+var ErrUnknownVersion = errors.New("unknown format version")
+
+// Registry holds the migration steps for one kind of file
+type Registry struct {
+	kind    string
+	current string
+	steps   []Step
+}
+
+// NewRegistry returns a registry whose steps lead, in order, to current. It panics if the
+// steps do not form an unbroken chain ending at current, which is a programming error.
+func NewRegistry(kind, current string, steps ...Step) *Registry {
+	for i, step := range steps {
+		if step.Apply == nil {
+			panic(fmt.Sprintf("migrate: %s step %s -> %s has no Apply", kind, step.From, step.To))
+		}
+		if i > 0 && steps[i-1].To != step.From {
+			panic(fmt.Sprintf("migrate: %s step %s -> %s does not follow %s -> %s", kind, step.From, step.To, steps[i-1].From, steps[i-1].To))
+		}
+	}
+	if len(steps) > 0 && steps[len(steps)-1].To != current {
+		panic(fmt.Sprintf("migrate: %s steps end at %s, not %s", kind, steps[len(steps)-1].To, current))
+	}
+	return &Registry{kind: kind, current: current, steps: steps}
+}
+
+// Current returns the version the registry upgrades to
+func (r *Registry) Current() string {
+	return r.current
+}
+
+// Plan returns the steps that take a document at version to the current version. An
+// empty version is the oldest the registry knows.
+func (r *Registry) Plan(version string) ([]Step, error) {
+	version = r.normalize(version)
+	if version == r.current {
+		return nil, nil
+	}
+	for i, step := range r.steps {
+		if step.From == version {
+			return r.steps[i:], nil
+		}
+	}
+	if compareVersions(version, r.current) > 0 {
+		return nil, &TooNewError{Kind: r.kind, Version: version, Current: r.current}
+	}
+	return nil, fmt.Errorf("%s version %q: %w", r.kind, version, ErrUnknownVersion)
+}
+
+// Migrate applies every step the document needs and returns the upgraded document. The
+// input is returned unchanged if it is already current.
+func (r *Registry) Migrate(data []byte) ([]byte, *Report, error) {
+	doc, err := decode(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", r.kind, err)
+	}
+
+	from, _ := doc[VersionKey].(string)
+	report := &Report{Kind: r.kind, From: r.normalize(from), To: r.current, Steps: []StepReport{}}
+	steps, err := r.Plan(from)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(steps) == 0 {
+		return data, report, nil
+	}
+
+	for _, step := range steps {
+		changes, err := step.Apply(doc)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s %s -> %s: %w", r.kind, step.From, step.To, err)
+		}
+		doc[VersionKey] = step.To
+		changes = append(changes, fmt.Sprintf("%s set to %s", VersionKey, step.To))
+		report.Steps = append(report.Steps, StepReport{From: step.From, To: step.To, Description: step.Description, Changes: changes})
+	}
+
+	out, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", r.kind, err)
+	}
+	return out, report, nil
+}
+
+// DryRun reports what Migrate would change without changing anything
+func (r *Registry) DryRun(data []byte) (*Report, error) {
+	_, report, err := r.Migrate(data)
+	return report, err
+}
+
+// DryRunFile reports what upgrading the file at path would change
+func (r *Registry) DryRunFile(path string) (*Report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	report, err := r.DryRun(data)
+	if report != nil {
+		report.File = path
+	}
+	return report, err
+}
+
+// Upgrade migrates data, the contents of the file at path. If the file needs upgrading
+// its original contents are first copied to a backup beside it. Writing the upgraded
+// document back is left to the caller, which knows how the file is saved.
+func (r *Registry) Upgrade(path string, data []byte) ([]byte, *Report, error) {
+	out, report, err := r.Migrate(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	report.File = path
+	if !report.NeedsMigration() {
+		return out, report, nil
+	}
+
+	backup, err := writeBackup(path, report.From, data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not back up %s before upgrading it: %w", path, err)
+	}
+	report.Backup = backup
+	return out, report, nil
+}
+
+// BackupPath is where the original of a file at version is kept when it is upgraded
+func BackupPath(path, version string) string {
+	return fmt.Sprintf("%s.%s.bak", path, strings.TrimPrefix(version, "v"))
+}
+
+// writeBackup copies data to the file's backup. An existing backup of the same version is
+// left alone; it is the same file or an earlier copy of it.
+func writeBackup(path, version string, data []byte) (string, error) {
+	backup := BackupPath(path, version)
+	if _, err := os.Stat(backup); err == nil {
+		return backup, nil
+	}
+	return backup, os.WriteFile(backup, data, 0644)
+}
+
+func (r *Registry) normalize(version string) string {
+	if version != "" {
+		return version
+	}
+	if len(r.steps) > 0 {
+		return r.steps[0].From
+	}
+	return r.current
+}
+
+func decode(data []byte) (map[string]any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var doc map[string]any
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, errors.New("file is not a JSON object")
+	}
+	return doc, nil
+}
+
+// compareVersions compares dotted versions such as 1.0 and v6.5.1 part by part,
+// numerically where both parts are numbers
+func compareVersions(a, b string) int {
+	as := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	bs := strings.Split(strings.TrimPrefix(b, "v"), ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		x, y := "0", "0" // a missing part counts as zero
+		if i < len(as) {
+			x = as[i]
+		}
+		if i < len(bs) {
+			y = bs[i]
+		}
+		xi, xerr := strconv.Atoi(x)
+		yi, yerr := strconv.Atoi(y)
+		switch {
+		case xerr == nil && yerr == nil && xi != yi:
+			if xi < yi {
+				return -1
+			}
+			return 1
+		case (xerr != nil || yerr != nil) && x != y:
+			return strings.Compare(x, y)
+		}
+	}
+	return 0
+}
@@ -0,0 +1,120 @@
+package migrate
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRegistry() *Registry {
+	return NewRegistry("widget", "3",
+		Step{From: "1", To: "2", Description: "colour is now color", Apply: func(doc map[string]any) ([]string, error) {
+			if v, ok := doc["colour"]; ok {
+				doc["color"] = v
+				delete(doc, "colour")
+				return []string{"colour renamed to color"}, nil
+			}
+			return nil, nil
+		}},
+		Step{From: "2", To: "3", Description: "sizes are in pixels", Apply: func(doc map[string]any) ([]string, error) {
+			if n, ok := doc["size"].(json.Number); ok {
+				v, err := n.Int64()
+				if err != nil {
+					return nil, err
+				}
+				doc["size"] = v * 10
+				return []string{"size converted to pixels"}, nil
+			}
+			return nil, nil
+		}},
+	)
+}
+
+func TestMigrateAppliesEveryStepInOrder(t *testing.T) {
+	out, report, err := testRegistry().Migrate([]byte(`{"colour":"red","size":4}`))
+	require.NoError(t, err)
+
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(out, &doc))
+	assert.Equal(t, map[string]any{"version": "3", "color": "red", "size": float64(40)}, doc)
+
+	assert.True(t, report.NeedsMigration())
+	assert.Equal(t, "1", report.From, "an unversioned file is the oldest version")
+	require.Len(t, report.Steps, 2)
+	assert.Equal(t, []string{"colour renamed to color", "version set to 2"}, report.Steps[0].Changes)
+	assert.Equal(t, "3", report.Steps[1].To)
+}
+
+func TestMigrateFromTheMiddleAndWhenCurrent(t *testing.T) {
+	r := testRegistry()
+
+	out, report, err := r.Migrate([]byte(`{"version":"2","colour":"red"}`))
+	require.NoError(t, err)
+	require.Len(t, report.Steps, 1, "only the steps after the file's version run")
+	assert.Contains(t, string(out), `"colour": "red"`)
+
+	data := []byte(`{"version":"3","size":1}`)
+	out, report, err = r.Migrate(data)
+	require.NoError(t, err)
+	assert.False(t, report.NeedsMigration())
+	assert.Equal(t, data, out)
+	assert.Equal(t, "widget is up to date (3)", report.String())
+}
+
+func TestTooNewAndUnknownVersions(t *testing.T) {
+	r := NewRegistry("project", "v6.5.1", Step{From: "1.0", To: "v6.5.1", Apply: func(map[string]any) ([]string, error) { return nil, nil }})
+
+	_, _, err := r.Migrate([]byte(`{"version":"v7.0.0"}`))
+	var tooNew *TooNewError
+	require.ErrorAs(t, err, &tooNew)
+	assert.Equal(t, "v7.0.0", tooNew.Version)
+	assert.Contains(t, err.Error(), "newer version of the application")
+
+	_, _, err = r.Migrate([]byte(`{"version":"0.5"}`))
+	assert.True(t, errors.Is(err, ErrUnknownVersion))
+
+	_, _, err = r.Migrate([]byte(`[1,2]`))
+	assert.Error(t, err)
+}
+
+func TestUpgradeBacksUpAndDryRunDoesNot(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "thing.json")
+	original := []byte(`{"version":"1","colour":"blue"}`)
+	require.NoError(t, os.WriteFile(path, original, 0644))
+	r := testRegistry()
+
+	report, err := r.DryRunFile(path)
+	require.NoError(t, err)
+	assert.True(t, report.NeedsMigration())
+	assert.Empty(t, report.Backup)
+	assert.NoFileExists(t, BackupPath(path, "1"))
+	assert.Contains(t, report.String(), "colour renamed to color")
+
+	_, report, err = r.Upgrade(path, original)
+	require.NoError(t, err)
+	assert.Equal(t, BackupPath(path, "1"), report.Backup)
+	saved, err := os.ReadFile(report.Backup)
+	require.NoError(t, err)
+	assert.Equal(t, original, saved)
+}
+
+func TestNewRegistryRejectsBrokenChains(t *testing.T) {
+	noop := func(map[string]any) ([]string, error) { return nil, nil }
+	assert.Panics(t, func() {
+		NewRegistry("x", "3", Step{From: "1", To: "2", Apply: noop}, Step{From: "1", To: "3", Apply: noop})
+	})
+	assert.Panics(t, func() { NewRegistry("x", "4", Step{From: "1", To: "2", Apply: noop}) })
+	assert.NotPanics(t, func() { NewRegistry("x", "1.0") })
+}
+
+func TestCompareVersions(t *testing.T) {
+	assert.Equal(t, -1, compareVersions("1.0", "v6.5.1"))
+	assert.Equal(t, 1, compareVersions("v6.10.0", "v6.9.9"))
+	assert.Equal(t, 0, compareVersions("1.0", "1.0.0"))
+}
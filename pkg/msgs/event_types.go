@@ -20,6 +20,7 @@ const (
 	EventFacetChanged    EventType = "facet:changed"
 	EventProjectClosed   EventType = "project:closed"
 	EventProjectSwitched EventType = "project:switched"
+	EventAutoname        EventType = "names:autoname"
 )
 
 var AllMessages = []struct {
@@ -43,4 +44,5 @@ var AllMessages = []struct {
 	{EventFacetChanged, "FACET_CHANGED"},
 	{EventProjectClosed, "PROJECT_CLOSED"},
 	{EventProjectSwitched, "PROJECT_SWITCHED"},
+	{EventAutoname, "AUTONAME"},
 }
@@ -109,12 +109,13 @@ func InitializeContext(ctx context.Context) {
 
 // emitMessage is the core function for emitting events.
 // It sends events through Wails runtime if context is available,
-// and always dispatches to local listeners if in test mode.
+// and always dispatches to local listeners if in test mode. Taps see every event.
 func emitMessage(messageType EventType, msgText string, payload ...interface{}) {
 	contextMutex.RLock()
 	ctx := wailsContext
 	contextMutex.RUnlock()
 
+	dispatchToTaps(messageType, msgText, payload...)
 	if IsTestMode() {
 		dispatchToListeners(messageType, msgText, payload...)
 	} else {
@@ -194,6 +195,11 @@ func EmitRowAction(payload *types.RowActionPayload) {
 	emitMessage(EventRowAction, "row-action", *payload)
 }
 
+// EmitAutoname reports the progress of a queued autoname job.
+func EmitAutoname(msgText string, payload ...interface{}) {
+	emitMessage(EventAutoname, msgText, payload...)
+}
+
 // On registers a callback function for a specific event type.
 // In production, it uses Wails' runtime.EventsOn.
 // In test mode, it registers the callback with the internal listener system.
@@ -293,3 +299,41 @@ func dispatchToListeners(eventType EventType, msgText string, payload ...interfa
 		}(listener)
 	}
 }
+
+var (
+	taps     = make(map[int]func(eventType EventType, optionalData ...interface{}))
+	nextTap  int
+	tapsLock sync.RWMutex
+)
+
+// Tap registers a callback that sees every event emitted from the backend, whether or not
+// the Wails runtime is running. It is how consumers outside the frontend (the headless
+// API server, for example) follow the application. It returns an unsubscribe function.
+func Tap(callback func(eventType EventType, optionalData ...interface{})) func() {
+	tapsLock.Lock()
+	defer tapsLock.Unlock()
+	id := nextTap
+	nextTap++
+	taps[id] = callback
+	return func() {
+		tapsLock.Lock()
+		defer tapsLock.Unlock()
+		delete(taps, id)
+	}
+}
+
+// dispatchToTaps sends an event to every registered tap. Taps are called synchronously
+// and must not block.
+func dispatchToTaps(eventType EventType, msgText string, payload ...interface{}) {
+	tapsLock.RLock()
+	defer tapsLock.RUnlock()
+	if len(taps) == 0 {
+		return
+	}
+
+	args := []interface{}{msgText}
+	args = append(args, payload...)
+	for _, tap := range taps {
+		tap(eventType, args...)
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/TrueBlocks/trueblocks-explorer/pkg/filewriter"
 	"github.com/TrueBlocks/trueblocks-explorer/pkg/logging"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/refresh"
 
 	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/file"
 	"github.com/kbinani/screenshot"
@@ -42,6 +43,20 @@ type OpenProject struct {
 	IsActive bool   `json:"isActive,omitempty"` // Which project is currently active
 }
 
+// RefreshMonitor is a monitor the background refresh keeps fresh
+type RefreshMonitor struct {
+	Chain   string `json:"chain"`
+	Address string `json:"address"`
+}
+
+// MonitorRefresh is the background refresh schedule and what its last runs found
+type MonitorRefresh struct {
+	Enabled         bool             `json:"enabled"`
+	IntervalMinutes int              `json:"intervalMinutes,omitempty"`
+	Monitors        []RefreshMonitor `json:"monitors"`
+	Results         []refresh.Result `json:"results,omitempty"`
+}
+
 type AppPreferences struct {
 	Version         string            `json:"version"`
 	Name            string            `json:"name"`
@@ -59,10 +74,22 @@ type AppPreferences struct {
 	SilencedDialogs map[string]bool   `json:"silencedDialogs"`
 	ChunksMetrics   map[string]string `json:"chunksMetrics,omitempty"`
 	ExportsMetrics  map[string]string `json:"exportsMetrics,omitempty"`
-	SectionStates   map[string]bool   `json:"sectionStates"`
-	Bounds          Bounds            `json:"bounds,omitempty"`
-	FontScale       float64           `json:"fontScale"`
-	ShowFieldTypes  bool              `json:"showFieldTypes"`
+	// ComparitoorSources maps a comparitoor source (etherscan, covalent, alchemy) to a
+	// fixture path or URL, which may contain {chain} and {address}
+	ComparitoorSources map[string]string `json:"comparitoorSources,omitempty"`
+	// FiatCurrency is the currency balances and charts are valued in (USD if empty) and
+	// PriceFile is a CSV of prices used when a statement has no spot price
+	FiatCurrency string `json:"fiatCurrency,omitempty"`
+	PriceFile    string `json:"priceFile,omitempty"`
+	// ApiPort is the port the headless API server listens on (on 127.0.0.1). Zero, the
+	// default, leaves the server off.
+	ApiPort int `json:"apiPort,omitempty"`
+	// MonitorRefresh schedules monitors to be freshened in the background (off if nil)
+	MonitorRefresh *MonitorRefresh `json:"monitorRefresh,omitempty"`
+	SectionStates  map[string]bool `json:"sectionStates"`
+	Bounds         Bounds          `json:"bounds,omitempty"`
+	FontScale      float64         `json:"fontScale"`
+	ShowFieldTypes bool            `json:"showFieldTypes"`
 }
 
 func (p *AppPreferences) String() string {
@@ -73,7 +100,7 @@ func (p *AppPreferences) String() string {
 // NewAppPreferences creates a new AppPreferences instance with default values
 func NewAppPreferences() *AppPreferences {
 	return &AppPreferences{
-		Version:         "1.0",
+		Version:         AppPrefsVersion,
 		LastTheme:       "dark",
 		LastSkin:        "default",
 		LastFormat:      "csv",
@@ -108,7 +135,11 @@ func GetAppPreferences() (AppPreferences, error) {
 
 	var appPrefs AppPreferences
 	contents := file.AsciiFileToString(path)
-	if err := json.Unmarshal([]byte(contents), &appPrefs); err != nil {
+	data, needsSave, err := upgradePrefs(AppPrefsMigrations, path, []byte(contents))
+	if err != nil {
+		return AppPreferences{}, err
+	}
+	if err := json.Unmarshal(data, &appPrefs); err != nil {
 		// Log the corruption issue for debugging
 		logging.LogBEWarning(fmt.Sprintf("App preferences file corrupted (%v), creating new defaults", err))
 		logging.LogBEWarning(fmt.Sprintf("Corrupted content: %s", contents))
@@ -124,7 +155,6 @@ func GetAppPreferences() (AppPreferences, error) {
 		logging.LogBEWarning("App preferences reset to defaults and saved")
 	}
 
-	var needsSave bool
 	if appPrefs.RecentProjects == nil {
 		appPrefs.RecentProjects = []string{}
 		needsSave = true
@@ -138,7 +168,7 @@ func GetAppPreferences() (AppPreferences, error) {
 		needsSave = true
 	}
 	if appPrefs.Version == "" {
-		appPrefs.Version = "1.0"
+		appPrefs.Version = AppPrefsVersion
 		needsSave = true
 	}
 	if appPrefs.LastTheme == "" {
@@ -231,6 +231,7 @@ func getDefaultAppConfig() *AppConfig {
 		},
 		ViewConfig: map[string]ViewConfigEntry{
 			"exports":     {MenuOrder: 20},
+			"portfolio":   {MenuOrder: 25},
 			"monitors":    {MenuOrder: 30},
 			"abis":        {MenuOrder: 40},
 			"names":       {MenuOrder: 50},
@@ -0,0 +1,71 @@
+package preferences
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/file"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/logging"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/migrate"
+)
+
+// The current format versions of the preference files. Each moves only when a step is
+// added to its registry below.
+const (
+	AppPrefsVersion  = "1.0"
+	UserPrefsVersion = "1.0"
+	OrgPrefsVersion  = "1.0"
+)
+
+// Migrations upgrade the preference files to their current versions. None of the formats
+// has changed yet; add steps here when they do.
+var (
+	AppPrefsMigrations  = migrate.NewRegistry("app preferences file", AppPrefsVersion)
+	UserPrefsMigrations = migrate.NewRegistry("user preferences file", UserPrefsVersion)
+	OrgPrefsMigrations  = migrate.NewRegistry("org preferences file", OrgPrefsVersion)
+)
+
+// upgradePrefs migrates the contents of the preference file at path, backing the
+// original up if it changes. Files too new (or too old) to migrate are an error; a file
+// that cannot be parsed is returned as is so the caller's own recovery can deal with it.
+// The boolean says whether the caller needs to save the upgraded preferences.
+func upgradePrefs(registry *migrate.Registry, path string, data []byte) ([]byte, bool, error) {
+	upgraded, report, err := registry.Upgrade(path, data)
+	if err != nil {
+		var tooNew *migrate.TooNewError
+		if errors.As(err, &tooNew) || errors.Is(err, migrate.ErrUnknownVersion) {
+			return nil, false, fmt.Errorf("%s: %w", path, err)
+		}
+		return data, false, nil
+	}
+	if report.NeedsMigration() {
+		logging.LogBackend(report.String())
+	}
+	return upgraded, report.NeedsMigration(), nil
+}
+
+// PlanMigrations reports, without changing anything, what loading each of the preference
+// files would do to it. Missing files are skipped.
+func PlanMigrations() ([]*migrate.Report, error) {
+	files := []struct {
+		registry *migrate.Registry
+		path     string
+	}{
+		{OrgPrefsMigrations, getOrgPrefsPath()},
+		{UserPrefsMigrations, getUserPrefsPath()},
+		{AppPrefsMigrations, getAppPrefsPath()},
+	}
+
+	reports := make([]*migrate.Report, 0, len(files))
+	for _, f := range files {
+		if !file.FileExists(f.path) {
+			continue
+		}
+		report, err := f.registry.DryRunFile(f.path)
+		if err != nil {
+			return reports, err
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
@@ -0,0 +1,57 @@
+package preferences
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/migrate"
+)
+
+func TestNewerPreferencesAreRefused(t *testing.T) {
+	tmp := t.TempDir()
+	defer SetConfigBaseForTest(t, tmp)()
+
+	contents := `{"version":"99.0","lastTheme":"light"}`
+	_ = os.MkdirAll(filepath.Dir(getAppPrefsPath()), 0755)
+	if err := os.WriteFile(getAppPrefsPath(), []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := GetAppPreferences()
+	var tooNew *migrate.TooNewError
+	if !errors.As(err, &tooNew) {
+		t.Fatalf("Expected a TooNewError, got %v", err)
+	}
+
+	data, _ := os.ReadFile(getAppPrefsPath())
+	if string(data) != contents {
+		t.Errorf("Expected the newer file to be left alone, got %s", data)
+	}
+}
+
+func TestPlanMigrations(t *testing.T) {
+	tmp := t.TempDir()
+	defer SetConfigBaseForTest(t, tmp)()
+
+	if _, err := GetOrgPreferences(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := GetUserPreferences(); err != nil {
+		t.Fatal(err)
+	}
+
+	reports, err := PlanMigrations()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(reports) != 2 {
+		t.Fatalf("Expected reports for the two existing files, got %d", len(reports))
+	}
+	for _, report := range reports {
+		if report.NeedsMigration() {
+			t.Errorf("Expected freshly written preferences to be current: %s", report)
+		}
+	}
+}
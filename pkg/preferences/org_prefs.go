@@ -27,7 +27,7 @@ func (o *OrgPreferences) String() string {
 // NewOrgPreferences creates a new OrgPreferences instance with default values
 func NewOrgPreferences() *OrgPreferences {
 	return &OrgPreferences{
-		Version:       "1.0",
+		Version:       OrgPrefsVersion,
 		Telemetry:     false,
 		Theme:         "dark",
 		Language:      "en",
@@ -57,11 +57,22 @@ func GetOrgPreferences() (OrgPreferences, error) {
 		return OrgPreferences{}, err
 	}
 
+	data, upgraded, err := upgradePrefs(OrgPrefsMigrations, path, data)
+	if err != nil {
+		return OrgPreferences{}, err
+	}
+
 	var orgPrefs OrgPreferences
 	if err := json.Unmarshal(data, &orgPrefs); err != nil {
 		return OrgPreferences{}, err
 	}
 
+	if upgraded {
+		if err := SetOrgPreferences(&orgPrefs); err != nil {
+			return OrgPreferences{}, err
+		}
+	}
+
 	return orgPrefs, nil
 }
 
@@ -17,7 +17,7 @@ type UserPreferences struct {
 
 func NewUserPreferences() *UserPreferences {
 	return &UserPreferences{
-		Version: "1.0",
+		Version: UserPrefsVersion,
 		Chains:  []Chain{},
 	}
 }
@@ -45,6 +45,11 @@ func GetUserPreferences() (UserPreferences, error) {
 		return UserPreferences{}, err
 	}
 
+	data, upgraded, err := upgradePrefs(UserPrefsMigrations, path, data)
+	if err != nil {
+		return UserPreferences{}, err
+	}
+
 	var userPrefs UserPreferences
 	if err := json.Unmarshal(data, &userPrefs); err != nil {
 		return UserPreferences{}, err
@@ -54,6 +59,12 @@ func GetUserPreferences() (UserPreferences, error) {
 		userPrefs.Chains = []Chain{}
 	}
 
+	if upgraded {
+		if err := SetUserPreferences(&userPrefs); err != nil {
+			return UserPreferences{}, err
+		}
+	}
+
 	return userPrefs, nil
 }
 
@@ -0,0 +1,42 @@
+package project
+
+import (
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/migrate"
+)
+
+// CurrentVersion is the version of the project file format this build writes. It is the
+// application version in which the format last changed, so it only moves when a step is
+// added to Migrations.
+const CurrentVersion = "v6.5.1"
+
+// Migrations upgrades project files to CurrentVersion. Files without a version are 1.0.
+// Add a step here (and move CurrentVersion) whenever the format changes.
+var Migrations = migrate.NewRegistry("project file", CurrentVersion,
+	migrate.Step{
+		From:        "1.0",
+		To:          "v6.5.1",
+		Description: "filter states became view facet states",
+		Apply:       renameFilterStates,
+	},
+)
+
+// renameFilterStates moves filterStates to viewFacetStates unless the file already has
+// view facet states, in which case the old filter states are dropped
+func renameFilterStates(doc map[string]any) ([]string, error) {
+	old, ok := doc["filterStates"]
+	if !ok {
+		return nil, nil
+	}
+	delete(doc, "filterStates")
+	if current, ok := doc["viewFacetStates"]; ok && current != nil {
+		return []string{"filterStates removed (viewFacetStates already present)"}, nil
+	}
+	doc["viewFacetStates"] = old
+	return []string{"filterStates renamed to viewFacetStates"}, nil
+}
+
+// PlanMigration reports, without changing anything, what opening the project at path
+// would do to it
+func PlanMigration(path string) (*migrate.Report, error) {
+	return Migrations.DryRunFile(path)
+}
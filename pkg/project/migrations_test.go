@@ -0,0 +1,75 @@
+package project_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/migrate"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/project"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const oldProject = `{
+  "version": "1.0",
+  "name": "old",
+  "addresses": ["0xf503017d7baf7fbc0fff7492b751025c6a78179b"],
+  "activeAddress": "0xf503017d7baf7fbc0fff7492b751025c6a78179b",
+  "chains": ["mainnet"],
+  "activeChain": "mainnet",
+  "filterStates": {"exports:statements": {"filtering": {"search": "eth"}}}
+}`
+
+func TestLoadUpgradesOldProjects(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "old.tbx")
+	require.NoError(t, os.WriteFile(path, []byte(oldProject), 0644))
+
+	report, err := project.PlanMigration(path)
+	require.NoError(t, err)
+	assert.Equal(t, "1.0", report.From)
+	assert.Equal(t, project.CurrentVersion, report.To)
+	require.Len(t, report.Steps, 1)
+	assert.Contains(t, report.Steps[0].Changes, "filterStates renamed to viewFacetStates")
+	assert.NoFileExists(t, migrate.BackupPath(path, "1.0"), "a dry run changes nothing")
+
+	p, err := project.Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, project.CurrentVersion, p.Version)
+	state, ok := p.GetViewFacetState(project.ViewStateKey{ViewName: "exports", FacetName: "statements"})
+	require.True(t, ok)
+	assert.Equal(t, "eth", state.Filtering["search"])
+
+	backup, err := os.ReadFile(migrate.BackupPath(path, "1.0"))
+	require.NoError(t, err)
+	assert.Equal(t, oldProject, string(backup))
+
+	var saved map[string]any
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &saved))
+	assert.Equal(t, project.CurrentVersion, saved["version"])
+	assert.NotContains(t, saved, "filterStates")
+}
+
+func TestLoadRefusesNewerProjects(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "new.tbx")
+	contents := `{"version":"v99.0.0","name":"new"}`
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0644))
+
+	_, err := project.Load(path)
+	var tooNew *migrate.TooNewError
+	require.ErrorAs(t, err, &tooNew)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, contents, string(data), "a newer file is left alone")
+}
+
+func TestNewProjectsAreCurrent(t *testing.T) {
+	p := project.NewProject("new", base.ZeroAddr, []string{"mainnet"})
+	assert.Equal(t, project.CurrentVersion, p.Version)
+}
@@ -3,6 +3,7 @@ package project
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -14,6 +15,7 @@ import (
 	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
 	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/file"
 	"github.com/TrueBlocks/trueblocks-explorer/pkg/filewriter"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/migrate"
 	"github.com/TrueBlocks/trueblocks-explorer/pkg/msgs"
 	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
 )
@@ -46,7 +48,7 @@ func NewProject(name string, activeAddress base.Address, chains []string) *Proje
 		addresses = append(addresses, activeAddress)
 	}
 	return &Project{
-		Version:         "1.0",
+		Version:         CurrentVersion,
 		Name:            name,
 		LastOpened:      time.Now().Format(time.RFC3339),
 		LastView:        "",
@@ -68,32 +70,6 @@ func (p *Project) String() string {
 	return p.Name
 }
 
-// ------------------------------------------------------------------------------------
-// UnmarshalJSON provides custom unmarshaling with migration support
-func (p *Project) UnmarshalJSON(data []byte) error {
-	type Alias Project
-	aux := &struct {
-		OldFilterStates map[ViewStateKey]ViewFacetState `json:"filterStates"`
-		*Alias
-	}{
-		Alias: (*Alias)(p),
-	}
-
-	if err := json.Unmarshal(data, aux); err != nil {
-		return err
-	}
-
-	if aux.OldFilterStates != nil && p.ViewFacetStates == nil {
-		p.ViewFacetStates = aux.OldFilterStates
-	}
-
-	if p.Version == "" || p.Version == "1.0" {
-		p.Version = "v6.5.1" // TODO: Why is this hard coded?
-	}
-
-	return nil
-}
-
 // ------------------------------------------------------------------------------------
 var ErrProjectRecoveryIncomplete = fmt.Errorf("failed to parse project file")
 
@@ -118,6 +94,16 @@ func Load(path string) (*Project, error) {
 		return nil, fmt.Errorf("failed to read project file: %w", err)
 	}
 
+	// Bring older files up to the current format, keeping a copy of the original
+	data, report, err := Migrations.Upgrade(path, data)
+	if err != nil {
+		var tooNew *migrate.TooNewError
+		if errors.As(err, &tooNew) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %w", ErrProjectRecoveryIncomplete, err)
+	}
+
 	var project Project
 	if err := json.Unmarshal(data, &project); err != nil {
 		return nil, ErrProjectRecoveryIncomplete
@@ -131,6 +117,13 @@ func Load(path string) (*Project, error) {
 		return nil, fmt.Errorf("failed to validate project addresses: %w", err)
 	}
 
+	if report.NeedsMigration() {
+		if err := project.Save(); err != nil {
+			return nil, fmt.Errorf("failed to save upgraded project file: %w", err)
+		}
+		msgs.EmitStatus(fmt.Sprintf("Upgraded %s to format %s (original saved to %s)", filepath.Base(path), report.To, report.Backup))
+	}
+
 	return &project, nil
 }
 
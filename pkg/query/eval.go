@@ -0,0 +1,416 @@
+package query
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
+)
+
+// aliases are short names accepted in place of a field key when the facet has no
+// field by that name. The first candidate the facet knows about wins.
+var aliases = map[string][]string{
+	"block": {"blockNumber"},
+	"tx":    {"transactionIndex"},
+	"hash":  {"transactionHash"},
+	"from":  {"sender"},
+	"to":    {"recipient"},
+}
+
+// numericTypes are the FieldConfig types compared as numbers rather than text
+var numericTypes = map[string]bool{
+	"blknum":    true,
+	"txnum":     true,
+	"lognum":    true,
+	"uint64":    true,
+	"int64":     true,
+	"value":     true,
+	"gas":       true,
+	"wei":       true,
+	"int256":    true,
+	"float64":   true,
+	"ether":     true,
+	"timestamp": true,
+}
+
+// units converts a literal suffix to wei. Literals with a unit are compared in wei;
+// "ether" typed fields are scaled up to match.
+var units = map[string]*big.Float{
+	"wei":   big.NewFloat(1),
+	"gwei":  big.NewFloat(1e9),
+	"eth":   big.NewFloat(1e18),
+	"ether": big.NewFloat(1e18),
+}
+
+// row is the evaluation context for a single item
+type row struct {
+	value    reflect.Value
+	fields   map[string]types.FieldConfig
+	fallback func(text string) bool
+}
+
+// Matches evaluates the query against item, which must be a pointer to a struct. Field
+// keys are resolved through fields (the facet's FieldConfig list) and a key that is not
+// one of them never matches; if fields is empty any json field of the item may be used
+// and unknown keys are treated as text. Plain text terms are handed to fallback.
+func (q *Query) Matches(item any, fields []types.FieldConfig, fallback func(text string) bool) bool {
+	if q.root == nil {
+		return true
+	}
+	return q.root.eval(newRow(item, fields, fallback))
+}
+
+func newRow(item any, fields []types.FieldConfig, fallback func(text string) bool) *row {
+	r := &row{value: reflect.ValueOf(item), fallback: fallback}
+	if len(fields) > 0 {
+		r.fields = make(map[string]types.FieldConfig, len(fields))
+		for _, f := range fields {
+			if !f.NoFilter {
+				r.fields[strings.ToLower(f.Key)] = f
+			}
+		}
+	}
+	return r
+}
+
+func (n *andNode) eval(r *row) bool { return n.left.eval(r) && n.right.eval(r) }
+func (n *orNode) eval(r *row) bool  { return n.left.eval(r) || n.right.eval(r) }
+func (n *notNode) eval(r *row) bool { return !n.inner.eval(r) }
+
+func (n *textNode) eval(r *row) bool {
+	if r.fallback == nil {
+		return true
+	}
+	return r.fallback(n.text)
+}
+
+func (n *fieldNode) eval(r *row) bool {
+	key, fieldType, ok := r.resolve(n.key)
+	if !ok {
+		if r.fields != nil {
+			return false
+		}
+		return (&textNode{text: n.raw}).eval(r)
+	}
+
+	value, found := lookup(r.value, key)
+	if !found {
+		return false
+	}
+
+	if numericTypes[fieldType] {
+		return n.compareNumber(value, fieldType)
+	}
+
+	if fieldType == "address" && n.op != OpContains && !strings.HasPrefix(n.value, "0x") {
+		// asset=USDC or sender=vitalik: compare against the name shown next to the address
+		for _, related := range relatedNames(key) {
+			if text, ok := lookup(r.value, related); ok && n.compareText(Text(text)) {
+				return true
+			}
+		}
+		return n.op == OpNeq
+	}
+
+	return n.compareText(Text(value))
+}
+
+// resolve maps a key typed by the user to a field key and its FieldConfig type
+func (r *row) resolve(key string) (string, string, bool) {
+	if r.fields == nil {
+		if _, found := lookup(r.value, key); found {
+			return key, "", true
+		}
+		for _, alias := range aliases[strings.ToLower(key)] {
+			if _, found := lookup(r.value, alias); found {
+				return alias, "", true
+			}
+		}
+		return "", "", false
+	}
+
+	if f, ok := r.fields[strings.ToLower(key)]; ok {
+		return f.Key, f.Type, true
+	}
+	for _, alias := range aliases[strings.ToLower(key)] {
+		if f, ok := r.fields[strings.ToLower(alias)]; ok {
+			return f.Key, f.Type, true
+		}
+	}
+	return "", "", false
+}
+
+// relatedNames returns the fields holding a human readable name for an address field
+func relatedNames(key string) []string {
+	ret := []string{key + "Name"}
+	if key == "asset" {
+		ret = append(ret, "symbol")
+	}
+	return ret
+}
+
+func (n *fieldNode) compareText(text string) bool {
+	text = strings.ToLower(text)
+	value := strings.ToLower(n.value)
+
+	if n.isRange {
+		lo, hi := strings.ToLower(n.lo), strings.ToLower(n.hi)
+		return (lo == "" || text >= lo) && (hi == "" || text <= hi)
+	}
+
+	switch n.op {
+	case OpEq:
+		return text == value
+	case OpNeq:
+		return text != value
+	case OpGt:
+		return text > value
+	case OpGte:
+		return text >= value
+	case OpLt:
+		return text < value
+	case OpLte:
+		return text <= value
+	default:
+		return strings.Contains(text, value)
+	}
+}
+
+func (n *fieldNode) compareNumber(value reflect.Value, fieldType string) bool {
+	field, ok := Number(value)
+	if !ok {
+		return false
+	}
+
+	cmp := func(literal string) (int, bool) {
+		lit, inWei, ok := parseLiteral(literal, fieldType)
+		if !ok {
+			return 0, false
+		}
+		f := field
+		if inWei && fieldType == "ether" {
+			f = new(big.Float).Mul(field, units["eth"])
+		}
+		return f.Cmp(lit), true
+	}
+
+	if n.isRange {
+		if n.lo != "" {
+			if c, ok := cmp(n.lo); !ok || c < 0 {
+				return false
+			}
+		}
+		if n.hi != "" {
+			if c, ok := cmp(n.hi); !ok || c > 0 {
+				return false
+			}
+		}
+		return true
+	}
+
+	c, ok := cmp(n.value)
+	if !ok {
+		return false
+	}
+
+	switch n.op {
+	case OpNeq:
+		return c != 0
+	case OpGt:
+		return c > 0
+	case OpGte:
+		return c >= 0
+	case OpLt:
+		return c < 0
+	case OpLte:
+		return c <= 0
+	default:
+		return c == 0
+	}
+}
+
+// parseLiteral converts the right hand side of a numeric comparison to a number. It
+// reports whether the literal carried a unit (and was therefore converted to wei).
+func parseLiteral(literal, fieldType string) (*big.Float, bool, bool) {
+	literal = strings.ToLower(strings.ReplaceAll(literal, "_", ""))
+
+	if fieldType == "timestamp" {
+		if t, err := time.Parse("2006-01-02", literal); err == nil {
+			return new(big.Float).SetInt64(t.Unix()), false, true
+		}
+	}
+
+	for _, suffix := range []string{"gwei", "ether", "eth", "wei"} {
+		if number, found := strings.CutSuffix(literal, suffix); found && number != "" {
+			f, ok := new(big.Float).SetPrec(256).SetString(number)
+			if !ok {
+				return nil, false, false
+			}
+			return f.Mul(f, units[suffix]), true, true
+		}
+	}
+
+	f, ok := new(big.Float).SetPrec(256).SetString(literal)
+	return f, false, ok
+}
+
+// ----------------------------------------------------------------------------------
+// Reflection helpers
+
+type fieldCacheKey struct {
+	t    reflect.Type
+	name string
+}
+
+// fieldCache maps a struct type and json name to the field's index path
+var fieldCache sync.Map
+
+// Field returns the value at a dotted json path (for example calcs.endBalEth) of item,
+// which is normally a pointer to a struct. Missing fields and nil pointers report false.
+func Field(item any, path string) (reflect.Value, bool) {
+	return lookup(reflect.ValueOf(item), path)
+}
+
+// lookup finds the value at a dotted json path. Segments that land on a string-keyed
+// map select the entry with that (lowercased) key.
+func lookup(v reflect.Value, path string) (reflect.Value, bool) {
+	for _, segment := range strings.Split(path, ".") {
+		for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		if v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String {
+			entry := v.MapIndex(reflect.ValueOf(strings.ToLower(segment)).Convert(v.Type().Key()))
+			if !entry.IsValid() {
+				return reflect.Value{}, false
+			}
+			v = entry
+			continue
+		}
+		if v.Kind() != reflect.Struct {
+			return reflect.Value{}, false
+		}
+
+		index, ok := fieldIndex(v.Type(), segment)
+		if !ok {
+			return reflect.Value{}, false
+		}
+		field, err := v.FieldByIndexErr(index)
+		if err != nil {
+			return reflect.Value{}, false
+		}
+		v = field
+	}
+	return v, true
+}
+
+// HasField returns true if values of type t have a field at the dotted json path. Any
+// key is accepted below a string-keyed map, since only the value can tell if it is there.
+func HasField(t reflect.Type, path string) bool {
+	for _, segment := range strings.Split(path, ".") {
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		switch {
+		case t.Kind() == reflect.Map && t.Key().Kind() == reflect.String:
+			t = t.Elem()
+		case t.Kind() == reflect.Struct:
+			index, ok := fieldIndex(t, segment)
+			if !ok {
+				return false
+			}
+			t = t.FieldByIndex(index).Type
+		case t.Kind() == reflect.Interface:
+			return true
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// fieldIndex returns the index path of the field whose json name matches name
+func fieldIndex(t reflect.Type, name string) ([]int, bool) {
+	key := fieldCacheKey{t, strings.ToLower(name)}
+	if cached, ok := fieldCache.Load(key); ok {
+		index, _ := cached.([]int)
+		return index, index != nil
+	}
+
+	var index []int
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() || f.Anonymous {
+			continue
+		}
+		jsonName, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if jsonName == "" {
+			jsonName = f.Name
+		}
+		if strings.EqualFold(jsonName, name) {
+			index = f.Index
+			break
+		}
+	}
+	fieldCache.Store(key, index)
+	return index, index != nil
+}
+
+// Text renders a field the way it appears in the table
+func Text(v reflect.Value) string {
+	if !v.IsValid() {
+		return ""
+	}
+	if v.Kind() == reflect.String {
+		return v.String()
+	}
+
+	candidates := []reflect.Value{v}
+	if v.CanAddr() {
+		candidates = append(candidates, v.Addr())
+	}
+	for _, c := range candidates {
+		if m := c.MethodByName("Hex"); m.IsValid() && m.Type().NumIn() == 0 && m.Type().NumOut() == 1 {
+			if out := m.Call(nil); out[0].Kind() == reflect.String {
+				return out[0].String()
+			}
+		}
+	}
+	for _, c := range candidates {
+		if s, ok := c.Interface().(fmt.Stringer); ok {
+			if c.Kind() == reflect.Ptr && c.IsNil() {
+				return ""
+			}
+			return s.String()
+		}
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// Number converts a numeric, string, or stringer field to a big.Float
+func Number(v reflect.Value) (*big.Float, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return new(big.Float).SetInt64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(big.Float).SetUint64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return big.NewFloat(v.Float()), true
+	case reflect.Bool:
+		if v.Bool() {
+			return big.NewFloat(1), true
+		}
+		return big.NewFloat(0), true
+	}
+
+	text := Text(v)
+	if text == "" {
+		return new(big.Float), true
+	}
+	return new(big.Float).SetPrec(256).SetString(text)
+}
@@ -0,0 +1,62 @@
+package query
+
+import (
+	"sync"
+
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
+)
+
+// NewFilter returns a predicate for use with Facet.GetPage. Structured queries are
+// evaluated against the facet's fields; plain text, including a filter that names a key
+// the facet does not have, is passed unchanged to fallback (the collection's hand-written
+// substring matcher). Returns nil for an empty filter, and an error if the filter names a
+// field the facet does not let be filtered.
+func NewFilter[T any](filter string, fields []types.FieldConfig, fallback func(*T, string) bool) (func(*T) bool, error) {
+	q := Parse(filter)
+	if q.IsEmpty() {
+		return nil, nil
+	}
+
+	if err := q.Check(fields); err != nil {
+		return nil, err
+	}
+
+	if !q.IsStructured() || !q.NamesFields(fields) {
+		return func(item *T) bool {
+			return fallback(item, q.raw)
+		}, nil
+	}
+
+	return func(item *T) bool {
+		return q.Matches(item, fields, func(text string) bool {
+			return fallback(item, text)
+		})
+	}, nil
+}
+
+type facetFieldsKey struct {
+	collection any
+	facet      types.DataFacet
+}
+
+// facetFields caches each facet's FieldConfig list, which does not change once built
+var facetFields sync.Map
+
+// FacetFields returns the FieldConfig list for one facet of a collection, or nil if the
+// collection's configuration is unavailable. The collection must be a pointer.
+func FacetFields(c interface {
+	GetConfig() (*types.ViewConfig, error)
+}, facet types.DataFacet) []types.FieldConfig {
+	key := facetFieldsKey{c, facet}
+	if cached, ok := facetFields.Load(key); ok {
+		return cached.([]types.FieldConfig)
+	}
+
+	cfg, err := c.GetConfig()
+	if err != nil || cfg == nil {
+		return nil
+	}
+	fields := cfg.Facets[string(facet)].Fields
+	facetFields.Store(key, fields)
+	return fields
+}
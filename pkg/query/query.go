@@ -0,0 +1,351 @@
+// Package query implements the small filter language accepted by every facet's filter
+// box. A query is a list of terms joined by AND (the default), OR, NOT and parentheses:
+//
+//	value>1eth AND asset=USDC AND block:18000000..18100000
+//
+// A term is either a field comparison (key op value) or plain text. Field keys are the
+// FieldConfig keys of the facet being filtered. A query naming a key the facet does not
+// have, such as "Binance: Hot Wallet" or "12:00", is plain text, as is anything that
+// fails to parse; plain text falls back to the facet's substring matcher. Naming a field
+// the facet does not let be filtered is an error.
+package query
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
+)
+
+// Op is a comparison operator in a field term
+type Op string
+
+const (
+	OpEq       Op = "="
+	OpNeq      Op = "!="
+	OpGt       Op = ">"
+	OpGte      Op = ">="
+	OpLt       Op = "<"
+	OpLte      Op = "<="
+	OpContains Op = "~"
+	OpMatch    Op = ":" // equality for numbers, substring for text, or a lo..hi range
+)
+
+// Query is a parsed filter string
+type Query struct {
+	raw  string
+	root node
+}
+
+// Parse parses a filter string. If the string is not a well formed query the result
+// treats the whole string as plain text, so Parse never fails.
+func Parse(input string) *Query {
+	input = strings.TrimSpace(input)
+	q := &Query{raw: input}
+	if input == "" {
+		return q
+	}
+
+	if root, err := parse(input); err == nil {
+		q.root = root
+	} else {
+		q.root = &textNode{text: input}
+	}
+	return q
+}
+
+// String returns the filter the query was parsed from
+func (q *Query) String() string {
+	return q.raw
+}
+
+// IsEmpty returns true if the query matches every row
+func (q *Query) IsEmpty() bool {
+	return q.root == nil
+}
+
+// Check returns an error naming the first field term whose key is one of fields marked
+// NoFilter. Keys that are not fields at all are not checked; see NamesFields.
+func (q *Query) Check(fields []types.FieldConfig) error {
+	var err error
+	walk(q.root, func(n node) {
+		if field, ok := n.(*fieldNode); ok && err == nil {
+			for _, f := range fields {
+				if f.NoFilter && strings.EqualFold(f.Key, field.key) {
+					err = fmt.Errorf("field %q cannot be filtered", field.key)
+				}
+			}
+		}
+	})
+	return err
+}
+
+// NamesFields returns true if the key (or alias) of every field term is one of fields.
+// A query checked against no fields may use any key.
+func (q *Query) NamesFields(fields []types.FieldConfig) bool {
+	if len(fields) == 0 {
+		return true
+	}
+	r := newRow(nil, fields, nil)
+	known := true
+	walk(q.root, func(n node) {
+		if field, ok := n.(*fieldNode); ok && known {
+			_, _, known = r.resolve(field.key)
+		}
+	})
+	return known
+}
+
+// IsStructured returns true if the query contains at least one field term
+func (q *Query) IsStructured() bool {
+	structured := false
+	walk(q.root, func(n node) {
+		if _, ok := n.(*fieldNode); ok {
+			structured = true
+		}
+	})
+	return structured
+}
+
+// ----------------------------------------------------------------------------------
+// Syntax tree
+
+type node interface {
+	eval(row *row) bool
+}
+
+type andNode struct{ left, right node }
+type orNode struct{ left, right node }
+type notNode struct{ inner node }
+
+// textNode is plain text matched with the facet's substring matcher
+type textNode struct {
+	text string
+}
+
+// fieldNode compares the value of a field to a literal (or a lo..hi range)
+type fieldNode struct {
+	key     string
+	op      Op
+	value   string
+	lo, hi  string
+	isRange bool
+	raw     string // original text, used if key is not a field of the row
+}
+
+func walk(n node, visit func(node)) {
+	if n == nil {
+		return
+	}
+	visit(n)
+	switch v := n.(type) {
+	case *andNode:
+		walk(v.left, visit)
+		walk(v.right, visit)
+	case *orNode:
+		walk(v.left, visit)
+		walk(v.right, visit)
+	case *notNode:
+		walk(v.inner, visit)
+	}
+}
+
+// ----------------------------------------------------------------------------------
+// Lexer
+
+type tokenKind int
+
+const (
+	tokWord tokenKind = iota
+	tokQuoted
+	tokOp
+	tokLParen
+	tokRParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+func isOpChar(r byte) bool {
+	return r == '<' || r == '>' || r == '=' || r == '!' || r == '~' || r == ':'
+}
+
+func lex(input string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(input); {
+		c := input[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, token{tokLParen, "("})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{tokRParen, ")"})
+			i++
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(input[i+1:], c)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated quote at %d", i)
+			}
+			tokens = append(tokens, token{tokQuoted, input[i+1 : i+1+end]})
+			i += end + 2
+		case isOpChar(c):
+			op := string(c)
+			if i+1 < len(input) && input[i+1] == '=' && c != '=' && c != ':' && c != '~' {
+				op += "="
+			}
+			if op == "!" {
+				return nil, fmt.Errorf("unexpected '!' at %d", i)
+			}
+			tokens = append(tokens, token{tokOp, op})
+			i += len(op)
+		default:
+			start := i
+			for i < len(input) && !strings.ContainsRune(" \t\n\r()\"'", rune(input[i])) && !isOpChar(input[i]) {
+				i++
+			}
+			tokens = append(tokens, token{tokWord, input[start:i]})
+		}
+	}
+	return tokens, nil
+}
+
+// ----------------------------------------------------------------------------------
+// Parser
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func parse(input string) (node, error) {
+	tokens, err := lex(input)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q", p.tokens[p.pos].text)
+	}
+	return root, nil
+}
+
+func (p *parser) peek() *token {
+	if p.pos < len(p.tokens) {
+		return &p.tokens[p.pos]
+	}
+	return nil
+}
+
+func (p *parser) isKeyword(word string) bool {
+	t := p.peek()
+	return t != nil && t.kind == tokWord && strings.EqualFold(t.text, word)
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("or") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &orNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		if t == nil || t.kind == tokRParen || p.isKeyword("or") {
+			return left, nil
+		}
+		if p.isKeyword("and") {
+			p.pos++
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &andNode{left, right}
+	}
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.isKeyword("not") {
+		p.pos++
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{inner}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.peek()
+	if t == nil {
+		return nil, fmt.Errorf("unexpected end of query")
+	}
+
+	switch t.kind {
+	case tokLParen:
+		p.pos++
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.peek(); closing == nil || closing.kind != tokRParen {
+			return nil, fmt.Errorf("missing ')'")
+		}
+		p.pos++
+		return inner, nil
+
+	case tokQuoted:
+		p.pos++
+		return &textNode{text: t.text}, nil
+
+	case tokWord:
+		p.pos++
+		next := p.peek()
+		if next == nil || next.kind != tokOp {
+			return &textNode{text: t.text}, nil
+		}
+		p.pos++
+		value := p.peek()
+		if value == nil || (value.kind != tokWord && value.kind != tokQuoted) {
+			return nil, fmt.Errorf("missing value after %s%s", t.text, next.text)
+		}
+		p.pos++
+		return newFieldNode(t.text, Op(next.text), value.text), nil
+
+	default:
+		return nil, fmt.Errorf("unexpected %q", t.text)
+	}
+}
+
+func newFieldNode(key string, op Op, value string) *fieldNode {
+	n := &fieldNode{key: key, op: op, value: value, raw: key + string(op) + value}
+	if op == OpMatch {
+		if lo, hi, found := strings.Cut(value, ".."); found {
+			n.isRange, n.lo, n.hi = true, lo, hi
+		}
+	}
+	return n
+}
@@ -0,0 +1,219 @@
+package query
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCalcs struct {
+	EndBalEth string `json:"endBalEth"`
+}
+
+type testRow struct {
+	BlockNumber base.Blknum    `json:"blockNumber"`
+	Timestamp   base.Timestamp `json:"timestamp"`
+	Asset       base.Address   `json:"asset"`
+	AssetName   string         `json:"assetName"`
+	Symbol      string         `json:"symbol"`
+	Amount      base.Wei       `json:"amount"`
+	Calcs       *testCalcs     `json:"calcs,omitempty"`
+}
+
+var testFields = []types.FieldConfig{
+	{Key: "blockNumber", Type: "blknum"},
+	{Key: "timestamp", Type: "timestamp"},
+	{Key: "asset", Type: "address"},
+	{Key: "assetName", Type: "string"},
+	{Key: "symbol", Type: "string"},
+	{Key: "amount", Type: "wei"},
+	{Key: "calcs.endBalEth", Type: "ether"},
+}
+
+const usdc = "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"
+
+func newTestRow(block uint64, amountWei string, symbol string) *testRow {
+	return &testRow{
+		BlockNumber: base.Blknum(block),
+		Timestamp:   base.Timestamp(1700000000),
+		Asset:       base.HexToAddress(usdc),
+		AssetName:   "USD Coin",
+		Symbol:      symbol,
+		Amount:      *base.NewWeiStr(amountWei),
+		Calcs:       &testCalcs{EndBalEth: "2.5"},
+	}
+}
+
+func substring(row *testRow, text string) bool {
+	return strings.Contains(strings.ToLower(row.AssetName), strings.ToLower(text))
+}
+
+func matches(t *testing.T, filter string, row *testRow) bool {
+	t.Helper()
+	return Parse(filter).Matches(row, testFields, func(text string) bool {
+		return substring(row, text)
+	})
+}
+
+func TestParseFallsBackToText(t *testing.T) {
+	assert.True(t, Parse("").IsEmpty())
+	assert.False(t, Parse("usd coin").IsStructured())
+	assert.False(t, Parse(`value>"unterminated`).IsStructured())
+	assert.False(t, Parse("(block:1..2").IsStructured())
+	assert.True(t, Parse("block:1..2").IsStructured())
+}
+
+func TestFieldComparisons(t *testing.T) {
+	row := newTestRow(18050000, "1500000000000000000", "USDC")
+
+	tests := []struct {
+		filter string
+		want   bool
+	}{
+		{"amount>1eth", true},
+		{"amount>2eth", false},
+		{"amount>=1500000000gwei", true},
+		{"amount=1500000000000000000", true},
+		{"block:18000000..18100000", true},
+		{"block:18100000..", false},
+		{"block:..18050000", true},
+		{"blockNumber!=18050000", false},
+		{"asset=usdc", true},
+		{"asset=" + usdc, true},
+		{"asset=dai", false},
+		{"asset!=dai", true},
+		{"assetName~coin", true},
+		{"calcs.endBalEth>2", true},
+		{"calcs.endBalEth<2eth", false},
+		{"timestamp>2023-01-01", true},
+		{"timestamp<2023-01-01", false},
+		{"amount=1500000000000000001", false},
+		{"amount<1500000000000000001", true},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, matches(t, tt.filter, row), tt.filter)
+	}
+}
+
+func TestBooleanOperators(t *testing.T) {
+	row := newTestRow(100, "5", "USDC")
+
+	assert.True(t, matches(t, "amount>1 AND asset=USDC AND block:50..150", row))
+	assert.True(t, matches(t, "amount>1 asset=USDC", row), "terms are ANDed by default")
+	assert.False(t, matches(t, "amount>10 AND asset=USDC", row))
+	assert.True(t, matches(t, "amount>10 OR asset=USDC", row))
+	assert.True(t, matches(t, "NOT amount>10", row))
+	assert.False(t, matches(t, "NOT (amount>1 AND asset=USDC)", row))
+	assert.True(t, matches(t, "block:1..10 OR (symbol=usdc AND amount<10)", row))
+}
+
+func TestTextUsesFallback(t *testing.T) {
+	row := newTestRow(100, "5", "USDC")
+
+	assert.True(t, matches(t, "block>50 coin", row), "plain text terms use the fallback")
+	assert.False(t, matches(t, "block>50 dai", row))
+	assert.False(t, matches(t, "nosuchfield=5", row), "unknown keys never match")
+	assert.True(t, matches(t, "nosuchfield=5 OR coin", row))
+	assert.True(t, Parse("nosuchfield~usd").Matches(row, nil, func(text string) bool {
+		return strings.Contains(text, "usd")
+	}), "without fields, unknown keys are treated as text")
+}
+
+func TestUnknownKeysAreText(t *testing.T) {
+	assert.True(t, Parse("block>50 AND asset=usdc").NamesFields(testFields))
+	assert.True(t, Parse("value>1eth").NamesFields(nil), "a facet without fields accepts any key")
+	assert.False(t, Parse("amount>1 AND value>1eth").NamesFields(testFields))
+
+	row := &testRow{}
+	for _, filter := range []string{
+		"Binance: Hot Wallet",
+		"uniswap: router",
+		"https://etherscan.io/address/" + usdc,
+		"12:00",
+		"foo=bar",
+		"value>1eth",
+	} {
+		var got string
+		pred, err := NewFilter(filter, testFields, func(_ *testRow, text string) bool {
+			got = text
+			return true
+		})
+		require.NoError(t, err, filter)
+		require.NotNil(t, pred, filter)
+		assert.True(t, pred(row), filter)
+		assert.Equal(t, filter, got, "the whole filter is passed to the fallback")
+	}
+}
+
+func TestNoFilterFieldIsAnError(t *testing.T) {
+	fields := append([]types.FieldConfig{{Key: "fiatValue", Type: "float64", NoFilter: true}}, testFields...)
+	assert.ErrorContains(t, Parse("fiatValue>100").Check(fields), `field "fiatValue" cannot be filtered`)
+	assert.NoError(t, Parse("block>50").Check(fields))
+
+	_, err := NewFilter("fiatValue>100", fields, substring)
+	assert.ErrorContains(t, err, `field "fiatValue" cannot be filtered`)
+}
+
+func TestMissingNestedFieldDoesNotMatch(t *testing.T) {
+	row := newTestRow(100, "5", "USDC")
+	row.Calcs = nil
+	assert.False(t, matches(t, "calcs.endBalEth>0", row))
+	assert.True(t, matches(t, "NOT calcs.endBalEth>0", row))
+}
+
+func TestNewFilter(t *testing.T) {
+	row := newTestRow(100, "5", "USDC")
+
+	empty, err := NewFilter("", testFields, substring)
+	require.NoError(t, err)
+	assert.Nil(t, empty)
+
+	plain, err := NewFilter("usd coin", testFields, substring)
+	require.NoError(t, err)
+	assert.True(t, plain(row), "plain text is passed to the fallback whole")
+
+	structured, err := NewFilter("block:1..200 AND asset=usdc", testFields, substring)
+	require.NoError(t, err)
+	assert.True(t, structured(row))
+	assert.False(t, structured(newTestRow(300, "5", "USDC")))
+}
+
+func TestMapKeysInPaths(t *testing.T) {
+	type breakdownRow struct {
+		Breakdown map[string]string `json:"breakdown"`
+	}
+	row := &breakdownRow{Breakdown: map[string]string{usdc: "1.5"}}
+	fields := []types.FieldConfig{{Key: "breakdown." + usdc, Type: "ether"}}
+	noText := func(string) bool { return false }
+
+	value, ok := Field(row, "breakdown."+strings.ToUpper(usdc))
+	assert.True(t, ok, "map keys are matched case-insensitively")
+	assert.Equal(t, "1.5", Text(value))
+
+	_, ok = Field(row, "breakdown.0x1234")
+	assert.False(t, ok)
+
+	assert.True(t, Parse("breakdown."+usdc+">1").Matches(row, fields, noText))
+	assert.False(t, Parse("breakdown."+usdc+">2").Matches(row, fields, noText))
+}
+
+type countingConfig struct {
+	calls int
+}
+
+func (c *countingConfig) GetConfig() (*types.ViewConfig, error) {
+	c.calls++
+	return &types.ViewConfig{Facets: map[string]types.FacetConfig{"rows": {Fields: testFields}}}, nil
+}
+
+func TestFacetFieldsAreCached(t *testing.T) {
+	c := &countingConfig{}
+	assert.Equal(t, testFields, FacetFields(c, "rows"))
+	assert.Equal(t, testFields, FacetFields(c, "rows"))
+	assert.Equal(t, 1, c.calls)
+}
@@ -0,0 +1,310 @@
+// Package refresh freshens selected monitors in the background.
+//
+// A Scheduler holds the monitors to keep fresh, grouped by chain, and visits each chain
+// on a fixed interval. Freshening a chain asks for the current record count of each of
+// its monitors; a monitor whose count grew is reported through the OnChange callback so
+// the stores built from it can be marked stale. A chain whose freshen fails is retried
+// with exponential backoff, capped at MaxBackoff, until it succeeds again. Every run's
+// results are handed to the OnRun callback so they can be persisted and displayed.
+package refresh
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+)
+
+// MinInterval is the shortest interval a schedule may use
+const MinInterval = time.Minute
+
+// DefaultInterval is used when a schedule gives no interval
+const DefaultInterval = 15 * time.Minute
+
+// MaxBackoff caps how long a failing chain waits before it is retried
+const MaxBackoff = 4 * time.Hour
+
+// Target is a monitor to keep fresh
+type Target struct {
+	Chain   string
+	Address base.Address
+}
+
+// Result is what the last run found for one monitor
+type Result struct {
+	Chain      string    `json:"chain"`
+	Address    string    `json:"address"`
+	LastRun    time.Time `json:"lastRun"`
+	NextRun    time.Time `json:"nextRun"`
+	NRecords   int64     `json:"nRecords"`
+	NewRecords int64     `json:"newRecords"`
+	Failures   int       `json:"failures"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// Freshener brings the monitors of addrs on chain up to date and returns each one's
+// record count
+type Freshener func(chain string, addrs []base.Address) (map[base.Address]int64, error)
+
+// Scheduler periodically freshens its targets
+type Scheduler struct {
+	freshen  Freshener
+	OnChange func(chain string, addr base.Address, newRecords int64)
+	OnRun    func(results []Result)
+
+	mu       sync.Mutex
+	enabled  bool
+	interval time.Duration
+	targets  map[string][]base.Address
+	results  map[string]*Result
+	nextRun  map[string]time.Time
+	failures map[string]int
+	stop     chan struct{}
+	done     chan struct{}
+	now      func() time.Time
+}
+
+// NewScheduler returns a stopped scheduler that freshens with freshen
+func NewScheduler(freshen Freshener) *Scheduler {
+	return &Scheduler{
+		freshen:  freshen,
+		interval: DefaultInterval,
+		targets:  make(map[string][]base.Address),
+		results:  make(map[string]*Result),
+		nextRun:  make(map[string]time.Time),
+		failures: make(map[string]int),
+		now:      time.Now,
+	}
+}
+
+// Configure replaces the schedule. Previous results are kept for targets still in it, so
+// a monitor's count carries over and only growth after it is reported. Each chain's
+// first run is due at once.
+func (s *Scheduler) Configure(enabled bool, interval time.Duration, targets []Target, previous []Result) {
+	if interval == 0 {
+		interval = DefaultInterval
+	}
+	if interval < MinInterval {
+		interval = MinInterval
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.enabled = enabled
+	s.interval = interval
+
+	known := s.results
+	for i := range previous {
+		r := previous[i]
+		if _, ok := known[key(r.Chain, base.HexToAddress(r.Address))]; !ok {
+			known[key(r.Chain, base.HexToAddress(r.Address))] = &r
+		}
+	}
+
+	s.targets = make(map[string][]base.Address)
+	s.results = make(map[string]*Result)
+	seen := make(map[string]bool)
+	for _, t := range targets {
+		k := key(t.Chain, t.Address)
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		s.targets[t.Chain] = append(s.targets[t.Chain], t.Address)
+		if r, ok := known[k]; ok {
+			s.results[k] = r
+		} else {
+			s.results[k] = &Result{Chain: t.Chain, Address: t.Address.Hex()}
+		}
+	}
+
+	s.nextRun = make(map[string]time.Time)
+	for chain := range s.targets {
+		s.nextRun[chain] = s.now()
+		s.failures[chain] = 0
+	}
+}
+
+// Start runs the scheduler in the background, checking for due chains every tick
+func (s *Scheduler) Start(tick time.Duration) {
+	s.mu.Lock()
+	if s.stop != nil {
+		s.mu.Unlock()
+		return
+	}
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+	stop, done := s.stop, s.done
+	s.mu.Unlock()
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(tick)
+		defer ticker.Stop()
+		for {
+			s.RunDue()
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop halts the background loop and waits for a run in progress to finish
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	stop, done := s.stop, s.done
+	s.stop, s.done = nil, nil
+	s.mu.Unlock()
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+}
+
+// RunDue freshens every chain whose next run has come, if the schedule is enabled
+func (s *Scheduler) RunDue() {
+	s.mu.Lock()
+	if !s.enabled {
+		s.mu.Unlock()
+		return
+	}
+	now := s.now()
+	due := []string{}
+	for chain, next := range s.nextRun {
+		if !now.Before(next) {
+			due = append(due, chain)
+		}
+	}
+	s.mu.Unlock()
+
+	sort.Strings(due)
+	for _, chain := range due {
+		s.RunChain(chain)
+	}
+}
+
+// RunChain freshens the targets on chain now, whether or not they are due
+func (s *Scheduler) RunChain(chain string) {
+	s.mu.Lock()
+	addrs := append([]base.Address(nil), s.targets[chain]...)
+	s.mu.Unlock()
+	if len(addrs) == 0 {
+		return
+	}
+
+	counts, err := s.freshen(chain, addrs)
+
+	s.mu.Lock()
+	now := s.now()
+	type change struct {
+		addr  base.Address
+		added int64
+	}
+	changes := []change{}
+
+	wait := s.interval
+	if err != nil {
+		s.failures[chain]++
+		wait = backoff(s.interval, s.failures[chain])
+	} else {
+		s.failures[chain] = 0
+	}
+	s.nextRun[chain] = now.Add(wait)
+
+	for _, addr := range addrs {
+		r := s.results[key(chain, addr)]
+		if r == nil {
+			continue
+		}
+		counted := !r.LastRun.IsZero()
+		r.LastRun, r.NextRun, r.Failures = now, s.nextRun[chain], s.failures[chain]
+		if err != nil {
+			r.Error = err.Error()
+			continue
+		}
+		r.Error = ""
+		count, ok := counts[addr]
+		if !ok {
+			r.Error = "no count returned for this monitor"
+			continue
+		}
+		r.NewRecords = 0
+		if counted && count > r.NRecords {
+			r.NewRecords = count - r.NRecords
+			changes = append(changes, change{addr, r.NewRecords})
+		}
+		r.NRecords = count
+	}
+	results := s.resultsLocked()
+	onChange, onRun := s.OnChange, s.OnRun
+	s.mu.Unlock()
+
+	if onChange != nil {
+		for _, c := range changes {
+			onChange(chain, c.addr, c.added)
+		}
+	}
+	if onRun != nil {
+		onRun(results)
+	}
+}
+
+// Results returns what the last run found for each target, ordered by chain and address
+func (s *Scheduler) Results() []Result {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.resultsLocked()
+}
+
+// Result returns the last result for the monitor of addr on chain, if it is scheduled
+func (s *Scheduler) Result(chain string, addr base.Address) (Result, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.results[key(chain, addr)]
+	if !ok {
+		return Result{}, false
+	}
+	return *r, true
+}
+
+// Enabled reports whether the schedule runs
+func (s *Scheduler) Enabled() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.enabled
+}
+
+func (s *Scheduler) resultsLocked() []Result {
+	ret := make([]Result, 0, len(s.results))
+	for _, r := range s.results {
+		ret = append(ret, *r)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Chain != ret[j].Chain {
+			return ret[i].Chain < ret[j].Chain
+		}
+		return ret[i].Address < ret[j].Address
+	})
+	return ret
+}
+
+// backoff doubles the interval for each consecutive failure, up to MaxBackoff
+func backoff(interval time.Duration, failures int) time.Duration {
+	wait := interval
+	for i := 0; i < failures && wait < MaxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > MaxBackoff {
+		wait = MaxBackoff
+	}
+	return wait
+}
+
+func key(chain string, addr base.Address) string {
+	return fmt.Sprintf("%s_%s", chain, addr.Hex())
+}
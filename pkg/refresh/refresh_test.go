@@ -0,0 +1,121 @@
+package refresh
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	alice = base.HexToAddress("0xf503017d7baf7fbc0fff7492b751025c6a78179b")
+	bob   = base.HexToAddress("0x054993ab0f2b1acc0fdc65405ee203b4271bebe6")
+)
+
+type fakeChain struct {
+	counts map[base.Address]int64
+	err    error
+	calls  int
+}
+
+func (f *fakeChain) freshen(chain string, addrs []base.Address) (map[base.Address]int64, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	ret := map[base.Address]int64{}
+	for _, a := range addrs {
+		ret[a] = f.counts[a]
+	}
+	return ret, nil
+}
+
+func newTestScheduler(f *fakeChain) (*Scheduler, *time.Time) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := NewScheduler(f.freshen)
+	s.now = func() time.Time { return now }
+	return s, &now
+}
+
+func TestSchedulerReportsGrowthAndRunsOnInterval(t *testing.T) {
+	f := &fakeChain{counts: map[base.Address]int64{alice: 10, bob: 5}}
+	s, now := newTestScheduler(f)
+
+	type changed struct {
+		addr  base.Address
+		added int64
+	}
+	var changes []changed
+	var runs int
+	s.OnChange = func(chain string, addr base.Address, added int64) { changes = append(changes, changed{addr, added}) }
+	s.OnRun = func([]Result) { runs++ }
+
+	s.Configure(true, 10*time.Minute, []Target{{"mainnet", alice}, {"mainnet", bob}, {"mainnet", alice}}, nil)
+	s.RunDue()
+	assert.Equal(t, 1, f.calls, "both monitors on a chain are freshened together")
+	assert.Empty(t, changes, "the first count is only a baseline")
+
+	f.counts[alice] = 12
+	*now = now.Add(5 * time.Minute)
+	s.RunDue()
+	assert.Equal(t, 1, f.calls, "not due yet")
+
+	*now = now.Add(5 * time.Minute)
+	s.RunDue()
+	assert.Equal(t, 2, f.calls)
+	assert.Equal(t, []changed{{alice, 2}}, changes)
+	assert.Equal(t, 2, runs)
+
+	r, ok := s.Result("mainnet", alice)
+	require.True(t, ok)
+	assert.Equal(t, int64(12), r.NRecords)
+	assert.Equal(t, now.Add(10*time.Minute), r.NextRun)
+}
+
+func TestSchedulerBacksOffOnErrors(t *testing.T) {
+	f := &fakeChain{counts: map[base.Address]int64{alice: 1}, err: errors.New("rpc down")}
+	s, now := newTestScheduler(f)
+	s.Configure(true, 10*time.Minute, []Target{{"mainnet", alice}}, nil)
+
+	s.RunDue()
+	r, _ := s.Result("mainnet", alice)
+	assert.Equal(t, "rpc down", r.Error)
+	assert.Equal(t, 1, r.Failures)
+	assert.Equal(t, now.Add(20*time.Minute), r.NextRun)
+
+	*now = r.NextRun
+	s.RunDue()
+	r, _ = s.Result("mainnet", alice)
+	assert.Equal(t, now.Add(40*time.Minute), r.NextRun)
+
+	f.err = nil
+	*now = r.NextRun
+	s.RunDue()
+	r, _ = s.Result("mainnet", alice)
+	assert.Empty(t, r.Error)
+	assert.Zero(t, r.Failures)
+	assert.Equal(t, now.Add(10*time.Minute), r.NextRun)
+
+	assert.Equal(t, MaxBackoff, backoff(time.Hour, 10))
+}
+
+func TestSchedulerKeepsPreviousCountsAndHonoursDisabled(t *testing.T) {
+	f := &fakeChain{counts: map[base.Address]int64{alice: 7}}
+	s, _ := newTestScheduler(f)
+	var added int64
+	s.OnChange = func(_ string, _ base.Address, n int64) { added = n }
+
+	previous := []Result{{Chain: "mainnet", Address: alice.Hex(), NRecords: 4, LastRun: time.Unix(1, 0)}}
+	s.Configure(false, 0, []Target{{"mainnet", alice}}, previous)
+	s.RunDue()
+	assert.Zero(t, f.calls, "a disabled schedule does nothing")
+
+	s.Configure(true, time.Second, []Target{{"mainnet", alice}}, previous)
+	s.RunDue()
+	assert.Equal(t, int64(3), added, "growth since the persisted count is reported")
+	r, _ := s.Result("mainnet", alice)
+	assert.Equal(t, MinInterval, r.NextRun.Sub(r.LastRun))
+}
@@ -0,0 +1,571 @@
+// Package rpcpool keeps track of the health of each chain's RPC providers and picks the
+// one the application's own RPC calls should use.
+//
+// A Pool holds the providers of each chain. Checking a chain probes every provider for its
+// chain ID, head block and latency. A provider is healthy if it answers, reports the chain
+// ID the chain is configured with and is no more than MaxLag blocks behind the highest head
+// any provider reports. The healthy provider with the lowest latency is selected, but the
+// current one is kept while it stays healthy and no slower than twice the fastest, so the
+// selection does not flap. A provider reported as failing is dropped from the selection
+// until its next good check. Each change of selection is handed to the OnSelect callback
+// and each new check to OnCheck.
+//
+// Only the application's own RPC calls fail over. The SDK reads its provider from chifra's
+// chain config, which it reads without locking and which cannot be handed a provider per
+// call, so the pool never touches it and SDK queries always use the chain's first provider.
+// Instead the pool installed with SetDefault answers Provider, which callers use to pick
+// the URL of each call, and Call reports to it any provider that cannot be reached.
+package rpcpool
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+)
+
+// MaxLag is how many blocks a provider may trail the highest head and still be healthy
+const MaxLag = 20
+
+// HistoryLen is how many checks are kept for each chain
+const HistoryLen = 500
+
+// ProbeTimeout bounds each request a probe makes
+const ProbeTimeout = 6 * time.Second
+
+// Probe is what a provider answered
+type Probe struct {
+	ChainId   uint64
+	HeadBlock uint64
+	Latency   time.Duration
+	Err       error
+}
+
+// Prober asks the provider at url for its chain ID and head block
+type Prober func(url string) Probe
+
+// Check is the outcome of probing one provider. CheckedAt is a Unix timestamp.
+type Check struct {
+	Chain     string `json:"chain"`
+	URL       string `json:"url"`
+	CheckedAt int64  `json:"checkedAt"`
+	Healthy   bool   `json:"healthy"`
+	Selected  bool   `json:"selected"`
+	LatencyMs int64  `json:"latencyMs"`
+	ChainId   uint64 `json:"chainId"`
+	HeadBlock uint64 `json:"headBlock"`
+	Lag       uint64 `json:"lag"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Model lets a check be streamed and exported like the SDK's types
+func (c *Check) Model(chain, format string, verbose bool, extraOpts map[string]any) sdk.Model {
+	return sdk.Model{
+		Data: map[string]any{
+			"chain":     c.Chain,
+			"url":       c.URL,
+			"checkedAt": c.CheckedAt,
+			"healthy":   c.Healthy,
+			"selected":  c.Selected,
+			"latencyMs": c.LatencyMs,
+			"chainId":   c.ChainId,
+			"headBlock": c.HeadBlock,
+			"lag":       c.Lag,
+			"error":     c.Error,
+		},
+		Order: []string{"chain", "url", "checkedAt", "healthy", "selected", "latencyMs", "chainId", "headBlock", "lag", "error"},
+	}
+}
+
+type chainPool struct {
+	chainId   uint64
+	providers []string
+	latest    map[string]Check
+	failed    map[string]bool
+	selected  string
+	history   []Check
+}
+
+// Pool tracks the providers of every configured chain
+type Pool struct {
+	probe    Prober
+	OnSelect func(chain, url string)
+	OnCheck  func(chain string, checks []Check)
+
+	mu     sync.Mutex
+	chains map[string]*chainPool
+	stop   chan struct{}
+	done   chan struct{}
+	now    func() time.Time
+}
+
+// NewPool returns a stopped pool that checks providers with probe
+func NewPool(probe Prober) *Pool {
+	return &Pool{
+		probe:  probe,
+		chains: make(map[string]*chainPool),
+		now:    time.Now,
+	}
+}
+
+// Configure sets the providers of chain, in order of preference, and the chain ID they
+// must report. A chainId of zero is not checked. What is known about providers still in
+// the list is kept; if the selected one was removed, the best known replaces it, or the
+// first provider if none has been checked.
+func (p *Pool) Configure(chain string, chainId uint64, providers []string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	cp := p.chains[chain]
+	if cp == nil {
+		cp = &chainPool{latest: make(map[string]Check), failed: make(map[string]bool)}
+		p.chains[chain] = cp
+	}
+	cp.chainId = chainId
+	cp.providers = cp.providers[:0]
+	seen := make(map[string]bool)
+	for _, url := range providers {
+		if url = strings.TrimSpace(url); url != "" && !seen[url] {
+			seen[url] = true
+			cp.providers = append(cp.providers, url)
+		}
+	}
+	for url := range cp.latest {
+		if !seen[url] {
+			delete(cp.latest, url)
+			delete(cp.failed, url)
+		}
+	}
+	if !seen[cp.selected] {
+		cp.selected = ""
+		if !cp.reselect() && len(cp.providers) > 0 {
+			cp.selected = cp.providers[0]
+		}
+	}
+}
+
+// Chains returns the configured chains in order
+func (p *Pool) Chains() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	ret := make([]string, 0, len(p.chains))
+	for chain := range p.chains {
+		ret = append(ret, chain)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// Selected returns the provider calls on chain should use, or "" if it has none
+func (p *Pool) Selected(chain string) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if cp := p.chains[chain]; cp != nil {
+		return cp.selected
+	}
+	return ""
+}
+
+// History returns the checks of chain's providers, oldest first
+func (p *Pool) History(chain string) []Check {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if cp := p.chains[chain]; cp != nil {
+		return append([]Check(nil), cp.history...)
+	}
+	return []Check{}
+}
+
+// Check probes every provider of chain, updates the selection and returns the new checks
+func (p *Pool) Check(chain string) []Check {
+	p.mu.Lock()
+	cp := p.chains[chain]
+	if cp == nil {
+		p.mu.Unlock()
+		return []Check{}
+	}
+	providers := append([]string(nil), cp.providers...)
+	p.mu.Unlock()
+
+	probes := make([]Probe, len(providers))
+	var wg sync.WaitGroup
+	for i, url := range providers {
+		wg.Add(1)
+		go func(i int, url string) {
+			defer wg.Done()
+			probes[i] = p.probe(url)
+		}(i, url)
+	}
+	wg.Wait()
+
+	p.mu.Lock()
+	checkedAt := p.now().Unix()
+	checks := evaluate(chain, cp.chainId, providers, probes, checkedAt)
+	for _, c := range checks {
+		cp.latest[c.URL] = c
+		if c.Healthy {
+			delete(cp.failed, c.URL)
+		}
+	}
+	changed := cp.reselect()
+	for i := range checks {
+		checks[i].Selected = checks[i].URL == cp.selected
+	}
+	cp.record(checks...)
+	selected := cp.selected
+	onSelect, onCheck := p.OnSelect, p.OnCheck
+	p.mu.Unlock()
+
+	if changed && onSelect != nil {
+		onSelect(chain, selected)
+	}
+	if onCheck != nil {
+		onCheck(chain, checks)
+	}
+	return checks
+}
+
+// CheckAll checks every configured chain
+func (p *Pool) CheckAll() {
+	for _, chain := range p.Chains() {
+		p.Check(chain)
+	}
+}
+
+// ReportFailure tells the pool a call to url on chain failed. The provider is passed over
+// until a check finds it healthy again and, if it was selected, the pool fails over to the
+// best of the others.
+func (p *Pool) ReportFailure(chain, url string, err error) {
+	p.mu.Lock()
+	cp := p.chains[chain]
+	if cp == nil {
+		p.mu.Unlock()
+		return
+	}
+	cp.failed[url] = true
+	c := Check{Chain: chain, URL: url, CheckedAt: p.now().Unix(), Error: "reported failure"}
+	if err != nil {
+		c.Error = err.Error()
+	}
+	changed := cp.reselect()
+	cp.record(c)
+	selected := cp.selected
+	onSelect, onCheck := p.OnSelect, p.OnCheck
+	p.mu.Unlock()
+
+	if changed && onSelect != nil {
+		onSelect(chain, selected)
+	}
+	if onCheck != nil {
+		onCheck(chain, []Check{c})
+	}
+}
+
+// chainsUsing returns the chains that have url among their providers
+func (p *Pool) chainsUsing(url string) []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	ret := []string{}
+	for chain, cp := range p.chains {
+		for _, provider := range cp.providers {
+			if provider == url {
+				ret = append(ret, chain)
+				break
+			}
+		}
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+var (
+	defaultPool   *Pool
+	defaultPoolMu sync.RWMutex
+)
+
+// SetDefault installs the pool Provider answers from and Call reports failures to
+func SetDefault(p *Pool) {
+	defaultPoolMu.Lock()
+	defer defaultPoolMu.Unlock()
+	defaultPool = p
+}
+
+func getDefault() *Pool {
+	defaultPoolMu.RLock()
+	defer defaultPoolMu.RUnlock()
+	return defaultPool
+}
+
+// Provider returns the provider selected for chain by the default pool, or fallback if
+// there is no pool or it has no provider for chain
+func Provider(chain, fallback string) string {
+	if p := getDefault(); p != nil {
+		if url := p.Selected(chain); url != "" {
+			return url
+		}
+	}
+	return fallback
+}
+
+// reportFailure tells the default pool that url could not be reached. Errors the server
+// answered with, such as a reverted eth_call, are not failures of the provider.
+func reportFailure(url string, err error) {
+	var callErr *CallError
+	if errors.As(err, &callErr) {
+		return
+	}
+	p := getDefault()
+	if p == nil {
+		return
+	}
+	for _, chain := range p.chainsUsing(url) {
+		p.ReportFailure(chain, url, err)
+	}
+}
+
+// Start checks every chain now and then every interval until stopped
+func (p *Pool) Start(interval time.Duration) {
+	p.mu.Lock()
+	if p.stop != nil {
+		p.mu.Unlock()
+		return
+	}
+	p.stop = make(chan struct{})
+	p.done = make(chan struct{})
+	stop, done := p.stop, p.done
+	p.mu.Unlock()
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			p.CheckAll()
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop halts the background checks and waits for one in progress to finish
+func (p *Pool) Stop() {
+	p.mu.Lock()
+	stop, done := p.stop, p.done
+	p.stop, p.done = nil, nil
+	p.mu.Unlock()
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+}
+
+// evaluate turns the probes of a chain's providers into checks
+func evaluate(chain string, chainId uint64, providers []string, probes []Probe, checkedAt int64) []Check {
+	var head uint64
+	for _, probe := range probes {
+		if probe.Err == nil && (chainId == 0 || probe.ChainId == chainId) {
+			head = max(head, probe.HeadBlock)
+		}
+	}
+
+	checks := make([]Check, len(providers))
+	for i, probe := range probes {
+		c := Check{
+			Chain:     chain,
+			URL:       providers[i],
+			CheckedAt: checkedAt,
+			LatencyMs: probe.Latency.Milliseconds(),
+			ChainId:   probe.ChainId,
+			HeadBlock: probe.HeadBlock,
+		}
+		if head > probe.HeadBlock {
+			c.Lag = head - probe.HeadBlock
+		}
+		switch {
+		case probe.Err != nil:
+			c.Error = probe.Err.Error()
+		case chainId != 0 && probe.ChainId != chainId:
+			c.Error = fmt.Sprintf("reports chain id %d, expected %d", probe.ChainId, chainId)
+		case c.Lag > MaxLag:
+			c.Error = fmt.Sprintf("%d blocks behind", c.Lag)
+		default:
+			c.Healthy = true
+		}
+		checks[i] = c
+	}
+	return checks
+}
+
+// reselect picks the provider to use from the latest checks and reports whether it changed.
+// With no healthy provider the selection is left alone.
+func (cp *chainPool) reselect() bool {
+	var best *Check
+	for _, url := range cp.providers {
+		c, ok := cp.latest[url]
+		if !ok || !c.Healthy || cp.failed[url] {
+			continue
+		}
+		if best == nil || c.LatencyMs < best.LatencyMs {
+			best = &c
+		}
+	}
+	if best == nil {
+		return false
+	}
+
+	if cur, ok := cp.latest[cp.selected]; ok && cur.Healthy && !cp.failed[cp.selected] {
+		if cur.LatencyMs <= 2*best.LatencyMs {
+			return false
+		}
+	}
+	changed := cp.selected != best.URL
+	cp.selected = best.URL
+	return changed
+}
+
+func (cp *chainPool) record(checks ...Check) {
+	cp.history = append(cp.history, checks...)
+	if over := len(cp.history) - HistoryLen; over > 0 {
+		cp.history = append([]Check(nil), cp.history[over:]...)
+	}
+}
+
+type rpcRequest struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      int    `json:"id"`
+	Method  string `json:"method"`
+	Params  []any  `json:"params"`
+}
+
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *CallError      `json:"error"`
+}
+
+// CallError is an error returned by the JSON-RPC server rather than the transport. Data is
+// whatever the server sent with it, such as the return data of a reverted eth_call.
+type CallError struct {
+	Method  string          `json:"-"`
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+func (e *CallError) Error() string {
+	return e.Method + ": " + e.Message
+}
+
+// JsonRpcProbe probes url with eth_chainId and eth_blockNumber. The latency is that of the
+// first request. Its failures are recorded by the check that made it, not reported.
+func JsonRpcProbe(url string) Probe {
+	var probe Probe
+	start := time.Now()
+	raw, err := call(url, "eth_chainId")
+	chainId, err := decodeString("eth_chainId", raw, err)
+	probe.Latency = time.Since(start)
+	if err != nil {
+		probe.Err = err
+		return probe
+	}
+	if probe.ChainId, err = strconv.ParseUint(strings.TrimPrefix(chainId, "0x"), 16, 64); err != nil {
+		probe.Err = fmt.Errorf("bad chain id %q", chainId)
+		return probe
+	}
+
+	raw, err = call(url, "eth_blockNumber")
+	head, err := decodeString("eth_blockNumber", raw, err)
+	if err != nil {
+		probe.Err = err
+		return probe
+	}
+	if probe.HeadBlock, err = strconv.ParseUint(strings.TrimPrefix(head, "0x"), 16, 64); err != nil {
+		probe.Err = fmt.Errorf("bad block number %q", head)
+	}
+	return probe
+}
+
+// Call sends one JSON-RPC request to url and returns its raw result. It gives up after
+// ProbeTimeout. If url cannot be reached, the default pool is told so it can fail over.
+func Call(url, method string, params ...any) (json.RawMessage, error) {
+	ret, err := call(url, method, params...)
+	if err != nil {
+		reportFailure(url, err)
+	}
+	return ret, err
+}
+
+func call(url, method string, params ...any) (json.RawMessage, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), ProbeTimeout)
+	defer cancel()
+
+	if params == nil {
+		params = []any{}
+	}
+	body, _ := json.Marshal(rpcRequest{JSONRPC: "2.0", ID: 1, Method: method, Params: params})
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: http status %d", method, resp.StatusCode)
+	}
+
+	var res rpcResponse
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, fmt.Errorf("%s: %w", method, err)
+	}
+	if res.Error != nil {
+		res.Error.Method = method
+		return nil, res.Error
+	}
+	return res.Result, nil
+}
+
+// CallString is Call for a method whose result is a string
+func CallString(url, method string, params ...any) (string, error) {
+	raw, err := Call(url, method, params...)
+	return decodeString(method, raw, err)
+}
+
+// ProbeCall is Call without reporting failures to the default pool. Capability probes use
+// it, since a provider that does not support a method is not failing.
+func ProbeCall(url, method string, params ...any) (json.RawMessage, error) {
+	return call(url, method, params...)
+}
+
+// ProbeCallString is ProbeCall for a method whose result is a string
+func ProbeCallString(url, method string, params ...any) (string, error) {
+	raw, err := call(url, method, params...)
+	return decodeString(method, raw, err)
+}
+
+// decodeString decodes the result of a call to method whose result is a string
+func decodeString(method string, raw json.RawMessage, err error) (string, error) {
+	if err != nil {
+		return "", err
+	}
+	var ret string
+	if err := json.Unmarshal(raw, &ret); err != nil {
+		return "", fmt.Errorf("%s: %w", method, err)
+	}
+	return ret, nil
+}
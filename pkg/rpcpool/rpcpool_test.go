@@ -0,0 +1,196 @@
+package rpcpool
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeProviders struct {
+	mu     sync.Mutex
+	probes map[string]Probe
+}
+
+func (f *fakeProviders) set(url string, probe Probe) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.probes[url] = probe
+}
+
+func (f *fakeProviders) probe(url string) Probe {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.probes[url]
+}
+
+func newTestPool() (*Pool, *fakeProviders, *[]string) {
+	fake := &fakeProviders{probes: map[string]Probe{
+		"a": {ChainId: 1, HeadBlock: 1000, Latency: 80 * time.Millisecond},
+		"b": {ChainId: 1, HeadBlock: 1000, Latency: 30 * time.Millisecond},
+		"c": {ChainId: 1, HeadBlock: 1000, Latency: 50 * time.Millisecond},
+	}}
+	selections := []string{}
+	p := NewPool(fake.probe)
+	p.now = func() time.Time { return time.Unix(1700000000, 0) }
+	p.OnSelect = func(chain, url string) { selections = append(selections, chain+":"+url) }
+	p.Configure("mainnet", 1, []string{"a", "b", "c"})
+	return p, fake, &selections
+}
+
+func TestCheckSelectsFastestHealthy(t *testing.T) {
+	p, fake, selections := newTestPool()
+	assert.Equal(t, "a", p.Selected("mainnet"), "the first provider is used until checked")
+
+	checks := p.Check("mainnet")
+	require.Len(t, checks, 3)
+	assert.Equal(t, "b", p.Selected("mainnet"))
+	assert.Equal(t, []string{"mainnet:b"}, *selections)
+	assert.True(t, checks[1].Selected)
+	assert.Equal(t, int64(1700000000), checks[0].CheckedAt)
+
+	// A provider on the wrong chain or too far behind is not healthy
+	fake.set("b", Probe{ChainId: 5, HeadBlock: 1000, Latency: 10 * time.Millisecond})
+	fake.set("c", Probe{ChainId: 1, HeadBlock: 1000 - MaxLag - 1, Latency: 10 * time.Millisecond})
+	checks = p.Check("mainnet")
+	assert.False(t, checks[1].Healthy)
+	assert.Contains(t, checks[1].Error, "chain id 5")
+	assert.False(t, checks[2].Healthy)
+	assert.Equal(t, uint64(MaxLag+1), checks[2].Lag)
+	assert.Equal(t, "a", p.Selected("mainnet"))
+	assert.Equal(t, []string{"mainnet:b", "mainnet:a"}, *selections)
+}
+
+func TestSelectionDoesNotFlap(t *testing.T) {
+	p, fake, selections := newTestPool()
+	p.Check("mainnet")
+	require.Equal(t, "b", p.Selected("mainnet"))
+
+	// Somewhat faster is not enough to switch; more than twice as fast is
+	fake.set("c", Probe{ChainId: 1, HeadBlock: 1000, Latency: 20 * time.Millisecond})
+	p.Check("mainnet")
+	assert.Equal(t, "b", p.Selected("mainnet"))
+
+	fake.set("c", Probe{ChainId: 1, HeadBlock: 1000, Latency: 10 * time.Millisecond})
+	p.Check("mainnet")
+	assert.Equal(t, "c", p.Selected("mainnet"))
+	assert.Equal(t, []string{"mainnet:b", "mainnet:c"}, *selections)
+}
+
+func TestFailover(t *testing.T) {
+	p, fake, selections := newTestPool()
+	reported := []Check{}
+	p.OnCheck = func(chain string, checks []Check) { reported = append(reported, checks...) }
+	p.Check("mainnet")
+	require.Equal(t, "b", p.Selected("mainnet"))
+
+	p.ReportFailure("mainnet", "b", errors.New("connection refused"))
+	assert.Equal(t, "c", p.Selected("mainnet"))
+	assert.Equal(t, []string{"mainnet:b", "mainnet:c"}, *selections)
+
+	// A provider that stops answering is failed over on the next check
+	fake.set("c", Probe{Err: errors.New("timeout")})
+	p.Check("mainnet")
+	assert.Equal(t, "b", p.Selected("mainnet"), "a good check clears a reported failure")
+
+	// With nothing healthy, the selection stays where it is
+	for _, url := range []string{"a", "b", "c"} {
+		fake.set(url, Probe{Err: errors.New("down")})
+	}
+	p.Check("mainnet")
+	assert.Equal(t, "b", p.Selected("mainnet"))
+
+	history := p.History("mainnet")
+	assert.Len(t, history, 10)
+	assert.Equal(t, history, reported, "every check is handed to OnCheck")
+	assert.Equal(t, "connection refused", history[3].Error)
+}
+
+func TestConfigureAndHistoryLimit(t *testing.T) {
+	p, _, _ := newTestPool()
+	for i := 0; i < HistoryLen; i++ {
+		p.Check("mainnet")
+	}
+	assert.Len(t, p.History("mainnet"), HistoryLen)
+
+	p.Configure("mainnet", 1, []string{"d", " c ", "c"})
+	assert.Equal(t, "c", p.Selected("mainnet"), "a removed selection falls back to the best known provider")
+
+	p.Configure("mainnet", 1, []string{"e", "f"})
+	assert.Equal(t, "e", p.Selected("mainnet"), "or to the first if none is known")
+	assert.Equal(t, []string{"mainnet"}, p.Chains())
+	assert.Empty(t, p.History("sepolia"))
+	assert.Empty(t, p.Check("sepolia"))
+}
+
+func TestJsonRpcProbe(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req rpcRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		switch req.Method {
+		case "eth_chainId":
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x1"}`))
+		case "eth_blockNumber":
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x10d4f"}`))
+		}
+	}))
+	defer srv.Close()
+
+	probe := JsonRpcProbe(srv.URL)
+	require.NoError(t, probe.Err)
+	assert.Equal(t, uint64(1), probe.ChainId)
+	assert.Equal(t, uint64(0x10d4f), probe.HeadBlock)
+
+	bad := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"method not found"}}`))
+	}))
+	defer bad.Close()
+	assert.ErrorContains(t, JsonRpcProbe(bad.URL).Err, "method not found")
+
+	_, err := Call(bad.URL, "eth_call")
+	var callErr *CallError
+	require.ErrorAs(t, err, &callErr)
+	assert.Equal(t, -32601, callErr.Code)
+	assert.Equal(t, "eth_call: method not found", err.Error())
+}
+
+func TestCallReportsUnreachableProviders(t *testing.T) {
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer down.Close()
+	reverts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":3,"message":"execution reverted"}}`))
+	}))
+	defer reverts.Close()
+
+	fake := &fakeProviders{probes: map[string]Probe{
+		down.URL:    {ChainId: 1, HeadBlock: 1000, Latency: 10 * time.Millisecond},
+		reverts.URL: {ChainId: 1, HeadBlock: 1000, Latency: 20 * time.Millisecond},
+	}}
+	p := NewPool(fake.probe)
+	p.Configure("mainnet", 1, []string{down.URL, reverts.URL})
+	p.Check("mainnet")
+	SetDefault(p)
+	t.Cleanup(func() { SetDefault(nil) })
+	require.Equal(t, down.URL, Provider("mainnet", "fallback"))
+	assert.Equal(t, "fallback", Provider("sepolia", "fallback"))
+
+	_, err := Call(reverts.URL, "eth_call")
+	require.Error(t, err)
+	assert.Equal(t, down.URL, Provider("mainnet", ""), "an error the server answered with is not a failure")
+
+	_, err = ProbeCall(down.URL, "trace_block", "0x1")
+	require.Error(t, err)
+	assert.Equal(t, down.URL, Provider("mainnet", ""), "a probe does not report what it finds")
+
+	_, err = Call(down.URL, "eth_call")
+	require.Error(t, err)
+	assert.Equal(t, reverts.URL, Provider("mainnet", ""), "an unreachable provider is failed over")
+}
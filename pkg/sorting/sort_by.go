@@ -0,0 +1,103 @@
+package sorting
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/query"
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+)
+
+// sortKey is the pre-computed value of one sort field for one item
+type sortKey struct {
+	present bool
+	number  *big.Float
+	text    string
+}
+
+// SortBy sorts items in place by every field in spec, in priority order. Fields are
+// json keys of T (dotted paths such as calcs.endBalEth reach into nested structs).
+// A field is compared numerically if every item's value is a number and as
+// case-insensitive text otherwise. Items missing a field sort before those that have
+// it. The sort is stable, so ties keep their original (arrival) order. A field that T
+// does not have is an error, and the items are left as they were.
+func SortBy[T any](items []T, spec sdk.SortSpec) error {
+	spec = Normalize(spec)
+	for _, field := range spec.Fields {
+		if !query.HasField(reflect.TypeOf((*T)(nil)).Elem(), field) {
+			return fmt.Errorf("unknown sort field %q", field)
+		}
+	}
+	if len(spec.Fields) == 0 || len(items) < 2 {
+		return nil
+	}
+
+	keys := make([][]sortKey, len(items))
+	for i := range items {
+		keys[i] = make([]sortKey, len(spec.Fields))
+	}
+
+	numeric := make([]bool, len(spec.Fields))
+	for f, field := range spec.Fields {
+		numeric[f] = true
+		for i := range items {
+			value, found := query.Field(&items[i], field)
+			if !found {
+				continue
+			}
+			key := sortKey{present: true, text: strings.ToLower(query.Text(value))}
+			if numeric[f] && !strings.HasPrefix(key.text, "0x") {
+				key.number, _ = query.Number(value)
+			}
+			if key.number == nil {
+				numeric[f] = false
+			}
+			keys[i][f] = key
+		}
+	}
+
+	order := make([]int, len(items))
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(a, b int) bool {
+		ka, kb := keys[order[a]], keys[order[b]]
+		for f := range spec.Fields {
+			c := compareKeys(ka[f], kb[f], numeric[f])
+			if c == 0 {
+				continue
+			}
+			if spec.Order[f] == sdk.Dec {
+				return c > 0
+			}
+			return c < 0
+		}
+		return false
+	})
+
+	sorted := make([]T, len(items))
+	for i, idx := range order {
+		sorted[i] = items[idx]
+	}
+	copy(items, sorted)
+	return nil
+}
+
+func compareKeys(a, b sortKey, numeric bool) int {
+	switch {
+	case !a.present && !b.present:
+		return 0
+	case !a.present:
+		return -1
+	case !b.present:
+		return 1
+	case numeric:
+		return a.number.Cmp(b.number)
+	default:
+		return strings.Compare(a.text, b.text)
+	}
+}
@@ -23,6 +23,26 @@ func NewSortSpec(field, direction string) sdk.SortSpec {
 	}
 }
 
+// Normalize returns a copy of spec with empty and repeated fields removed and with
+// exactly one order per field (missing orders default to ascending)
+func Normalize(spec sdk.SortSpec) sdk.SortSpec {
+	ret := EmptySortSpec()
+	seen := make(map[string]bool, len(spec.Fields))
+	for i, field := range spec.Fields {
+		if field == "" || seen[field] {
+			continue
+		}
+		seen[field] = true
+		order := sdk.Asc
+		if i < len(spec.Order) {
+			order = spec.Order[i]
+		}
+		ret.Fields = append(ret.Fields, field)
+		ret.Order = append(ret.Order, order)
+	}
+	return ret
+}
+
 // GetSortField extracts the first field from a SortSpec (for backward compatibility)
 func GetSortField(spec sdk.SortSpec) string {
 	if len(spec.Fields) > 0 {
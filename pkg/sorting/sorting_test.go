@@ -0,0 +1,84 @@
+package sorting
+
+import (
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sortRow struct {
+	ID          int         `json:"id"`
+	Asset       string      `json:"asset"`
+	BlockNumber base.Blknum `json:"blockNumber"`
+	Amount      base.Wei    `json:"amount"`
+	Calcs       *sortCalcs  `json:"calcs,omitempty"`
+}
+
+type sortCalcs struct {
+	EndBalEth string `json:"endBalEth"`
+}
+
+func ids(rows []sortRow) []int {
+	ret := make([]int, len(rows))
+	for i, r := range rows {
+		ret[i] = r.ID
+	}
+	return ret
+}
+
+func TestNormalize(t *testing.T) {
+	spec := Normalize(sdk.SortSpec{
+		Fields: []string{"asset", "", "blockNumber", "asset", "amount"},
+		Order:  []sdk.SortOrder{sdk.Dec, sdk.Asc, sdk.Dec},
+	})
+	assert.Equal(t, []string{"asset", "blockNumber", "amount"}, spec.Fields)
+	assert.Equal(t, []sdk.SortOrder{sdk.Dec, sdk.Dec, sdk.Asc}, spec.Order)
+}
+
+func TestSortByMultipleFields(t *testing.T) {
+	rows := []sortRow{
+		{ID: 1, Asset: "USDC", BlockNumber: 5},
+		{ID: 2, Asset: "dai", BlockNumber: 7},
+		{ID: 3, Asset: "usdc", BlockNumber: 9},
+		{ID: 4, Asset: "DAI", BlockNumber: 3},
+		{ID: 5, Asset: "usdc", BlockNumber: 9},
+	}
+
+	require.NoError(t, SortBy(rows, sdk.SortSpec{Fields: []string{"asset", "blockNumber"}, Order: []sdk.SortOrder{sdk.Asc, sdk.Dec}}))
+	assert.Equal(t, []int{2, 4, 3, 5, 1}, ids(rows), "ties (3 and 5) keep their original order")
+}
+
+func TestSortByNumericValues(t *testing.T) {
+	rows := []sortRow{
+		{ID: 1, Amount: *base.NewWeiStr("1000000000000000000000")},
+		{ID: 2, Amount: *base.NewWeiStr("20")},
+		{ID: 3, Amount: *base.NewWeiStr("3")},
+	}
+
+	require.NoError(t, SortBy(rows, NewSortSpec("amount", "asc")))
+	assert.Equal(t, []int{3, 2, 1}, ids(rows), "amounts compare as numbers, not text")
+}
+
+func TestSortByNestedAndMissingFields(t *testing.T) {
+	rows := []sortRow{
+		{ID: 1, Calcs: &sortCalcs{EndBalEth: "2.5"}},
+		{ID: 2},
+		{ID: 3, Calcs: &sortCalcs{EndBalEth: "10"}},
+	}
+
+	require.NoError(t, SortBy(rows, NewSortSpec("calcs.endBalEth", "desc")))
+	assert.Equal(t, []int{3, 1, 2}, ids(rows))
+
+	assert.ErrorContains(t, SortBy(rows, NewSortSpec("noSuchField", "asc")), `unknown sort field "noSuchField"`)
+	assert.ErrorContains(t, SortBy(rows, NewSortSpec("calcs.noSuchField", "asc")), "unknown sort field")
+	assert.Equal(t, []int{3, 1, 2}, ids(rows), "a rejected sort leaves the order alone")
+}
+
+func TestSortByEmptySpec(t *testing.T) {
+	rows := []sortRow{{ID: 2}, {ID: 1}}
+	require.NoError(t, SortBy(rows, EmptySortSpec()))
+	assert.Equal(t, []int{2, 1}, ids(rows))
+}
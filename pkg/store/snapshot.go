@@ -0,0 +1,283 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/filewriter"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/logging"
+)
+
+// SnapshotVersion is bumped whenever the on-disk layout of a snapshot changes. Files
+// written with any other version are ignored (and eventually overwritten).
+const SnapshotVersion = 2
+
+var (
+	snapshotDir   string
+	snapshotDirMu sync.RWMutex
+)
+
+// SetSnapshotDir enables on-disk snapshots by pointing the store package at a folder.
+// An empty path disables snapshots entirely.
+func SetSnapshotDir(dir string) {
+	snapshotDirMu.Lock()
+	defer snapshotDirMu.Unlock()
+	snapshotDir = dir
+}
+
+// GetSnapshotDir returns the folder snapshots are read from and written to
+func GetSnapshotDir() string {
+	snapshotDirMu.RLock()
+	defer snapshotDirMu.RUnlock()
+	return snapshotDir
+}
+
+// snapshotFile is the versioned on-disk representation of a store. Each row of Data is
+// written by encodeItem so that fields hidden from JSON survive the round trip.
+type snapshotFile struct {
+	Version       int               `json:"version"`
+	ContextKey    string            `json:"contextKey"`
+	LastBlock     uint64            `json:"lastBlock"`
+	ExpectedTotal int64             `json:"expectedTotal"`
+	Data          []json.RawMessage `json:"data"`
+	MapIndex      map[string]int    `json:"mapIndex,omitempty"`
+}
+
+// snapshotPath returns the file a store with the given context key is persisted to
+func snapshotPath(contextKey string) string {
+	dir := GetSnapshotDir()
+	if dir == "" || contextKey == "" {
+		return ""
+	}
+	safe := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		default:
+			return '_'
+		}
+	}, contextKey)
+	return filepath.Join(dir, safe+".json")
+}
+
+// EnableSnapshots marks the store as one whose contents should be written to disk each
+// time a fetch completes.
+func (s *Store[T]) EnableSnapshots() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.snapshotEnabled = true
+}
+
+// HasSnapshot returns true if the store's current contents came from an on-disk snapshot
+func (s *Store[T]) HasSnapshot() bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.fromSnapshot
+}
+
+// SetIncremental switches the store between reloading everything on Fetch (the default)
+// and appending only rows newer than LastBlock. Only stores whose queryFunc honors
+// ResumeBlock should opt in.
+func (s *Store[T]) SetIncremental(incremental bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.incremental = incremental
+}
+
+// IsIncremental returns true if Fetch appends to the store rather than reloading it
+func (s *Store[T]) IsIncremental() bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.incremental
+}
+
+// LastBlock returns the highest block ingested by the last completed fetch
+func (s *Store[T]) LastBlock() uint64 {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.lastBlock
+}
+
+// CanResume returns true if the next Fetch will only ask for rows after LastBlock
+func (s *Store[T]) CanResume() bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.canResume()
+}
+
+// ResumeBlock returns the first block a queryFunc needs to ask for. It is zero unless the
+// store is incremental and already holds data, in which case only newer rows are required.
+func (s *Store[T]) ResumeBlock() uint64 {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	if !s.canResume() {
+		return 0
+	}
+	return s.lastBlock + 1
+}
+
+// canResume must be called with the mutex held
+func (s *Store[T]) canResume() bool {
+	return s.incremental && s.lastBlock > 0 && len(s.data) > 0
+}
+
+// trimUncommitted drops rows past lastBlock left behind by an interrupted fetch so the
+// next incremental fetch does not duplicate them. Rows are not assumed to be in block
+// order, so every row is checked. Must be called with the mutex held.
+func (s *Store[T]) trimUncommitted() {
+	kept := make([]*T, 0, len(s.data))
+	for _, item := range s.data {
+		if extractBlockNumberFromItem(item) <= s.lastBlock {
+			kept = append(kept, item)
+		}
+	}
+	if len(kept) == len(s.data) {
+		return
+	}
+
+	s.data = kept
+	if s.dataMap != nil && s.mappingFunc != nil {
+		tempMap := make(map[interface{}]*T, len(s.data))
+		for _, item := range s.data {
+			if key, include := s.mappingFunc(item); include {
+				tempMap[key] = item
+			}
+		}
+		s.dataMap = &tempMap
+	}
+	s.expectedTotalItems.Store(int64(len(s.data)))
+}
+
+// loadSnapshot populates an empty store from its snapshot file, if one exists. It
+// returns true if data was loaded. Must be called before the store is shared.
+func (s *Store[T]) loadSnapshot() bool {
+	path := snapshotPath(s.contextKey)
+	if path == "" {
+		return false
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+
+	var snap snapshotFile
+	if err := json.Unmarshal(data, &snap); err != nil {
+		logging.LogBEWarning(fmt.Sprintf("Ignoring unreadable snapshot %s: %v", path, err))
+		return false
+	}
+
+	if snap.Version != SnapshotVersion || snap.ContextKey != s.contextKey || len(snap.Data) == 0 {
+		return false
+	}
+
+	items := make([]*T, len(snap.Data))
+	for i, raw := range snap.Data {
+		items[i] = new(T)
+		if err := decodeItem(raw, items[i]); err != nil {
+			logging.LogBEWarning(fmt.Sprintf("Ignoring unreadable snapshot %s: %v", path, err))
+			return false
+		}
+	}
+
+	s.data = items
+	if s.mappingFunc != nil {
+		tempMap := make(map[interface{}]*T, len(snap.MapIndex))
+		for key, idx := range snap.MapIndex {
+			if idx >= 0 && idx < len(s.data) {
+				tempMap[key] = s.data[idx]
+			}
+		}
+		s.dataMap = &tempMap
+	}
+	s.lastBlock = snap.LastBlock
+	s.fromSnapshot = true
+	s.expectedTotalItems.Store(snap.ExpectedTotal)
+	return true
+}
+
+// saveSnapshot writes the store's current contents to disk if snapshots are enabled
+func (s *Store[T]) saveSnapshot() error {
+	s.mutex.RLock()
+	if !s.snapshotEnabled || len(s.data) == 0 {
+		s.mutex.RUnlock()
+		return nil
+	}
+
+	path := snapshotPath(s.contextKey)
+	if path == "" {
+		s.mutex.RUnlock()
+		return nil
+	}
+
+	snap := snapshotFile{
+		Version:       SnapshotVersion,
+		ContextKey:    s.contextKey,
+		LastBlock:     s.lastBlock,
+		ExpectedTotal: s.expectedTotalItems.Load(),
+		Data:          make([]json.RawMessage, len(s.data)),
+	}
+
+	indexOf := make(map[*T]int, len(s.data))
+	for i, item := range s.data {
+		indexOf[item] = i
+		raw, err := encodeItem(item)
+		if err != nil {
+			s.mutex.RUnlock()
+			return fmt.Errorf("failed to serialize snapshot: %w", err)
+		}
+		snap.Data[i] = raw
+	}
+
+	if s.dataMap != nil {
+		snap.MapIndex = make(map[string]int, len(*s.dataMap))
+		for key, item := range *s.dataMap {
+			if idx, ok := indexOf[item]; ok {
+				snap.MapIndex[fmt.Sprint(key)] = idx
+			}
+		}
+	}
+
+	bytes, err := json.Marshal(snap)
+	s.mutex.RUnlock()
+	if err != nil {
+		return fmt.Errorf("failed to serialize snapshot: %w", err)
+	}
+
+	return filewriter.GetGlobalWriter().WriteFile(path, bytes, filewriter.Batched)
+}
+
+// removeSnapshot deletes the store's snapshot file, if any. It goes through the same
+// writer as saveSnapshot so a save still waiting to be flushed is dropped, not written after.
+func (s *Store[T]) removeSnapshot() {
+	if path := snapshotPath(s.contextKey); path != "" {
+		_ = filewriter.GetGlobalWriter().RemoveFile(path)
+	}
+}
+
+// extractBlockNumberFromItem extracts a block number from an item using reflection
+func extractBlockNumberFromItem(item interface{}) uint64 {
+	value := reflect.ValueOf(item)
+	if value.Kind() == reflect.Ptr {
+		value = value.Elem()
+	}
+	if value.Kind() == reflect.Struct {
+		blockField := value.FieldByName("BlockNumber")
+		if blockField.IsValid() {
+			switch blockField.Kind() {
+			case reflect.Uint64, reflect.Uint32, reflect.Uint:
+				return blockField.Uint()
+			case reflect.Int64, reflect.Int32, reflect.Int:
+				if v := blockField.Int(); v > 0 {
+					return uint64(v)
+				}
+			}
+		}
+	}
+	return 0
+}
@@ -0,0 +1,163 @@
+package store
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// Snapshots cannot use the types' own JSON encoding: chifra hides fields the UI does not
+// show behind `json:"-"` (a statement's transaction and log, a transaction's rewards, a
+// trace's index, ...) and a restored store would silently lose them. encodeItem instead
+// walks every exported field by its Go name. Values that know how to encode themselves
+// (addresses, hashes, big numbers) are written with their own JSON, which round-trips.
+
+var (
+	jsonMarshalerType   = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// encodeItem returns item with every exported field, hidden or not, as JSON
+func encodeItem(item any) (json.RawMessage, error) {
+	tree, err := encodeValue(reflect.ValueOf(item), map[uintptr]bool{})
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(tree)
+}
+
+// decodeItem fills the struct item points to from JSON written by encodeItem
+func decodeItem(data json.RawMessage, item any) error {
+	value := reflect.ValueOf(item)
+	if value.Kind() != reflect.Ptr || value.IsNil() {
+		return fmt.Errorf("cannot decode into %T", item)
+	}
+	return decodeValue(data, value.Elem())
+}
+
+// isLeaf returns true for values written with their own JSON encoding rather than field by field
+func isLeaf(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return t.Kind() != reflect.Ptr && t.Kind() != reflect.Slice && t.Kind() != reflect.Array
+	}
+	p := reflect.PointerTo(t)
+	for _, iface := range []reflect.Type{jsonMarshalerType, jsonUnmarshalerType, textMarshalerType, textUnmarshalerType} {
+		if t.Implements(iface) || p.Implements(iface) {
+			return true
+		}
+	}
+	return false
+}
+
+// encodeValue returns v as a tree json.Marshal can write. onPath holds the pointers being
+// encoded above v, so that a value which refers back to its parent does not recurse forever.
+func encodeValue(v reflect.Value, onPath map[uintptr]bool) (any, error) {
+	t := v.Type()
+	switch {
+	case t.Kind() == reflect.Ptr:
+		if v.IsNil() || onPath[v.Pointer()] {
+			return nil, nil
+		}
+		onPath[v.Pointer()] = true
+		defer delete(onPath, v.Pointer())
+		return encodeValue(v.Elem(), onPath)
+
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+		return leafJSON(v)
+
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		if t.Kind() == reflect.Slice && v.IsNil() {
+			return nil, nil
+		}
+		ret := make([]any, v.Len())
+		for i := range ret {
+			item, err := encodeValue(v.Index(i), onPath)
+			if err != nil {
+				return nil, err
+			}
+			ret[i] = item
+		}
+		return ret, nil
+
+	case isLeaf(t):
+		return leafJSON(v)
+	}
+
+	ret := make(map[string]any, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		item, err := encodeValue(v.Field(i), onPath)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", t.Name(), field.Name, err)
+		}
+		ret[field.Name] = item
+	}
+	return ret, nil
+}
+
+// leafJSON encodes v through a pointer, since several chifra types marshal only that way
+func leafJSON(v reflect.Value) (json.RawMessage, error) {
+	ptr := reflect.New(v.Type())
+	ptr.Elem().Set(v)
+	return json.Marshal(ptr.Interface())
+}
+
+func decodeValue(data json.RawMessage, v reflect.Value) error {
+	if len(data) == 0 || string(data) == "null" {
+		return nil
+	}
+
+	t := v.Type()
+	switch {
+	case t.Kind() == reflect.Ptr:
+		elem := reflect.New(t.Elem())
+		if err := decodeValue(data, elem.Elem()); err != nil {
+			return err
+		}
+		v.Set(elem)
+		return nil
+
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+		return json.Unmarshal(data, v.Addr().Interface())
+
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		var items []json.RawMessage
+		if err := json.Unmarshal(data, &items); err != nil {
+			return err
+		}
+		if t.Kind() == reflect.Slice {
+			v.Set(reflect.MakeSlice(t, len(items), len(items)))
+		}
+		for i := 0; i < len(items) && i < v.Len(); i++ {
+			if err := decodeValue(items[i], v.Index(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case isLeaf(t):
+		return json.Unmarshal(data, v.Addr().Interface())
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		raw, ok := fields[field.Name]
+		if !ok || !field.IsExported() {
+			continue
+		}
+		if err := decodeValue(raw, v.Field(i)); err != nil {
+			return fmt.Errorf("%s.%s: %w", t.Name(), field.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,258 @@
+package store
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/output"
+	coreTypes "github.com/TrueBlocks/trueblocks-chifra/v6/pkg/types"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type BlockData struct {
+	BlockNumber uint64 `json:"blockNumber"`
+	Name        string `json:"name"`
+}
+
+func (b *BlockData) Model(chain, format string, verbose bool, extraOptions map[string]any) coreTypes.Model {
+	return coreTypes.Model{
+		Data:  map[string]any{"blockNumber": b.BlockNumber, "name": b.Name},
+		Order: []string{"blockNumber", "name"},
+	}
+}
+
+type blockObserver struct {
+	states []types.StoreState
+}
+
+func (o *blockObserver) OnNewItem(item *BlockData, index int) {}
+
+func (o *blockObserver) OnStateChanged(state types.StoreState, reason string) {
+	o.states = append(o.states, state)
+}
+
+// createBlockStore returns a store whose query streams every item in chain at or after ResumeBlock
+func createBlockStore(t *testing.T, key string, chain *[]*BlockData) *Store[BlockData] {
+	t.Helper()
+	var s *Store[BlockData]
+	s = NewStore(key,
+		func(ctx *output.RenderCtx) error {
+			first := s.ResumeBlock()
+			go func() {
+				defer close(ctx.ModelChan)
+				defer close(ctx.ErrorChan)
+				for _, item := range *chain {
+					if item.BlockNumber >= first {
+						ctx.ModelChan <- item
+					}
+				}
+			}()
+			return nil
+		},
+		func(item interface{}) *BlockData {
+			if it, ok := item.(*BlockData); ok {
+				return it
+			}
+			return nil
+		},
+		func(item *BlockData) (string, bool) {
+			return item.Name, true
+		})
+	s.EnableSnapshots()
+	s.SetIncremental(true)
+	return s
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	SetSnapshotDir(t.TempDir())
+	defer SetSnapshotDir("")
+
+	chain := []*BlockData{{10, "a"}, {11, "b"}, {12, "c"}}
+	first := createBlockStore(t, "snap-roundtrip", &chain)
+	assert.False(t, first.HasSnapshot())
+	require.NoError(t, first.Fetch())
+	assert.Equal(t, 3, first.Count())
+	assert.FileExists(t, snapshotPath("snap-roundtrip"))
+
+	second := createBlockStore(t, "snap-roundtrip", &chain)
+	assert.True(t, second.HasSnapshot())
+	assert.Equal(t, 3, second.Count())
+	assert.Equal(t, uint64(13), second.ResumeBlock())
+	assert.Equal(t, int64(3), second.ExpectedTotalItems())
+	assert.Equal(t, types.StateStale, second.GetState())
+
+	item, found := second.GetItemFromMap("b")
+	require.True(t, found)
+	assert.Equal(t, uint64(11), item.BlockNumber)
+}
+
+func TestSnapshotIncrementalFetch(t *testing.T) {
+	SetSnapshotDir(t.TempDir())
+	defer SetSnapshotDir("")
+
+	chain := []*BlockData{{10, "a"}, {11, "b"}}
+	require.NoError(t, createBlockStore(t, "snap-incremental", &chain).Fetch())
+
+	chain = append(chain, &BlockData{20, "c"}, &BlockData{21, "d"})
+	restored := createBlockStore(t, "snap-incremental", &chain)
+	observer := &blockObserver{}
+	restored.RegisterObserver(observer)
+
+	require.NoError(t, restored.Fetch())
+	assert.Equal(t, 4, restored.Count())
+	assert.Equal(t, "d", restored.GetItem(3).Name)
+	assert.Equal(t, types.StateCached, observer.states[0])
+	assert.Equal(t, types.StateLoaded, observer.states[len(observer.states)-1])
+	assert.Equal(t, uint64(22), restored.ResumeBlock())
+}
+
+func TestIncrementalFetchWithoutSnapshot(t *testing.T) {
+	SetSnapshotDir("")
+
+	chain := []*BlockData{{10, "a"}, {11, "b"}}
+	s := createBlockStore(t, "incremental-memory", &chain)
+	assert.False(t, s.CanResume())
+	require.NoError(t, s.Fetch())
+	assert.Equal(t, uint64(11), s.LastBlock())
+	assert.True(t, s.CanResume())
+
+	chain = append(chain, &BlockData{15, "c"})
+	require.NoError(t, s.Fetch())
+	assert.Equal(t, 3, s.Count())
+	assert.Equal(t, uint64(15), s.LastBlock())
+
+	require.NoError(t, s.Fetch())
+	assert.Equal(t, 3, s.Count(), "nothing new means nothing appended")
+}
+
+func TestIncrementalFetchTrimsUncommittedRows(t *testing.T) {
+	SetSnapshotDir("")
+
+	chain := []*BlockData{{10, "a"}, {11, "b"}}
+	s := createBlockStore(t, "incremental-trim", &chain)
+	require.NoError(t, s.Fetch())
+
+	// Simulate rows left behind by a fetch that never completed
+	s.AddItem(&BlockData{12, "partial"}, 2)
+	assert.Equal(t, 3, s.Count())
+
+	chain = append(chain, &BlockData{12, "c"})
+	require.NoError(t, s.Fetch())
+	assert.Equal(t, 3, s.Count())
+	assert.Equal(t, "c", s.GetItem(2).Name)
+	_, found := s.GetItemFromMap("partial")
+	assert.False(t, found)
+}
+
+func TestIncrementalFetchTrimsOutOfOrderRows(t *testing.T) {
+	SetSnapshotDir("")
+
+	chain := []*BlockData{{10, "a"}, {11, "b"}}
+	s := createBlockStore(t, "incremental-trim-order", &chain)
+	require.NoError(t, s.Fetch())
+
+	// An interrupted fetch need not leave its rows in block order
+	s.AddItem(&BlockData{13, "partial"}, 2)
+	s.AddItem(&BlockData{11, "late"}, 3)
+
+	chain = append(chain, &BlockData{13, "c"})
+	require.NoError(t, s.Fetch())
+	assert.Equal(t, 4, s.Count())
+	_, found := s.GetItemFromMap("partial")
+	assert.False(t, found)
+	_, found = s.GetItemFromMap("late")
+	assert.True(t, found)
+}
+
+func TestNonIncrementalFetchReloads(t *testing.T) {
+	SetSnapshotDir("")
+
+	chain := []*BlockData{{10, "a"}, {11, "b"}}
+	s := createBlockStore(t, "incremental-off", &chain)
+	s.SetIncremental(false)
+	require.NoError(t, s.Fetch())
+	assert.Equal(t, uint64(0), s.ResumeBlock())
+
+	require.NoError(t, s.Fetch())
+	assert.Equal(t, 2, s.Count())
+}
+
+func TestSnapshotIgnoredOnVersionMismatch(t *testing.T) {
+	SetSnapshotDir(t.TempDir())
+	defer SetSnapshotDir("")
+
+	path := snapshotPath("snap-version")
+	contents := fmt.Sprintf(`{"version":%d,"contextKey":"snap-version","lastBlock":5,"data":[{"blockNumber":5,"name":"x"}]}`, SnapshotVersion+1)
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0644))
+
+	s := createBlockStore(t, "snap-version", &[]*BlockData{})
+	assert.False(t, s.HasSnapshot())
+	assert.Equal(t, 0, s.Count())
+}
+
+func TestSnapshotRemovedOnReset(t *testing.T) {
+	SetSnapshotDir(t.TempDir())
+	defer SetSnapshotDir("")
+
+	s := createBlockStore(t, "snap-reset", &[]*BlockData{{1, "a"}})
+	require.NoError(t, s.Fetch())
+	assert.FileExists(t, snapshotPath("snap-reset"))
+
+	s.Reset()
+	assert.NoFileExists(t, snapshotPath("snap-reset"))
+	assert.Equal(t, uint64(0), s.ResumeBlock())
+}
+
+func TestSnapshotDisabledWithoutDir(t *testing.T) {
+	SetSnapshotDir("")
+	s := createBlockStore(t, "snap-disabled", &[]*BlockData{{1, "a"}})
+	require.NoError(t, s.Fetch())
+	assert.False(t, s.HasSnapshot())
+	assert.Equal(t, "", snapshotPath("snap-disabled"))
+}
+
+func TestSnapshotKeepsHiddenFields(t *testing.T) {
+	// chifra hides these from JSON, which a snapshot must not lose
+	tx := &coreTypes.Transaction{
+		BlockNumber: 12,
+		Hash:        base.HexToHash("0x01"),
+		Value:       *base.NewWei(1000),
+		Message:     "hidden message",
+		Rewards:     &coreTypes.Rewards{Block: *base.NewWei(2)},
+	}
+	stmt := &coreTypes.Statement{
+		BlockNumber: 12,
+		Asset:       base.HexToAddress("0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266"),
+		AmountIn:    *base.NewWei(5),
+		Transaction: tx,
+		Log:         &coreTypes.Log{BlockNumber: 12, LogIndex: 3},
+		EndSentinel: true,
+	}
+	trace := &coreTypes.Trace{BlockNumber: 12, TraceIndex: 7}
+
+	raw, err := encodeItem(stmt)
+	require.NoError(t, err)
+	var gotStmt coreTypes.Statement
+	require.NoError(t, decodeItem(raw, &gotStmt))
+	assert.Equal(t, stmt.Asset, gotStmt.Asset)
+	assert.Equal(t, "5", gotStmt.AmountIn.String())
+	assert.True(t, gotStmt.EndSentinel)
+	require.NotNil(t, gotStmt.Transaction)
+	assert.Equal(t, "hidden message", gotStmt.Transaction.Message)
+	assert.Equal(t, tx.Hash, gotStmt.Transaction.Hash)
+	assert.Equal(t, "1000", gotStmt.Transaction.Value.String())
+	require.NotNil(t, gotStmt.Transaction.Rewards)
+	assert.Equal(t, "2", gotStmt.Transaction.Rewards.Block.String())
+	require.NotNil(t, gotStmt.Log)
+	assert.Equal(t, base.Lognum(3), gotStmt.Log.LogIndex)
+
+	raw, err = encodeItem(trace)
+	require.NoError(t, err)
+	var gotTrace coreTypes.Trace
+	require.NoError(t, decodeItem(raw, &gotTrace))
+	assert.Equal(t, base.Tracenum(7), gotTrace.TraceIndex)
+}
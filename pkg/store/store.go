@@ -1,6 +1,7 @@
 package store
 
 import (
+	"fmt"
 	"sort"
 	"sync"
 	"sync/atomic"
@@ -34,9 +35,14 @@ type Store[T any] struct {
 	summaryManager     *SummaryManager[T] // Manages aggregated summary data
 	mutex              sync.RWMutex
 	mapSortFunc        func(a, b *T) bool
+	snapshotEnabled    bool   // Write contents to disk after each completed fetch
+	fromSnapshot       bool   // Current contents were loaded from disk
+	incremental        bool   // Fetch appends rows after lastBlock instead of reloading
+	lastBlock          uint64 // Highest block ingested by the last completed fetch (0 if none)
 }
 
-// NewStore creates a new SDK-based store
+// NewStore creates a new SDK-based store. If a snapshot for the context key exists on
+// disk, the store starts out populated with it (see SetSnapshotDir).
 func NewStore[T any](
 	contextKey string,
 	queryFunc func(*output.RenderCtx) error,
@@ -58,6 +64,7 @@ func NewStore[T any](
 		s.dataMap = &tempMap
 	}
 	s.expectedTotalItems.Store(0)
+	s.loadSnapshot()
 	return s
 }
 
@@ -195,19 +202,28 @@ func (s *Store[T]) Fetch() error {
 	// Stop any currently running fetches. If we're here, we want to reload (or process for the first time)
 	UnregisterContext(s.contextKey)
 
-	// Clear data and change state to prepare for the new fetch
+	// Clear data and change state to prepare for the new fetch. An incremental store keeps
+	// what it already holds (the query only returns newer rows) and reports it as cached.
 	s.mutex.Lock()
-	s.data = s.data[:0]
-	s.expectedTotalItems.Store(0)
-	s.state = types.StateFetching
-	s.stateReason = "User reload - fetching data"
+	newState, reason := types.StateFetching, "User reload - fetching data"
+	if s.canResume() {
+		newState, reason = types.StateCached, "Showing cached data - fetching newer rows"
+		s.trimUncommitted()
+	} else {
+		s.data = s.data[:0]
+		s.expectedTotalItems.Store(0)
+		s.lastBlock = 0
+	}
+	highest := s.lastBlock
+	s.state = newState
+	s.stateReason = reason
 
 	// Notify observers while holding the lock
 	currentObservers := make([]FacetObserver[T], len(s.observers))
 	copy(currentObservers, s.observers)
 	s.mutex.Unlock()
 	for _, observer := range currentObservers {
-		observer.OnStateChanged(types.StateFetching, "User reload - fetching data")
+		observer.OnStateChanged(newState, reason)
 	}
 
 	renderCtx := RegisterContext(s.contextKey)
@@ -259,6 +275,9 @@ func (s *Store[T]) Fetch() error {
 			s.data = append(s.data, itemPtr)
 			s.expectedTotalItems.Store(int64(len(s.data)))
 			index := len(s.data) - 1
+			if blk := extractBlockNumberFromItem(itemPtr); blk > highest {
+				highest = blk
+			}
 
 			// TODO: BOGUS
 			// Note: Summary aggregation is now handled by the GetSummaryPage method in the backend
@@ -312,6 +331,16 @@ func (s *Store[T]) Fetch() error {
 		}
 	}
 
+	// Only a completed fetch moves the high-water mark. Rows from an interrupted fetch
+	// stay visible but are trimmed and re-requested by the next incremental fetch.
+	s.mutex.Lock()
+	s.lastBlock = highest
+	s.mutex.Unlock()
+
+	if err := s.saveSnapshot(); err != nil {
+		logging.LogBEWarning(fmt.Sprintf("Failed to write snapshot for %s: %v", s.contextKey, err))
+	}
+
 	s.ChangeState(types.StateLoaded, "Data loaded successfully")
 	return nil
 }
@@ -361,6 +390,9 @@ func (s *Store[T]) Reset() {
 	}
 	s.summaryManager.Reset()
 	s.expectedTotalItems.Store(0)
+	s.lastBlock = 0
+	s.fromSnapshot = false
+	s.removeSnapshot()
 
 	s.state = types.StateStale
 	s.stateReason = "Store reset"
@@ -104,6 +104,29 @@ func NormalizeToPeriod(timestamp int64, period types.Period) int64 {
 	}
 }
 
+// NextPeriodStart returns the start of the period after the one holding timestamp. For
+// PeriodBlockly, which has no periods, it returns timestamp.
+func NextPeriodStart(timestamp int64, period types.Period) int64 {
+	t := time.Unix(NormalizeToPeriod(timestamp, period), 0).UTC()
+
+	switch period {
+	case types.PeriodHourly:
+		return t.Add(time.Hour).Unix()
+	case types.PeriodDaily:
+		return t.AddDate(0, 0, 1).Unix()
+	case types.PeriodWeekly:
+		return t.AddDate(0, 0, 7).Unix()
+	case types.PeriodMonthly:
+		return t.AddDate(0, 1, 0).Unix()
+	case types.PeriodQuarterly:
+		return t.AddDate(0, 3, 0).Unix()
+	case types.PeriodAnnual:
+		return t.AddDate(1, 0, 0).Unix()
+	default: // PeriodBlockly
+		return timestamp
+	}
+}
+
 // extractTimestampFromItem extracts a timestamp from an item using reflection
 func extractTimestampFromItem(item interface{}) int64 {
 	// Try to find a Timestamp field using reflection
@@ -88,3 +88,27 @@ func TestStoreWithSummary(t *testing.T) {
 		t.Errorf("Expected 2 items in daily summaries, got %d", len(summaries))
 	}
 }
+
+func TestNextPeriodStart(t *testing.T) {
+	// Wednesday 2024-05-15 13:45:10 UTC
+	ts := time.Date(2024, 5, 15, 13, 45, 10, 0, time.UTC).Unix()
+	tests := []struct {
+		period   types.Period
+		expected time.Time
+	}{
+		{types.PeriodHourly, time.Date(2024, 5, 15, 14, 0, 0, 0, time.UTC)},
+		{types.PeriodDaily, time.Date(2024, 5, 16, 0, 0, 0, 0, time.UTC)},
+		{types.PeriodWeekly, time.Date(2024, 5, 19, 0, 0, 0, 0, time.UTC)},
+		{types.PeriodMonthly, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{types.PeriodQuarterly, time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)},
+		{types.PeriodAnnual, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		if got := NextPeriodStart(ts, tt.period); got != tt.expected.Unix() {
+			t.Errorf("%s: expected %s, got %s", tt.period, tt.expected, time.Unix(got, 0).UTC())
+		}
+	}
+	if got := NextPeriodStart(ts, types.PeriodBlockly); got != ts {
+		t.Errorf("blockly: expected the timestamp unchanged, got %d", got)
+	}
+}
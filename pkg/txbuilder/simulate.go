@@ -0,0 +1,161 @@
+package txbuilder
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/rpcpool"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/articulate"
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// The selectors of the errors Solidity raises itself
+var (
+	errorSelector = []byte{0x08, 0xc3, 0x79, 0xa0} // Error(string)
+	panicSelector = []byte{0x4e, 0x48, 0x7b, 0x71} // Panic(uint256)
+)
+
+// Simulation is the outcome of running a transaction with eth_call at the latest block. A
+// call that succeeds has the function's outputs decoded; one that reverts has the reason.
+type Simulation struct {
+	Success      bool            `json:"success"`
+	Outputs      []sdk.Parameter `json:"outputs,omitempty"`
+	ReturnData   string          `json:"returnData,omitempty"`
+	Reverted     bool            `json:"reverted,omitempty"`
+	RevertReason string          `json:"revertReason,omitempty"`
+	Error        string          `json:"error,omitempty"`
+}
+
+// Simulate calls req's transaction on the provider at rpcURL, as req's sender, without
+// sending it. fn decodes what the call returns and getErrors, which returns the custom errors
+// of the contract and is called only if the call reverts, why it reverts. A call that could
+// not be made at all has its reason in Error.
+func Simulate(rpcURL string, req Request, fn *sdk.Function, getErrors func() []abi.Error) *Simulation {
+	call := map[string]string{
+		"from": req.From.Hex(),
+		"data": "0x" + hex.EncodeToString(req.Data),
+	}
+	if req.To != nil {
+		call["to"] = req.To.Hex()
+	}
+	if req.Value != nil && req.Value.Sign() > 0 {
+		call["value"] = hexQuantity(req.Value)
+	}
+
+	ret := &Simulation{}
+	out, err := rpcpool.CallString(rpcURL, "eth_call", call, "latest")
+	if err != nil {
+		data, reverted := revertData(err)
+		if !reverted {
+			ret.Error = err.Error()
+			return ret
+		}
+		ret.Reverted = true
+		if len(data) > 0 {
+			ret.ReturnData = "0x" + hex.EncodeToString(data)
+		}
+		var errs []abi.Error
+		if getErrors != nil && len(data) >= 4 {
+			errs = getErrors()
+		}
+		ret.RevertReason = DecodeRevert(data, errs)
+		return ret
+	}
+
+	ret.Success = true
+	ret.ReturnData = out
+	if fn != nil && len(fn.Outputs) > 0 && len(out) > 2 {
+		decoded := *fn
+		decoded.Outputs = append([]sdk.Parameter(nil), fn.Outputs...)
+		if err := articulate.ArticulateFunction(&decoded, "", strings.TrimPrefix(out, "0x")); err != nil {
+			ret.Error = fmt.Sprintf("could not decode the result: %v", err)
+		} else {
+			ret.Outputs = decoded.Outputs
+		}
+	}
+	return ret
+}
+
+// revertData returns the return data of a reverted call from the provider's error. Clients
+// send it as a hex string, some with a "Reverted " prefix. An error without data is a revert
+// if its message says so.
+func revertData(err error) ([]byte, bool) {
+	var callErr *rpcpool.CallError
+	if !errors.As(err, &callErr) {
+		return nil, false
+	}
+	var s string
+	if len(callErr.Data) > 0 && json.Unmarshal(callErr.Data, &s) == nil {
+		s = strings.TrimPrefix(s, "Reverted ")
+		if data, err := hex.DecodeString(strings.TrimPrefix(s, "0x")); err == nil && strings.HasPrefix(s, "0x") {
+			return data, true
+		}
+	}
+	msg := strings.ToLower(callErr.Message)
+	return nil, callErr.Code == 3 || strings.Contains(msg, "revert")
+}
+
+// DecodeRevert returns a readable reason for the return data of a reverted call: the message
+// of a require or revert, the meaning of a panic's code, or a custom error in errs with its
+// arguments
+func DecodeRevert(data []byte, errs []abi.Error) string {
+	if len(data) == 0 {
+		return "reverted without a reason"
+	}
+	if len(data) < 4 {
+		return "reverted with malformed data 0x" + hex.EncodeToString(data)
+	}
+
+	selector := data[:4]
+	switch {
+	case bytes.Equal(selector, errorSelector), bytes.Equal(selector, panicSelector):
+		reason, err := abi.UnpackRevert(data)
+		if err != nil {
+			break
+		}
+		if bytes.Equal(selector, panicSelector) {
+			return "panic: " + reason
+		}
+		return reason
+	default:
+		for i := range errs {
+			if !bytes.Equal(selector, errs[i].ID[:4]) {
+				continue
+			}
+			values, err := errs[i].Unpack(data)
+			if err != nil {
+				break
+			}
+			args := make([]string, len(errs[i].Inputs))
+			for j, input := range errs[i].Inputs {
+				args[j] = input.Name + ": " + formatValue(values.([]any)[j])
+			}
+			return errs[i].Name + "(" + strings.Join(args, ", ") + ")"
+		}
+	}
+	return "reverted with unknown error 0x" + hex.EncodeToString(selector)
+}
+
+func formatValue(value any) string {
+	switch v := value.(type) {
+	case common.Address:
+		addr := base.HexToAddress(v.Hex())
+		return addr.Hex()
+	case []byte:
+		return "0x" + hex.EncodeToString(v)
+	case *big.Int:
+		return v.String()
+	case [32]byte:
+		return "0x" + hex.EncodeToString(v[:])
+	}
+	return fmt.Sprint(value)
+}
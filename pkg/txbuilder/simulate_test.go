@@ -0,0 +1,119 @@
+package txbuilder
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testErrorsAbi = `[{"type":"error","name":"InsufficientBalance","inputs":[
+	{"name":"owner","type":"address"},{"name":"available","type":"uint256"},{"name":"required","type":"uint256"}]}]`
+
+func testErrors(t *testing.T) []abi.Error {
+	parsed, err := abi.JSON(strings.NewReader(testErrorsAbi))
+	require.NoError(t, err)
+	return []abi.Error{parsed.Errors["InsufficientBalance"]}
+}
+
+func revertWith(t *testing.T, typ string, value any) string {
+	abiType, err := abi.NewType(typ, "", nil)
+	require.NoError(t, err)
+	packed, err := abi.Arguments{{Type: abiType}}.Pack(value)
+	require.NoError(t, err)
+	selector := errorSelector
+	if typ == "uint256" {
+		selector = panicSelector
+	}
+	return "0x" + hex.EncodeToString(append(append([]byte{}, selector...), packed...))
+}
+
+func TestDecodeRevert(t *testing.T) {
+	errs := testErrors(t)
+	decode := func(s string) string {
+		data, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+		require.NoError(t, err)
+		return DecodeRevert(data, errs)
+	}
+
+	assert.Equal(t, "ERC20: transfer amount exceeds balance", decode(revertWith(t, "string", "ERC20: transfer amount exceeds balance")))
+	assert.Equal(t, "panic: arithmetic underflow or overflow", decode(revertWith(t, "uint256", big.NewInt(0x11))))
+	assert.Equal(t, "reverted without a reason", decode(""))
+	assert.Equal(t, "reverted with unknown error 0xdeadbeef", decode("0xdeadbeef"))
+
+	owner := base.HexToAddress("0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266")
+	packed, err := errs[0].Inputs.Pack(owner.Address, big.NewInt(5), big.NewInt(10))
+	require.NoError(t, err)
+	custom := "0x" + hex.EncodeToString(errs[0].ID[:4]) + hex.EncodeToString(packed)
+	assert.Equal(t, "InsufficientBalance(owner: 0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266, available: 5, required: 10)", decode(custom))
+}
+
+// simulationServer answers eth_call with response, the body of a JSON-RPC reply
+func simulationServer(t *testing.T, response string) string {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string           `json:"method"`
+			Params []map[string]any `json:"params"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		assert.Equal(t, "eth_call", req.Method)
+		assert.Equal(t, "0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266", req.Params[0]["from"])
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,` + response + `}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+func TestSimulate(t *testing.T) {
+	to := base.HexToAddress("0x3535353535353535353535353535353535353535")
+	req := Request{From: base.HexToAddress("0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266"), To: &to, Data: []byte{0xa9, 0x05, 0x9c, 0xbb}}
+	transfer := &sdk.Function{
+		Name:            "transfer",
+		FunctionType:    "function",
+		StateMutability: "nonpayable",
+		Inputs:          []sdk.Parameter{{Name: "to", ParameterType: "address"}, {Name: "amount", ParameterType: "uint256"}},
+		Outputs:         []sdk.Parameter{{Name: "success", ParameterType: "bool"}},
+	}
+	errs := func() []abi.Error { return testErrors(t) }
+
+	sim := Simulate(simulationServer(t, `"result":"0x`+strings.Repeat("0", 63)+`1"`), req, transfer, errs)
+	require.True(t, sim.Success)
+	require.Len(t, sim.Outputs, 1)
+	assert.Equal(t, true, sim.Outputs[0].Value)
+	assert.Nil(t, transfer.Outputs[0].Value, "the function's own outputs are not changed")
+
+	reason := revertWith(t, "string", "Ownable: caller is not the owner")
+	sim = Simulate(simulationServer(t, `"error":{"code":3,"message":"execution reverted","data":"`+reason+`"}`), req, transfer, errs)
+	assert.False(t, sim.Success)
+	assert.True(t, sim.Reverted)
+	assert.Equal(t, "Ownable: caller is not the owner", sim.RevertReason)
+	assert.Equal(t, reason, sim.ReturnData)
+
+	// Some clients prefix the data, and some send none
+	sim = Simulate(simulationServer(t, `"error":{"code":-32015,"message":"VM execution error.","data":"Reverted `+reason+`"}`), req, transfer, errs)
+	assert.Equal(t, "Ownable: caller is not the owner", sim.RevertReason)
+	sim = Simulate(simulationServer(t, `"error":{"code":-32000,"message":"execution reverted"}`), req, transfer, errs)
+	assert.True(t, sim.Reverted)
+	assert.Equal(t, "reverted without a reason", sim.RevertReason)
+
+	insufficient := testErrors(t)[0]
+	packed, err := insufficient.Inputs.Pack(req.From.Address, big.NewInt(0), big.NewInt(1))
+	require.NoError(t, err)
+	custom := "0x" + hex.EncodeToString(insufficient.ID[:4]) + hex.EncodeToString(packed)
+	sim = Simulate(simulationServer(t, `"error":{"code":3,"message":"execution reverted","data":"`+custom+`"}`), req, transfer, errs)
+	assert.Equal(t, "InsufficientBalance(owner: 0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266, available: 0, required: 1)", sim.RevertReason)
+
+	// A failure that is not a revert is an error
+	sim = Simulate(simulationServer(t, `"error":{"code":-32000,"message":"header not found"}`), req, transfer, errs)
+	assert.False(t, sim.Reverted)
+	assert.Equal(t, "eth_call: header not found", sim.Error)
+}
@@ -0,0 +1,243 @@
+package txbuilder
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/rpcpool"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// FeeModel is how a transaction pays for gas
+type FeeModel string
+
+const (
+	// FeeAuto uses EIP-1559 fees if the chain's latest block has a base fee, legacy otherwise
+	FeeAuto FeeModel = ""
+	// FeeLegacy pays a single gas price
+	FeeLegacy FeeModel = "legacy"
+	// FeeEip1559 pays the base fee plus a priority fee, up to a maximum
+	FeeEip1559 FeeModel = "eip1559"
+)
+
+// DefaultPriorityFee is the priority fee, in wei, used when the provider does not suggest one
+const DefaultPriorityFee = 1_000_000_000
+
+// Request is what a transaction is built from. Gas is the gas limit, usually an estimate.
+// The nonce, chain ID and fees are read from the provider unless they are given.
+type Request struct {
+	ChainId              uint64
+	From                 base.Address
+	To                   *base.Address
+	Value                *big.Int
+	Data                 []byte
+	Gas                  uint64
+	FeeModel             FeeModel
+	Nonce                *uint64
+	GasPrice             *big.Int
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+}
+
+// UnsignedTx is a transaction ready to be signed elsewhere. Payload is the RLP encoding of
+// the fields that are signed, prefixed with the type byte for EIP-1559, and SigningHash is
+// its keccak, which is what the signer signs. Amounts are hex quantities, as in JSON-RPC.
+type UnsignedTx struct {
+	Type                 FeeModel `json:"type"`
+	ChainId              uint64   `json:"chainId"`
+	Nonce                uint64   `json:"nonce"`
+	From                 string   `json:"from"`
+	To                   string   `json:"to,omitempty"`
+	Value                string   `json:"value"`
+	Data                 string   `json:"data"`
+	Gas                  string   `json:"gas"`
+	GasPrice             string   `json:"gasPrice,omitempty"`
+	MaxFeePerGas         string   `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas string   `json:"maxPriorityFeePerGas,omitempty"`
+	Payload              string   `json:"payload"`
+	SigningHash          string   `json:"signingHash"`
+}
+
+// Build fills in req from the provider at rpcURL and returns the unsigned transaction. A
+// chain ID in req must match the provider's.
+func Build(rpcURL string, req Request) (*UnsignedTx, error) {
+	chainId, err := quantity(rpcURL, "eth_chainId")
+	if err != nil {
+		return nil, err
+	}
+	if !chainId.IsUint64() || (req.ChainId != 0 && chainId.Uint64() != req.ChainId) {
+		return nil, fmt.Errorf("the rpc provider is on chain %s, not %d", chainId, req.ChainId)
+	}
+	req.ChainId = chainId.Uint64()
+
+	if req.Nonce == nil {
+		n, err := quantity(rpcURL, "eth_getTransactionCount", req.From.Hex(), "pending")
+		if err != nil {
+			return nil, err
+		}
+		nonce := n.Uint64()
+		req.Nonce = &nonce
+	}
+
+	if err := fillFees(rpcURL, &req); err != nil {
+		return nil, err
+	}
+	return Encode(req)
+}
+
+// fillFees chooses the fee model, if it is not given, and reads any fees that are missing.
+// An EIP-1559 transaction may pay up to twice the current base fee plus its priority fee,
+// so it stays valid while the base fee rises for a few blocks.
+func fillFees(rpcURL string, req *Request) error {
+	var baseFee *big.Int
+	if req.FeeModel != FeeLegacy {
+		raw, err := rpcpool.Call(rpcURL, "eth_getBlockByNumber", "latest", false)
+		if err != nil {
+			return err
+		}
+		var block struct {
+			BaseFeePerGas string `json:"baseFeePerGas"`
+		}
+		if err := json.Unmarshal(raw, &block); err != nil {
+			return fmt.Errorf("bad latest block: %w", err)
+		}
+		if block.BaseFeePerGas != "" {
+			if baseFee, err = parseQuantity(block.BaseFeePerGas); err != nil {
+				return err
+			}
+		}
+	}
+
+	switch req.FeeModel {
+	case FeeAuto:
+		req.FeeModel = FeeLegacy
+		if baseFee != nil {
+			req.FeeModel = FeeEip1559
+		}
+	case FeeLegacy:
+	case FeeEip1559:
+		if baseFee == nil && req.MaxFeePerGas == nil {
+			return fmt.Errorf("the chain does not support EIP-1559 fees")
+		}
+	default:
+		return fmt.Errorf("unknown fee model %q", req.FeeModel)
+	}
+
+	if req.FeeModel == FeeLegacy {
+		if req.GasPrice == nil {
+			price, err := quantity(rpcURL, "eth_gasPrice")
+			if err != nil {
+				return err
+			}
+			req.GasPrice = price
+		}
+		return nil
+	}
+
+	if req.MaxPriorityFeePerGas == nil {
+		tip, err := quantity(rpcURL, "eth_maxPriorityFeePerGas")
+		if err != nil {
+			tip = big.NewInt(DefaultPriorityFee)
+		}
+		req.MaxPriorityFeePerGas = tip
+	}
+	if req.MaxFeePerGas == nil {
+		req.MaxFeePerGas = new(big.Int).Add(new(big.Int).Mul(baseFee, big.NewInt(2)), req.MaxPriorityFeePerGas)
+	}
+	return nil
+}
+
+// Encode returns the unsigned transaction of req, which must be complete. A legacy
+// transaction is signed as EIP-155 describes, with the chain ID in place of the signature.
+func Encode(req Request) (*UnsignedTx, error) {
+	if req.Nonce == nil {
+		return nil, fmt.Errorf("the transaction has no nonce")
+	}
+	if req.ChainId == 0 {
+		return nil, fmt.Errorf("the transaction has no chain id")
+	}
+	value := req.Value
+	if value == nil {
+		value = new(big.Int)
+	}
+	to := []byte{}
+	if req.To != nil {
+		to = req.To.Bytes()
+	}
+
+	ret := &UnsignedTx{
+		Type:    req.FeeModel,
+		ChainId: req.ChainId,
+		Nonce:   *req.Nonce,
+		From:    req.From.Hex(),
+		Value:   hexQuantity(value),
+		Data:    "0x" + hex.EncodeToString(req.Data),
+		Gas:     fmt.Sprintf("0x%x", req.Gas),
+	}
+	if req.To != nil {
+		ret.To = req.To.Hex()
+	}
+
+	var payload []byte
+	switch req.FeeModel {
+	case FeeLegacy:
+		if req.GasPrice == nil {
+			return nil, fmt.Errorf("a legacy transaction needs a gas price")
+		}
+		ret.GasPrice = hexQuantity(req.GasPrice)
+		fields := []any{*req.Nonce, req.GasPrice, req.Gas, to, value, req.Data, req.ChainId, uint(0), uint(0)}
+		enc, err := rlp.EncodeToBytes(fields)
+		if err != nil {
+			return nil, err
+		}
+		payload = enc
+	case FeeEip1559:
+		if req.MaxFeePerGas == nil || req.MaxPriorityFeePerGas == nil {
+			return nil, fmt.Errorf("an EIP-1559 transaction needs a max fee and a priority fee")
+		}
+		if req.MaxPriorityFeePerGas.Cmp(req.MaxFeePerGas) > 0 {
+			return nil, fmt.Errorf("the priority fee is more than the max fee")
+		}
+		ret.MaxFeePerGas = hexQuantity(req.MaxFeePerGas)
+		ret.MaxPriorityFeePerGas = hexQuantity(req.MaxPriorityFeePerGas)
+		accessList := []any{}
+		fields := []any{req.ChainId, *req.Nonce, req.MaxPriorityFeePerGas, req.MaxFeePerGas, req.Gas, to, value, req.Data, accessList}
+		enc, err := rlp.EncodeToBytes(fields)
+		if err != nil {
+			return nil, err
+		}
+		payload = append([]byte{0x02}, enc...)
+	default:
+		return nil, fmt.Errorf("unknown fee model %q", req.FeeModel)
+	}
+
+	ret.Payload = "0x" + hex.EncodeToString(payload)
+	ret.SigningHash = "0x" + hex.EncodeToString(crypto.Keccak256(payload))
+	return ret, nil
+}
+
+func quantity(rpcURL, method string, params ...any) (*big.Int, error) {
+	s, err := rpcpool.CallString(rpcURL, method, params...)
+	if err != nil {
+		return nil, err
+	}
+	return parseQuantity(s)
+}
+
+func parseQuantity(s string) (*big.Int, error) {
+	n, ok := new(big.Int).SetString(strings.TrimPrefix(s, "0x"), 16)
+	if !ok || !strings.HasPrefix(s, "0x") {
+		return nil, fmt.Errorf("bad quantity %q", s)
+	}
+	return n, nil
+}
+
+func hexQuantity(n *big.Int) string {
+	return "0x" + n.Text(16)
+}
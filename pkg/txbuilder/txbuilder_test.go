@@ -0,0 +1,134 @@
+package txbuilder
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeMatchesSigners(t *testing.T) {
+	nonce := uint64(7)
+	to := base.HexToAddress("0x3535353535353535353535353535353535353535")
+	req := Request{
+		ChainId: 1,
+		From:    base.HexToAddress("0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266"),
+		To:      &to,
+		Value:   big.NewInt(1_000_000_000_000_000),
+		Data:    []byte{0xa9, 0x05, 0x9c, 0xbb},
+		Gas:     21000,
+		Nonce:   &nonce,
+	}
+
+	legacy := req
+	legacy.FeeModel = FeeLegacy
+	legacy.GasPrice = big.NewInt(20_000_000_000)
+	tx, err := Encode(legacy)
+	require.NoError(t, err)
+	want := types.NewEIP155Signer(big.NewInt(1)).Hash(types.NewTx(&types.LegacyTx{
+		Nonce: 7, GasPrice: legacy.GasPrice, Gas: 21000, To: &to.Address, Value: req.Value, Data: req.Data,
+	}))
+	assert.Equal(t, want.Hex(), tx.SigningHash)
+	assert.Equal(t, "0x4a817c800", tx.GasPrice)
+	assert.Empty(t, tx.MaxFeePerGas)
+
+	dynamic := req
+	dynamic.FeeModel = FeeEip1559
+	dynamic.MaxFeePerGas = big.NewInt(30_000_000_000)
+	dynamic.MaxPriorityFeePerGas = big.NewInt(2_000_000_000)
+	tx, err = Encode(dynamic)
+	require.NoError(t, err)
+	want = types.NewLondonSigner(big.NewInt(1)).Hash(types.NewTx(&types.DynamicFeeTx{
+		ChainID: big.NewInt(1), Nonce: 7, GasTipCap: dynamic.MaxPriorityFeePerGas, GasFeeCap: dynamic.MaxFeePerGas,
+		Gas: 21000, To: &to.Address, Value: req.Value, Data: req.Data,
+	}))
+	assert.Equal(t, want.Hex(), tx.SigningHash)
+	assert.Equal(t, "0x02", tx.Payload[:4])
+	assert.Equal(t, "0x6fc23ac00", tx.MaxFeePerGas)
+
+	dynamic.MaxPriorityFeePerGas = big.NewInt(40_000_000_000)
+	_, err = Encode(dynamic)
+	assert.ErrorContains(t, err, "priority fee")
+
+	// A contract creation has no recipient
+	create := legacy
+	create.To = nil
+	tx, err = Encode(create)
+	require.NoError(t, err)
+	want = types.NewEIP155Signer(big.NewInt(1)).Hash(types.NewTx(&types.LegacyTx{
+		Nonce: 7, GasPrice: legacy.GasPrice, Gas: 21000, Value: req.Value, Data: req.Data,
+	}))
+	assert.Equal(t, want.Hex(), tx.SigningHash)
+	assert.Empty(t, tx.To)
+}
+
+func newTestProvider(t *testing.T, results map[string]string) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string `json:"method"`
+			Params []any  `json:"params"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if req.Method == "eth_getTransactionCount" {
+			assert.Equal(t, "pending", req.Params[1])
+		}
+		result, ok := results[req.Method]
+		if !ok {
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"method not found"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":` + result + `}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestBuild(t *testing.T) {
+	srv := newTestProvider(t, map[string]string{
+		"eth_chainId":              `"0xaa36a7"`,
+		"eth_getTransactionCount":  `"0x2a"`,
+		"eth_getBlockByNumber":     `{"number":"0x10","baseFeePerGas":"0x3b9aca00"}`,
+		"eth_maxPriorityFeePerGas": `"0x77359400"`,
+		"eth_gasPrice":             `"0x4a817c800"`,
+	})
+	to := base.HexToAddress("0x3535353535353535353535353535353535353535")
+	req := Request{From: base.HexToAddress("0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266"), To: &to, Gas: 50000}
+
+	tx, err := Build(srv.URL, req)
+	require.NoError(t, err)
+	assert.Equal(t, FeeEip1559, tx.Type)
+	assert.Equal(t, uint64(11155111), tx.ChainId)
+	assert.Equal(t, uint64(42), tx.Nonce)
+	assert.Equal(t, "0x77359400", tx.MaxPriorityFeePerGas)
+	assert.Equal(t, "0xee6b2800", tx.MaxFeePerGas, "twice the base fee plus the priority fee")
+
+	req.FeeModel = FeeLegacy
+	tx, err = Build(srv.URL, req)
+	require.NoError(t, err)
+	assert.Equal(t, "0x4a817c800", tx.GasPrice)
+
+	req.ChainId = 1
+	_, err = Build(srv.URL, req)
+	assert.ErrorContains(t, err, "not 1")
+
+	// Without a base fee, the chain is legacy
+	legacy := newTestProvider(t, map[string]string{
+		"eth_chainId":             `"0x1"`,
+		"eth_getTransactionCount": `"0x0"`,
+		"eth_getBlockByNumber":    `{"number":"0x10"}`,
+		"eth_gasPrice":            `"0x3b9aca00"`,
+	})
+	req.ChainId, req.FeeModel = 0, FeeAuto
+	tx, err = Build(legacy.URL, req)
+	require.NoError(t, err)
+	assert.Equal(t, FeeLegacy, tx.Type)
+	req.FeeModel = FeeEip1559
+	_, err = Build(legacy.URL, req)
+	assert.ErrorContains(t, err, "does not support EIP-1559")
+}
@@ -0,0 +1,269 @@
+package typeddata
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// Field is one value of a typed-data message, with the fields of a struct or the elements
+// of an array beneath it. An address the names collection knows carries its name.
+type Field struct {
+	Name        string  `json:"name"`
+	Type        string  `json:"type"`
+	Value       string  `json:"value,omitempty"`
+	AddressName string  `json:"addressName,omitempty"`
+	Fields      []Field `json:"fields,omitempty"`
+}
+
+// Inspection is an EIP-712 payload checked against its types, with the hashes a signature
+// of it commits to. SigningHash is the keccak of 0x1901, the domain separator and the
+// struct hash, which is what the signer signs.
+type Inspection struct {
+	PrimaryType     string   `json:"primaryType"`
+	EncodedType     string   `json:"encodedType"`
+	DomainSeparator string   `json:"domainSeparator"`
+	StructHash      string   `json:"structHash"`
+	SigningHash     string   `json:"signingHash"`
+	Domain          []Field  `json:"domain"`
+	Message         []Field  `json:"message"`
+	Warnings        []string `json:"warnings,omitempty"`
+}
+
+// Inspect parses the EIP-712 JSON in data, as passed to eth_signTypedData_v4, and checks
+// every field of its domain and message against the declared types. chainId, if not zero,
+// is the chain the payload is expected to be signed for, and nameOf names addresses.
+func Inspect(data string, chainId uint64, nameOf func(base.Address) string) (*Inspection, error) {
+	decoder := json.NewDecoder(strings.NewReader(data))
+	decoder.UseNumber()
+	var raw struct {
+		Types       apitypes.Types `json:"types"`
+		PrimaryType string         `json:"primaryType"`
+		Domain      map[string]any `json:"domain"`
+		Message     map[string]any `json:"message"`
+	}
+	if err := decoder.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("invalid typed data: %w", err)
+	}
+
+	td := apitypes.TypedData{Types: raw.Types, PrimaryType: raw.PrimaryType}
+	if raw.PrimaryType == "" {
+		return nil, fmt.Errorf("the typed data has no primaryType")
+	}
+	if raw.PrimaryType == "EIP712Domain" {
+		return nil, fmt.Errorf("the primaryType cannot be EIP712Domain")
+	}
+	for _, name := range []string{"EIP712Domain", raw.PrimaryType} {
+		if _, ok := raw.Types[name]; !ok {
+			return nil, fmt.Errorf("the type %s is not declared", name)
+		}
+	}
+	if err := checkTypes(raw.Types); err != nil {
+		return nil, err
+	}
+
+	// Numbers are passed on as strings, which the encoder reads without losing precision
+	domain, _ := numbersToStrings(raw.Domain).(map[string]any)
+	message, _ := numbersToStrings(raw.Message).(map[string]any)
+	if err := checkFields(raw.Types, "EIP712Domain", domain, "domain"); err != nil {
+		return nil, err
+	}
+	if err := checkFields(raw.Types, raw.PrimaryType, message, "message"); err != nil {
+		return nil, err
+	}
+	domainJson, _ := json.Marshal(domain)
+	if err := json.Unmarshal(domainJson, &td.Domain); err != nil {
+		return nil, fmt.Errorf("invalid domain: %w", err)
+	}
+	td.Message = message
+
+	separator, err := td.HashStruct("EIP712Domain", td.Domain.Map())
+	if err != nil {
+		return nil, fmt.Errorf("domain: %w", err)
+	}
+	structHash, err := td.HashStruct(raw.PrimaryType, message)
+	if err != nil {
+		return nil, fmt.Errorf("message: %w", err)
+	}
+	digest := crypto.Keccak256([]byte{0x19, 0x01}, separator, structHash)
+
+	ret := &Inspection{
+		PrimaryType:     raw.PrimaryType,
+		EncodedType:     string(td.EncodeType(raw.PrimaryType)),
+		DomainSeparator: "0x" + hex.EncodeToString(separator),
+		StructHash:      "0x" + hex.EncodeToString(structHash),
+		SigningHash:     "0x" + hex.EncodeToString(digest),
+		Domain:          breakdown(raw.Types, "EIP712Domain", domain, nameOf),
+		Message:         breakdown(raw.Types, raw.PrimaryType, message, nameOf),
+	}
+
+	switch {
+	case td.Domain.ChainId == nil:
+		ret.Warnings = append(ret.Warnings, "the domain has no chainId, so a signature is valid on every chain")
+	case chainId != 0 && (*big.Int)(td.Domain.ChainId).Cmp(new(big.Int).SetUint64(chainId)) != 0:
+		ret.Warnings = append(ret.Warnings, fmt.Sprintf("the domain is for chain %s, not the active chain %d", (*big.Int)(td.Domain.ChainId), chainId))
+	}
+	if td.Domain.VerifyingContract == "" {
+		ret.Warnings = append(ret.Warnings, "the domain has no verifyingContract")
+	}
+	return ret, nil
+}
+
+// numbersToStrings replaces every JSON number in value with its text
+func numbersToStrings(value any) any {
+	switch v := value.(type) {
+	case json.Number:
+		return v.String()
+	case map[string]any:
+		for key, item := range v {
+			v[key] = numbersToStrings(item)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = numbersToStrings(item)
+		}
+	}
+	return value
+}
+
+// typePattern is a type name followed by any number of array suffixes, fixed or dynamic
+var typePattern = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*(\[[0-9]*\])*$`)
+
+// checkTypes reports a declared field whose type is not a name with array suffixes, such
+// as "uint256]" or "Person[2", before anything tries to take it apart
+func checkTypes(types apitypes.Types) error {
+	names := make([]string, 0, len(types))
+	for name := range types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, field := range types[name] {
+			if !typePattern.MatchString(field.Type) {
+				return fmt.Errorf("%s.%s has an invalid type %q", name, field.Name, field.Type)
+			}
+		}
+	}
+	return nil
+}
+
+// checkFields reports a value of data that typeName does not declare, or a struct or array
+// that is not one, at any depth. The encoder itself checks each declared field's value.
+func checkFields(types apitypes.Types, typeName string, data map[string]any, path string) error {
+	declared := make(map[string]string, len(types[typeName]))
+	for _, field := range types[typeName] {
+		declared[field.Name] = field.Type
+	}
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		typ, ok := declared[key]
+		if !ok {
+			return fmt.Errorf("%s.%s is not a field of %s", path, key, typeName)
+		}
+		if err := checkValue(types, typ, data[key], path+"."+key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkValue(types apitypes.Types, typ string, value any, path string) error {
+	if strings.HasSuffix(typ, "]") {
+		items, ok := value.([]any)
+		if !ok {
+			return fmt.Errorf("%s should be an array of %s", path, elementType(typ))
+		}
+		for i, item := range items {
+			if err := checkValue(types, elementType(typ), item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if _, isStruct := types[typ]; isStruct {
+		fields, ok := value.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s should be a %s", path, typ)
+		}
+		return checkFields(types, typ, fields, path)
+	}
+	return nil
+}
+
+// elementType returns the type of the elements of the array type typ, or "" if typ is
+// not an array
+func elementType(typ string) string {
+	i := strings.LastIndex(typ, "[")
+	if i < 0 || !strings.HasSuffix(typ, "]") {
+		return ""
+	}
+	return typ[:i]
+}
+
+// breakdown returns data as fields of typeName, in the order they are declared
+func breakdown(types apitypes.Types, typeName string, data map[string]any, nameOf func(base.Address) string) []Field {
+	ret := []Field{}
+	for _, field := range types[typeName] {
+		value, ok := data[field.Name]
+		if !ok {
+			continue
+		}
+		ret = append(ret, describe(types, field.Name, field.Type, value, nameOf))
+	}
+	return ret
+}
+
+func describe(types apitypes.Types, name, typ string, value any, nameOf func(base.Address) string) Field {
+	ret := Field{Name: name, Type: typ}
+	switch {
+	case strings.HasSuffix(typ, "]"):
+		items, _ := value.([]any)
+		ret.Fields = make([]Field, 0, len(items))
+		for i, item := range items {
+			ret.Fields = append(ret.Fields, describe(types, fmt.Sprintf("[%d]", i), elementType(typ), item, nameOf))
+		}
+	case types[typ] != nil:
+		fields, _ := value.(map[string]any)
+		ret.Fields = breakdown(types, typ, fields, nameOf)
+	case typ == "address":
+		s := fmt.Sprint(value)
+		if base.IsValidAddress(s) {
+			addr := base.HexToAddress(s)
+			ret.Value = addr.Hex()
+			if nameOf != nil {
+				ret.AddressName = nameOf(addr)
+			}
+		} else {
+			ret.Value = s
+		}
+	case strings.HasPrefix(typ, "uint") || strings.HasPrefix(typ, "int"):
+		s := fmt.Sprint(value)
+		ret.Value = s
+		if n, ok := parseInteger(s); ok {
+			ret.Value = n.String()
+		}
+	default:
+		ret.Value = fmt.Sprint(value)
+	}
+	return ret
+}
+
+// parseInteger reads an integer in decimal or, with a 0x prefix, hex
+func parseInteger(s string) (*big.Int, bool) {
+	if rest, ok := strings.CutPrefix(s, "0x"); ok {
+		return new(big.Int).SetString(rest, 16)
+	}
+	return new(big.Int).SetString(s, 10)
+}
@@ -0,0 +1,147 @@
+package typeddata
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mail is the example from EIP-712 itself
+const mail = `{
+	"types": {
+		"EIP712Domain": [
+			{"name": "name", "type": "string"},
+			{"name": "version", "type": "string"},
+			{"name": "chainId", "type": "uint256"},
+			{"name": "verifyingContract", "type": "address"}
+		],
+		"Person": [
+			{"name": "name", "type": "string"},
+			{"name": "wallet", "type": "address"}
+		],
+		"Mail": [
+			{"name": "from", "type": "Person"},
+			{"name": "to", "type": "Person"},
+			{"name": "contents", "type": "string"}
+		]
+	},
+	"primaryType": "Mail",
+	"domain": {
+		"name": "Ether Mail",
+		"version": "1",
+		"chainId": 1,
+		"verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+	},
+	"message": {
+		"from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+		"to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+		"contents": "Hello, Bob!"
+	}
+}`
+
+func TestInspectMail(t *testing.T) {
+	bob := base.HexToAddress("0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB")
+	nameOf := func(addr base.Address) string {
+		if addr == bob {
+			return "Bob's wallet"
+		}
+		return ""
+	}
+
+	got, err := Inspect(mail, 1, nameOf)
+	require.NoError(t, err)
+	assert.Equal(t, "0xf2cee375fa42b42143804025fc449deafd50cc031ca257e0b194a650a912090f", got.DomainSeparator)
+	assert.Equal(t, "0xc52c0ee5d84264471806290a3f2c4cecfc5490626bf912d01f240d7a274b371e", got.StructHash)
+	assert.Equal(t, "0xbe609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2", got.SigningHash)
+	assert.Equal(t, "Mail(Person from,Person to,string contents)Person(string name,address wallet)", got.EncodedType)
+	assert.Empty(t, got.Warnings)
+
+	require.Len(t, got.Message, 3)
+	to := got.Message[1]
+	assert.Equal(t, "to", to.Name)
+	assert.Equal(t, "Person", to.Type)
+	require.Len(t, to.Fields, 2)
+	assert.Equal(t, "0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb", to.Fields[1].Value)
+	assert.Equal(t, "Bob's wallet", to.Fields[1].AddressName)
+	assert.Equal(t, "1", got.Domain[2].Value)
+
+	got, err = Inspect(mail, 11155111, nameOf)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"the domain is for chain 1, not the active chain 11155111"}, got.Warnings)
+}
+
+func TestInspectPermit(t *testing.T) {
+	// A uint256 too large for a float keeps its value, and arrays are broken down by element
+	permit := `{
+		"types": {
+			"EIP712Domain": [{"name": "name", "type": "string"}, {"name": "chainId", "type": "uint256"}],
+			"Permit": [
+				{"name": "spenders", "type": "address[]"},
+				{"name": "value", "type": "uint256"},
+				{"name": "deadline", "type": "uint256"}
+			]
+		},
+		"primaryType": "Permit",
+		"domain": {"name": "Token", "chainId": "0x1"},
+		"message": {
+			"spenders": ["0x3535353535353535353535353535353535353535", "0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266"],
+			"value": 115792089237316195423570985008687907853269984665640564039457584007913129639935,
+			"deadline": "0x6553f100"
+		}
+	}`
+	got, err := Inspect(permit, 1, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"the domain has no verifyingContract"}, got.Warnings)
+	require.Len(t, got.Message, 3)
+	assert.Len(t, got.Message[0].Fields, 2)
+	assert.Equal(t, "[1]", got.Message[0].Fields[1].Name)
+	assert.Equal(t, "address", got.Message[0].Fields[1].Type)
+	assert.Equal(t, "115792089237316195423570985008687907853269984665640564039457584007913129639935", got.Message[1].Value)
+	assert.Equal(t, "1700000000", got.Message[2].Value)
+}
+
+func TestInspectInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		edit    func(string) string
+		wantErr string
+	}{
+		{"not json", func(s string) string { return s[:20] }, "invalid typed data"},
+		{"undeclared primary type", func(s string) string {
+			return strings.Replace(s, `"primaryType": "Mail"`, `"primaryType": "Letter"`, 1)
+		}, "the type Letter is not declared"},
+		{"undeclared field", func(s string) string {
+			return strings.Replace(s, `"contents": "Hello, Bob!"`, `"contents": "Hello, Bob!", "cc": "Alice"`, 1)
+		}, "message.cc is not a field of Mail"},
+		{"nested undeclared field", func(s string) string {
+			return strings.Replace(s, `{"name": "Bob",`, `{"name": "Bob", "email": "bob@example.com",`, 1)
+		}, "message.to.email is not a field of Person"},
+		{"struct of the wrong shape", func(s string) string {
+			return strings.Replace(s, `"to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"}`, `"to": "Bob"`, 1)
+		}, "message.to should be a Person"},
+		{"bad address", func(s string) string {
+			return strings.Replace(s, "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826", "0x1234", 1)
+		}, "message: provided data '0x1234' doesn't match type 'address'"},
+		{"missing field", func(s string) string {
+			return strings.Replace(s, `"contents": "Hello, Bob!"`, `"contents": null`, 1)
+		}, "message:"},
+		{"unopened array type", func(s string) string {
+			return strings.Replace(s, `{"name": "contents", "type": "string"}`, `{"name": "contents", "type": "uint256]"}`, 1)
+		}, `Mail.contents has an invalid type "uint256]"`},
+		{"unclosed array type", func(s string) string {
+			return strings.Replace(s, `{"name": "contents", "type": "string"}`, `{"name": "contents", "type": "Person[2"}`, 1)
+		}, `Mail.contents has an invalid type "Person[2"`},
+		{"bad array length", func(s string) string {
+			return strings.Replace(s, `{"name": "contents", "type": "string"}`, `{"name": "contents", "type": "string[x]"}`, 1)
+		}, `invalid type "string[x]"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Inspect(tt.edit(mail), 1, nil)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
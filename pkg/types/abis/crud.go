@@ -25,7 +25,7 @@ func (c *AbisCollection) Crud(
 	var err error
 	switch op {
 	case crud.Autoname:
-		if err = names.AutonameAddress(abi.Address.Hex()); err != nil {
+		if err = names.AutonameAddress(payload.ActiveChain, abi.Address.Hex()); err != nil {
 			msgs.EmitError("Abis.Crud.Autoname", err)
 			return err
 		}
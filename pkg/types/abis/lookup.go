@@ -0,0 +1,64 @@
+package abis
+
+import (
+	"os"
+
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types/names"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/abi"
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// GetAbi returns the ABI of addr on chain, downloading it first if it is not in the cache
+func GetAbi(chain string, addr base.Address) (*Abi, error) {
+	opts := sdk.AbisOptions{
+		Addrs:   []string{addr.Hex()},
+		Globals: sdk.Globals{Cache: true, Chain: chain},
+	}
+	functions, _, err := opts.Abis()
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &Abi{
+		Address:     addr,
+		AddressName: names.NameAddress(addr),
+		Functions:   functions,
+		IsEmpty:     len(functions) == 0,
+	}
+	for _, fn := range functions {
+		switch fn.FunctionType {
+		case "event":
+			ret.NEvents++
+		case "function":
+			ret.NFunctions++
+		}
+	}
+	return ret, nil
+}
+
+// GetErrors returns the custom errors declared in the ABI of addr on chain. The SDK leaves
+// them out of its functions, so they are read from the cached ABI file, which GetAbi
+// downloads if need be.
+func GetErrors(chain string, addr base.Address) ([]ethabi.Error, error) {
+	if _, err := GetAbi(chain, addr); err != nil {
+		return nil, err
+	}
+	f, err := os.Open(abi.PathToAbisCache(chain, addr.Hex()))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	parsed, err := ethabi.JSON(f)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]ethabi.Error, 0, len(parsed.Errors))
+	for _, e := range parsed.Errors {
+		ret = append(ret, e)
+	}
+	return ret, nil
+}
@@ -13,6 +13,8 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/query"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/sorting"
 	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
 	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
 )
@@ -69,14 +71,15 @@ func (c *AbisCollection) GetPage(
 		facet := c.downloadedFacet
 		var filterFunc func(*Abi) bool
 		if filter != "" {
-			filterFunc = func(item *Abi) bool {
-				return c.matchesDownloadedFilter(item, filter)
+			var err error
+			if filterFunc, err = query.NewFilter(filter, query.FacetFields(c, dataFacet), c.matchesDownloadedFilter); err != nil {
+				return nil, types.NewValidationError("abis", dataFacet, "GetPage", err)
 			}
 		}
 		sortFunc := func(items []Abi, sort sdk.SortSpec) error {
-			return sdk.SortAbis(items, sort)
+			return sorting.SortBy(items, sort)
 		}
-		if result, err := facet.GetPage(first, pageSize, filterFunc, sortSpec, sortFunc); err != nil {
+		if result, err := facet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
 			return nil, types.NewStoreError("abis", dataFacet, "GetPage", err)
 		} else {
 			page.Abis = result.Items
@@ -88,14 +91,15 @@ func (c *AbisCollection) GetPage(
 		facet := c.knownFacet
 		var filterFunc func(*Abi) bool
 		if filter != "" {
-			filterFunc = func(item *Abi) bool {
-				return c.matchesKnownFilter(item, filter)
+			var err error
+			if filterFunc, err = query.NewFilter(filter, query.FacetFields(c, dataFacet), c.matchesKnownFilter); err != nil {
+				return nil, types.NewValidationError("abis", dataFacet, "GetPage", err)
 			}
 		}
 		sortFunc := func(items []Abi, sort sdk.SortSpec) error {
-			return sdk.SortAbis(items, sort)
+			return sorting.SortBy(items, sort)
 		}
-		if result, err := facet.GetPage(first, pageSize, filterFunc, sortSpec, sortFunc); err != nil {
+		if result, err := facet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
 			return nil, types.NewStoreError("abis", dataFacet, "GetPage", err)
 		} else {
 			page.Abis = result.Items
@@ -107,14 +111,15 @@ func (c *AbisCollection) GetPage(
 		facet := c.functionsFacet
 		var filterFunc func(*Function) bool
 		if filter != "" {
-			filterFunc = func(item *Function) bool {
-				return c.matchesFunctionFilter(item, filter)
+			var err error
+			if filterFunc, err = query.NewFilter(filter, query.FacetFields(c, dataFacet), c.matchesFunctionFilter); err != nil {
+				return nil, types.NewValidationError("abis", dataFacet, "GetPage", err)
 			}
 		}
 		sortFunc := func(items []Function, sort sdk.SortSpec) error {
-			return sdk.SortFunctions(items, sort)
+			return sorting.SortBy(items, sort)
 		}
-		if result, err := facet.GetPage(first, pageSize, filterFunc, sortSpec, sortFunc); err != nil {
+		if result, err := facet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
 			return nil, types.NewStoreError("abis", dataFacet, "GetPage", err)
 		} else {
 			page.Functions = result.Items
@@ -126,14 +131,15 @@ func (c *AbisCollection) GetPage(
 		facet := c.eventsFacet
 		var filterFunc func(*Function) bool
 		if filter != "" {
-			filterFunc = func(item *Function) bool {
-				return c.matchesEventFilter(item, filter)
+			var err error
+			if filterFunc, err = query.NewFilter(filter, query.FacetFields(c, dataFacet), c.matchesEventFilter); err != nil {
+				return nil, types.NewValidationError("abis", dataFacet, "GetPage", err)
 			}
 		}
 		sortFunc := func(items []Function, sort sdk.SortSpec) error {
-			return sdk.SortFunctions(items, sort)
+			return sorting.SortBy(items, sort)
 		}
-		if result, err := facet.GetPage(first, pageSize, filterFunc, sortSpec, sortFunc); err != nil {
+		if result, err := facet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
 			return nil, types.NewStoreError("abis", dataFacet, "GetPage", err)
 		} else {
 			page.Functions = result.Items
@@ -22,6 +22,8 @@ func (c *ChunksCollection) GetBuckets(payload *types.Payload) (*types.Buckets, e
 		facet = c.bloomsFacet
 	case ChunksManifest:
 		facet = c.manifestFacet
+	case ChunksIntegrity:
+		facet = c.integrityFacet
 	default:
 		return &types.Buckets{
 			Series:   make(map[string][]types.Bucket),
@@ -31,6 +33,9 @@ func (c *ChunksCollection) GetBuckets(payload *types.Payload) (*types.Buckets, e
 
 	buckets := facet.GetBuckets()
 	// EXISTING_CODE
+	if payload.DataFacet == ChunksBlooms {
+		buckets = withProbeSeries(buckets, payload.ActiveChain)
+	}
 	// EXISTING_CODE
 	return buckets, nil
 }
@@ -21,10 +21,11 @@ import (
 )
 
 const (
-	ChunksStats    types.DataFacet = "stats"
-	ChunksIndex    types.DataFacet = "index"
-	ChunksBlooms   types.DataFacet = "blooms"
-	ChunksManifest types.DataFacet = "manifest"
+	ChunksStats     types.DataFacet = "stats"
+	ChunksIndex     types.DataFacet = "index"
+	ChunksBlooms    types.DataFacet = "blooms"
+	ChunksManifest  types.DataFacet = "manifest"
+	ChunksIntegrity types.DataFacet = "integrity"
 )
 
 func init() {
@@ -32,15 +33,17 @@ func init() {
 	types.RegisterDataFacet(ChunksIndex)
 	types.RegisterDataFacet(ChunksBlooms)
 	types.RegisterDataFacet(ChunksManifest)
+	types.RegisterDataFacet(ChunksIntegrity)
 }
 
 type ChunksCollection struct {
-	statsFacet    *facets.Facet[Stats]
-	indexFacet    *facets.Facet[Index]
-	bloomsFacet   *facets.Facet[Bloom]
-	manifestFacet *facets.Facet[Manifest]
-	summary       types.Summary
-	summaryMutex  sync.RWMutex
+	statsFacet     *facets.Facet[Stats]
+	indexFacet     *facets.Facet[Index]
+	bloomsFacet    *facets.Facet[Bloom]
+	manifestFacet  *facets.Facet[Manifest]
+	integrityFacet *facets.Facet[Integrity]
+	summary        types.Summary
+	summaryMutex   sync.RWMutex
 }
 
 func NewChunksCollection(payload *types.Payload) *ChunksCollection {
@@ -90,6 +93,16 @@ func (c *ChunksCollection) initializeFacets(payload *types.Payload) {
 		c,
 		false,
 	)
+
+	c.integrityFacet = facets.NewFacet(
+		ChunksIntegrity,
+		isIntegrity,
+		isDupIntegrity(),
+		c.getIntegrityStore(payload, ChunksIntegrity),
+		"chunks",
+		c,
+		false,
+	)
 }
 
 func isStats(item *Stats) bool {
@@ -116,6 +129,12 @@ func isManifest(item *Manifest) bool {
 	// EXISTING_CODE
 }
 
+func isIntegrity(item *Integrity) bool {
+	// EXISTING_CODE
+	return true
+	// EXISTING_CODE
+}
+
 func isDupBloom() func(existing []*Bloom, newItem *Bloom) bool {
 	// EXISTING_CODE
 	return nil
@@ -128,6 +147,12 @@ func isDupIndex() func(existing []*Index, newItem *Index) bool {
 	// EXISTING_CODE
 }
 
+func isDupIntegrity() func(existing []*Integrity, newItem *Integrity) bool {
+	// EXISTING_CODE
+	return nil
+	// EXISTING_CODE
+}
+
 func isDupManifest() func(existing []*Manifest, newItem *Manifest) bool {
 	// EXISTING_CODE
 	return nil
@@ -164,6 +189,10 @@ func (c *ChunksCollection) FetchByFacet(payload *types.Payload) {
 			if err := c.manifestFacet.FetchFacet(); err != nil {
 				logging.LogError(fmt.Sprintf("LoadData.%s from store: %%v", dataFacet), err, facets.ErrAlreadyLoading)
 			}
+		case ChunksIntegrity:
+			if err := c.integrityFacet.FetchFacet(); err != nil {
+				logging.LogError(fmt.Sprintf("LoadData.%s from store: %%v", dataFacet), err, facets.ErrAlreadyLoading)
+			}
 		default:
 			logging.LogError("LoadData: unexpected dataFacet: %v", fmt.Errorf("invalid dataFacet: %s", dataFacet), nil)
 			return
@@ -181,6 +210,8 @@ func (c *ChunksCollection) Reset(payload *types.Payload) {
 		c.bloomsFacet.Reset()
 	case ChunksManifest:
 		c.manifestFacet.Reset()
+	case ChunksIntegrity:
+		c.integrityFacet.Reset()
 	default:
 		return
 	}
@@ -196,6 +227,8 @@ func (c *ChunksCollection) NeedsUpdate(payload *types.Payload) bool {
 		return c.bloomsFacet.NeedsUpdate()
 	case ChunksManifest:
 		return c.manifestFacet.NeedsUpdate()
+	case ChunksIntegrity:
+		return c.integrityFacet.NeedsUpdate()
 	default:
 		return false
 	}
@@ -257,6 +290,19 @@ func (c *ChunksCollection) AccumulateItem(item interface{}, summary *types.Summa
 		manifestCount, _ := summary.CustomData["manifestCount"].(int)
 		manifestCount++
 		summary.CustomData["manifestCount"] = manifestCount
+
+	case *Integrity:
+		summary.TotalCount++
+		summary.FacetCounts[ChunksIntegrity]++
+		if summary.CustomData == nil {
+			summary.CustomData = make(map[string]interface{})
+		}
+
+		if it := item.(*Integrity); it.Status != IntegrityOk {
+			failedCount, _ := summary.CustomData["integrityFailures"].(int)
+			failedCount++
+			summary.CustomData["integrityFailures"] = failedCount
+		}
 	}
 	// EXISTING_CODE
 }
@@ -303,6 +349,8 @@ func (c *ChunksCollection) ExportData(payload *types.Payload) (string, error) {
 		return c.bloomsFacet.ExportData(payload, string(ChunksBlooms))
 	case ChunksManifest:
 		return c.manifestFacet.ExportData(payload, string(ChunksManifest))
+	case ChunksIntegrity:
+		return c.integrityFacet.ExportData(payload, string(ChunksIntegrity))
 	default:
 		return "", fmt.Errorf("[ExportData] unsupported chunks facet: %s", payload.DataFacet)
 	}
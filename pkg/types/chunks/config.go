@@ -22,6 +22,8 @@ func (c *ChunksCollection) GetConfig() (*types.ViewConfig, error) {
 		Actions:    c.buildActions(),
 	}
 
+	c.addDynamicFields(cfg)
+
 	types.DeriveFacets(cfg)
 	types.SortFields(cfg)
 	types.SetMenuOrder(cfg)
@@ -72,6 +74,15 @@ func (c *ChunksCollection) buildStaticFacets() map[string]types.FacetConfig {
 			Actions:       []string{},
 			HeaderActions: []string{},
 		},
+		"integrity": {
+			Name:          "Integrity",
+			Store:         "integrity",
+			ViewType:      "table",
+			DividerBefore: false,
+			Fields:        getIntegrityFields(),
+			Actions:       []string{},
+			HeaderActions: []string{"export"},
+		},
 	}
 }
 
@@ -81,6 +92,7 @@ func (c *ChunksCollection) buildFacetOrder() []string {
 		"index",
 		"blooms",
 		"manifest",
+		"integrity",
 	}
 }
 
@@ -119,6 +131,26 @@ func getIndexFields() []types.FieldConfig {
 	return ret
 }
 
+func getIntegrityFields() []types.FieldConfig {
+	ret := []types.FieldConfig{
+		{Section: "Range", Key: "range", Type: "blkrange"},
+		{Section: "Status", Key: "status", Type: "string"},
+		{Section: "Status", Key: "indexStatus", Type: "string"},
+		{Section: "Status", Key: "bloomStatus", Type: "string"},
+		{Section: "Status", Key: "error", Type: "string", NoTable: true},
+		{Section: "Index", Key: "indexHash", Type: "ipfsHash", NoTable: true},
+		{Section: "Index", Key: "localIndexHash", Type: "ipfsHash", NoTable: true},
+		{Section: "Index", Key: "indexSize", Type: "fileSize"},
+		{Section: "Index", Key: "localIndexSize", Type: "fileSize"},
+		{Section: "Bloom", Key: "bloomHash", Type: "ipfsHash", NoTable: true},
+		{Section: "Bloom", Key: "localBloomHash", Type: "ipfsHash", NoTable: true},
+		{Section: "Bloom", Key: "bloomSize", Type: "fileSize"},
+		{Section: "Bloom", Key: "localBloomSize", Type: "fileSize"},
+	}
+	types.NormalizeFields(&ret)
+	return ret
+}
+
 func getManifestFields() []types.FieldConfig {
 	ret := []types.FieldConfig{
 		{Section: "Manifest", Key: "version", Type: "string"},
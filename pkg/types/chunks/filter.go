@@ -87,3 +87,16 @@ func (c *ChunksCollection) matchesManifestFilter(manifest *Manifest, filter stri
 
 	return false
 }
+
+func (c *ChunksCollection) matchesIntegrityFilter(integrity *Integrity, filter string) bool {
+	filterLower := strings.ToLower(filter)
+
+	// Filter by range and by any of the statuses
+	for _, s := range []string{integrity.Range, integrity.Status, integrity.IndexStatus, integrity.BloomStatus} {
+		if strings.Contains(strings.ToLower(s), filterLower) {
+			return true
+		}
+	}
+
+	return false
+}
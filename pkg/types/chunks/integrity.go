@@ -0,0 +1,239 @@
+package chunks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/cid"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/output"
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+)
+
+// Statuses of a chunk file, and of a range as a whole, from best to worst
+const (
+	IntegrityOk      = "ok"
+	IntegrityExtra   = "extra"
+	IntegrityMissing = "missing"
+	IntegrityCorrupt = "corrupt"
+)
+
+var integrityRank = map[string]int{
+	"":               0,
+	IntegrityOk:      1,
+	IntegrityExtra:   2,
+	IntegrityMissing: 3,
+	IntegrityCorrupt: 4,
+}
+
+// Integrity is one row of the integrity facet: how the local index and bloom files of a
+// range compare with the manifest. A file is missing if it is not on disc, corrupt if
+// its size or hash differs, and extra if the manifest has no such range. Status is the
+// worse of the two. Local hashes are empty when a file's size already shows it corrupt.
+type Integrity struct {
+	Range          string `json:"range"`
+	Status         string `json:"status"`
+	IndexStatus    string `json:"indexStatus"`
+	BloomStatus    string `json:"bloomStatus"`
+	IndexHash      string `json:"indexHash"`
+	LocalIndexHash string `json:"localIndexHash"`
+	IndexSize      int64  `json:"indexSize"`
+	LocalIndexSize int64  `json:"localIndexSize"`
+	BloomHash      string `json:"bloomHash"`
+	LocalBloomHash string `json:"localBloomHash"`
+	BloomSize      int64  `json:"bloomSize"`
+	LocalBloomSize int64  `json:"localBloomSize"`
+	Error          string `json:"error,omitempty"`
+}
+
+// Model lets the row be streamed and exported like the SDK's types
+func (i *Integrity) Model(chain, format string, verbose bool, extraOpts map[string]any) sdk.Model {
+	return sdk.Model{
+		Data: map[string]any{
+			"range":          i.Range,
+			"status":         i.Status,
+			"indexStatus":    i.IndexStatus,
+			"bloomStatus":    i.BloomStatus,
+			"indexHash":      i.IndexHash,
+			"localIndexHash": i.LocalIndexHash,
+			"indexSize":      i.IndexSize,
+			"localIndexSize": i.LocalIndexSize,
+			"bloomHash":      i.BloomHash,
+			"localBloomHash": i.LocalBloomHash,
+			"bloomSize":      i.BloomSize,
+			"localBloomSize": i.LocalBloomSize,
+			"error":          i.Error,
+		},
+		Order: []string{
+			"range", "status", "indexStatus", "bloomStatus",
+			"indexHash", "localIndexHash", "indexSize", "localIndexSize",
+			"bloomHash", "localBloomHash", "bloomSize", "localBloomSize", "error",
+		},
+	}
+}
+
+// verifyChunks compares the chunk and bloom files under indexPath with the manifest's
+// chunks and sends one row per range to emit, manifest ranges first and then any extra
+// ones, each in order. It stops early if ctx is canceled.
+func verifyChunks(ctx context.Context, indexPath string, chunks []sdk.ChunkRecord, hash func(path string) (string, error), emit func(*Integrity)) error {
+	sorted := append([]sdk.ChunkRecord(nil), chunks...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Range < sorted[j].Range })
+
+	known := make(map[string]bool, len(sorted))
+	for _, chunk := range sorted {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		known[chunk.Range] = true
+
+		row := &Integrity{
+			Range:     chunk.Range,
+			IndexHash: chunk.IndexHash.String(),
+			IndexSize: chunk.IndexSize,
+			BloomHash: chunk.BloomHash.String(),
+			BloomSize: chunk.BloomSize,
+		}
+		var indexErr, bloomErr error
+		row.IndexStatus, row.LocalIndexHash, row.LocalIndexSize, indexErr = checkFile(indexFile(indexPath, chunk.Range), row.IndexHash, row.IndexSize, hash)
+		row.BloomStatus, row.LocalBloomHash, row.LocalBloomSize, bloomErr = checkFile(bloomFile(indexPath, chunk.Range), row.BloomHash, row.BloomSize, hash)
+		if err := errors.Join(indexErr, bloomErr); err != nil {
+			row.Error = err.Error()
+		}
+		row.Status = worse(row.IndexStatus, row.BloomStatus)
+		emit(row)
+	}
+
+	extras := make(map[string]*Integrity)
+	extra := func(rng string) *Integrity {
+		if extras[rng] == nil {
+			extras[rng] = &Integrity{Range: rng, Status: IntegrityExtra}
+		}
+		return extras[rng]
+	}
+	for _, rng := range localRanges(filepath.Join(indexPath, "finalized"), ".bin") {
+		if !known[rng] {
+			row := extra(rng)
+			row.IndexStatus = IntegrityExtra
+			row.LocalIndexSize = fileSize(indexFile(indexPath, rng))
+		}
+	}
+	for _, rng := range localRanges(filepath.Join(indexPath, "blooms"), ".bloom") {
+		if !known[rng] {
+			row := extra(rng)
+			row.BloomStatus = IntegrityExtra
+			row.LocalBloomSize = fileSize(bloomFile(indexPath, rng))
+		}
+	}
+
+	ranges := make([]string, 0, len(extras))
+	for rng := range extras {
+		ranges = append(ranges, rng)
+	}
+	sort.Strings(ranges)
+	for _, rng := range ranges {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		emit(extras[rng])
+	}
+	return nil
+}
+
+// checkFile compares the file at path with the size and hash the manifest gives it. The
+// file is only hashed if its size matches; an unknown hash or size is not checked.
+func checkFile(path, wantHash string, wantSize int64, hash func(string) (string, error)) (status, localHash string, localSize int64, err error) {
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return IntegrityMissing, "", 0, nil
+	}
+	if err != nil {
+		return IntegrityCorrupt, "", 0, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	localSize = info.Size()
+	if wantSize > 0 && localSize != wantSize {
+		return IntegrityCorrupt, "", localSize, nil
+	}
+	if wantHash == "" {
+		return IntegrityOk, "", localSize, nil
+	}
+	if localHash, err = hash(path); err != nil {
+		return IntegrityCorrupt, "", localSize, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	if localHash != wantHash {
+		return IntegrityCorrupt, localHash, localSize, nil
+	}
+	return IntegrityOk, localHash, localSize, nil
+}
+
+// localRanges returns the ranges of the files in dir with the given extension
+func localRanges(dir, ext string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	ret := []string{}
+	for _, entry := range entries {
+		if name := entry.Name(); !entry.IsDir() && strings.HasSuffix(name, ext) {
+			ret = append(ret, strings.TrimSuffix(name, ext))
+		}
+	}
+	return ret
+}
+
+func indexFile(indexPath, rng string) string {
+	return filepath.Join(indexPath, "finalized", rng+".bin")
+}
+
+func bloomFile(indexPath, rng string) string {
+	return filepath.Join(indexPath, "blooms", rng+".bloom")
+}
+
+func fileSize(path string) int64 {
+	if info, err := os.Stat(path); err == nil {
+		return info.Size()
+	}
+	return 0
+}
+
+func worse(a, b string) string {
+	if integrityRank[b] > integrityRank[a] {
+		return b
+	}
+	return a
+}
+
+// streamIntegrity verifies the local index of chain against its manifest, streaming the
+// rows into the store as each range is checked
+func streamIntegrity(renderCtx *output.RenderCtx, chain, indexPath string) error {
+	ctx := renderCtx.Ctx
+	opts := sdk.ChunksOptions{
+		Globals: sdk.Globals{
+			Verbose: true,
+			Chain:   chain,
+		},
+	}
+	manifests, _, err := opts.ChunksManifest()
+	if err != nil {
+		return err
+	}
+	chunks := []sdk.ChunkRecord{}
+	for _, m := range manifests {
+		chunks = append(chunks, m.Chunks...)
+	}
+
+	err = verifyChunks(ctx, indexPath, chunks, cid.File, func(row *Integrity) {
+		select {
+		case renderCtx.ModelChan <- row:
+		case <-ctx.Done():
+		}
+	})
+	if errors.Is(err, context.Canceled) {
+		return nil
+	}
+	return err
+}
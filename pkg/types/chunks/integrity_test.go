@@ -0,0 +1,117 @@
+package chunks
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+)
+
+// fakeHash stands in for a CID: the file's contents prefixed with "h-"
+func fakeHash(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return "h-" + string(data), nil
+}
+
+func writeChunk(t *testing.T, indexPath, rng, index, bloom string) {
+	t.Helper()
+	if index != "" {
+		if err := os.WriteFile(indexFile(indexPath, rng), []byte(index), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if bloom != "" {
+		if err := os.WriteFile(bloomFile(indexPath, rng), []byte(bloom), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func record(rng, index, bloom string) sdk.ChunkRecord {
+	return sdk.ChunkRecord{
+		Range:     rng,
+		IndexHash: base.IpfsHash("h-" + index),
+		IndexSize: int64(len(index)),
+		BloomHash: base.IpfsHash("h-" + bloom),
+		BloomSize: int64(len(bloom)),
+	}
+}
+
+func TestVerifyChunks(t *testing.T) {
+	indexPath := t.TempDir()
+	for _, dir := range []string{"finalized", "blooms"} {
+		if err := os.MkdirAll(filepath.Join(indexPath, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeChunk(t, indexPath, "000000000-000000010", "index-a", "bloom-a")
+	writeChunk(t, indexPath, "000000011-000000020", "index-b", "")
+	writeChunk(t, indexPath, "000000021-000000030", "index-X", "bloom-c")
+	writeChunk(t, indexPath, "000000031-000000040", "index-d-longer", "bloom-d")
+	writeChunk(t, indexPath, "000000041-000000050", "", "bloom-e")
+
+	chunks := []sdk.ChunkRecord{
+		record("000000031-000000040", "index-d", "bloom-d"),
+		record("000000000-000000010", "index-a", "bloom-a"),
+		record("000000011-000000020", "index-b", "bloom-b"),
+		record("000000021-000000030", "index-c", "bloom-c"),
+	}
+
+	rows := []*Integrity{}
+	err := verifyChunks(context.Background(), indexPath, chunks, fakeHash, func(row *Integrity) {
+		rows = append(rows, row)
+	})
+	if err != nil {
+		t.Fatalf("verifyChunks: %v", err)
+	}
+
+	expected := []struct {
+		rng, status, index, bloom string
+	}{
+		{"000000000-000000010", IntegrityOk, IntegrityOk, IntegrityOk},
+		{"000000011-000000020", IntegrityMissing, IntegrityOk, IntegrityMissing},
+		{"000000021-000000030", IntegrityCorrupt, IntegrityCorrupt, IntegrityOk},
+		{"000000031-000000040", IntegrityCorrupt, IntegrityCorrupt, IntegrityOk},
+		{"000000041-000000050", IntegrityExtra, "", IntegrityExtra},
+	}
+	if len(rows) != len(expected) {
+		t.Fatalf("Expected %d rows, got %d", len(expected), len(rows))
+	}
+	for i, want := range expected {
+		got := rows[i]
+		if got.Range != want.rng || got.Status != want.status || got.IndexStatus != want.index || got.BloomStatus != want.bloom {
+			t.Errorf("Row %d: expected %v, got %s %s %s %s", i, want, got.Range, got.Status, got.IndexStatus, got.BloomStatus)
+		}
+	}
+
+	// A same-sized file with different contents is hashed; a wrongly sized one is not
+	if rows[2].LocalIndexHash != "h-index-X" {
+		t.Errorf("Expected the local hash of a corrupt file, got %q", rows[2].LocalIndexHash)
+	}
+	if rows[3].LocalIndexHash != "" || rows[3].LocalIndexSize != int64(len("index-d-longer")) {
+		t.Errorf("Expected only the size of a wrongly sized file, got %q %d", rows[3].LocalIndexHash, rows[3].LocalIndexSize)
+	}
+}
+
+func TestVerifyChunksCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	emitted := 0
+	err := verifyChunks(ctx, t.TempDir(), []sdk.ChunkRecord{record("000000000-000000010", "a", "b")}, fakeHash, func(*Integrity) {
+		emitted++
+	})
+	if err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if emitted != 0 {
+		t.Errorf("Expected no rows after cancel, got %d", emitted)
+	}
+}
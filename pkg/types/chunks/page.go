@@ -13,6 +13,8 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/query"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/sorting"
 	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
 	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
 )
@@ -23,6 +25,7 @@ type ChunksPage struct {
 	Facet         types.DataFacet  `json:"facet"`
 	Blooms        []Bloom          `json:"blooms"`
 	Index         []Index          `json:"index"`
+	Integrity     []Integrity      `json:"integrity"`
 	Manifest      []Manifest       `json:"manifest"`
 	Stats         []Stats          `json:"stats"`
 	TotalItems    int              `json:"totalItems"`
@@ -71,14 +74,15 @@ func (c *ChunksCollection) GetPage(
 		facet := c.statsFacet
 		var filterFunc func(*Stats) bool
 		if filter != "" {
-			filterFunc = func(item *Stats) bool {
-				return c.matchesStatsFilter(item, filter)
+			var err error
+			if filterFunc, err = query.NewFilter(filter, query.FacetFields(c, dataFacet), c.matchesStatsFilter); err != nil {
+				return nil, types.NewValidationError("chunks", dataFacet, "GetPage", err)
 			}
 		}
 		sortFunc := func(items []Stats, sort sdk.SortSpec) error {
-			return sdk.SortStats(items, sort)
+			return sorting.SortBy(items, sort)
 		}
-		if result, err := facet.GetPage(first, pageSize, filterFunc, sortSpec, sortFunc); err != nil {
+		if result, err := facet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
 			return nil, types.NewStoreError("chunks", dataFacet, "GetPage", err)
 		} else {
 			page.Stats = result.Items
@@ -90,14 +94,15 @@ func (c *ChunksCollection) GetPage(
 		facet := c.indexFacet
 		var filterFunc func(*Index) bool
 		if filter != "" {
-			filterFunc = func(item *Index) bool {
-				return c.matchesIndexFilter(item, filter)
+			var err error
+			if filterFunc, err = query.NewFilter(filter, query.FacetFields(c, dataFacet), c.matchesIndexFilter); err != nil {
+				return nil, types.NewValidationError("chunks", dataFacet, "GetPage", err)
 			}
 		}
 		sortFunc := func(items []Index, sort sdk.SortSpec) error {
-			return sdk.SortIndex(items, sort)
+			return sorting.SortBy(items, sort)
 		}
-		if result, err := facet.GetPage(first, pageSize, filterFunc, sortSpec, sortFunc); err != nil {
+		if result, err := facet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
 			return nil, types.NewStoreError("chunks", dataFacet, "GetPage", err)
 		} else {
 			page.Index = result.Items
@@ -109,14 +114,15 @@ func (c *ChunksCollection) GetPage(
 		facet := c.bloomsFacet
 		var filterFunc func(*Bloom) bool
 		if filter != "" {
-			filterFunc = func(item *Bloom) bool {
-				return c.matchesBloomFilter(item, filter)
+			var err error
+			if filterFunc, err = query.NewFilter(filter, query.FacetFields(c, dataFacet), c.matchesBloomFilter); err != nil {
+				return nil, types.NewValidationError("chunks", dataFacet, "GetPage", err)
 			}
 		}
 		sortFunc := func(items []Bloom, sort sdk.SortSpec) error {
-			return sdk.SortBlooms(items, sort)
+			return sorting.SortBy(items, sort)
 		}
-		if result, err := facet.GetPage(first, pageSize, filterFunc, sortSpec, sortFunc); err != nil {
+		if result, err := facet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
 			return nil, types.NewStoreError("chunks", dataFacet, "GetPage", err)
 		} else {
 			page.Blooms = result.Items
@@ -128,14 +134,15 @@ func (c *ChunksCollection) GetPage(
 		facet := c.manifestFacet
 		var filterFunc func(*Manifest) bool
 		if filter != "" {
-			filterFunc = func(item *Manifest) bool {
-				return c.matchesManifestFilter(item, filter)
+			var err error
+			if filterFunc, err = query.NewFilter(filter, query.FacetFields(c, dataFacet), c.matchesManifestFilter); err != nil {
+				return nil, types.NewValidationError("chunks", dataFacet, "GetPage", err)
 			}
 		}
 		sortFunc := func(items []Manifest, sort sdk.SortSpec) error {
-			return sdk.SortManifest(items, sort)
+			return sorting.SortBy(items, sort)
 		}
-		if result, err := facet.GetPage(first, pageSize, filterFunc, sortSpec, sortFunc); err != nil {
+		if result, err := facet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
 			return nil, types.NewStoreError("chunks", dataFacet, "GetPage", err)
 		} else {
 			page.Manifest = result.Items
@@ -143,6 +150,26 @@ func (c *ChunksCollection) GetPage(
 			page.State = result.State
 		}
 		page.ExpectedTotal = facet.ExpectedCount()
+	case ChunksIntegrity:
+		facet := c.integrityFacet
+		var filterFunc func(*Integrity) bool
+		if filter != "" {
+			var err error
+			if filterFunc, err = query.NewFilter(filter, query.FacetFields(c, dataFacet), c.matchesIntegrityFilter); err != nil {
+				return nil, types.NewValidationError("chunks", dataFacet, "GetPage", err)
+			}
+		}
+		sortFunc := func(items []Integrity, sort sdk.SortSpec) error {
+			return sorting.SortBy(items, sort)
+		}
+		if result, err := facet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
+			return nil, types.NewStoreError("chunks", dataFacet, "GetPage", err)
+		} else {
+			page.Integrity = result.Items
+			page.TotalItems = result.TotalItems
+			page.State = result.State
+		}
+		page.ExpectedTotal = facet.ExpectedCount()
 	default:
 		return nil, types.NewValidationError("chunks", payload.DataFacet, "GetPage",
 			fmt.Errorf("[GetPage] unsupported dataFacet: %v", payload.DataFacet))
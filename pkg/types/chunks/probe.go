@@ -0,0 +1,227 @@
+package chunks
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/index"
+)
+
+// BloomProbe is one chunk whose bloom filter claims it may hold the probed address. If the
+// probe was confirmed, the chunk's index was searched as well and a hit with no appearances
+// is a false positive. ExpectedRate is the chance of a false positive given how full the
+// chunk's blooms are.
+type BloomProbe struct {
+	Range         string  `json:"range"`
+	Checked       bool    `json:"checked"`
+	Appearances   int     `json:"appearances"`
+	FalsePositive bool    `json:"falsePositive"`
+	ExpectedRate  float64 `json:"expectedRate"`
+	Error         string  `json:"error,omitempty"`
+}
+
+// BloomProbeResult is the outcome of testing one address against the local blooms of the
+// chunks that overlap a block range
+type BloomProbeResult struct {
+	Address           string       `json:"address"`
+	FirstBlock        uint64       `json:"firstBlock"`
+	LastBlock         uint64       `json:"lastBlock"`
+	Confirmed         bool         `json:"confirmed"`
+	NChunks           int          `json:"nChunks"`
+	NHits             int          `json:"nHits"`
+	NFalsePositives   int          `json:"nFalsePositives"`
+	FalsePositiveRate float64      `json:"falsePositiveRate"`
+	Hits              []BloomProbe `json:"hits"`
+}
+
+// The bloom facet's bucket series the latest probe is drawn into
+const (
+	probeHitsSeries           = "probeHits"
+	probeFalsePositivesSeries = "probeFalsePositives"
+)
+
+// The latest probe on each chain, kept apart from the blooms facet's buckets, which are
+// cleared whenever the facet reloads
+var (
+	probes   = make(map[string]*BloomProbeResult)
+	probesMu sync.Mutex
+)
+
+// ProbeBlooms tests addr against the bloom filter of every local chunk that overlaps
+// firstBlock to lastBlock, a lastBlock of zero meaning the end of the index. If confirm is
+// set, each hit is searched for in the chunk's index to tell real hits from false positives.
+// The result is kept so the blooms facet's buckets can show it.
+func (c *ChunksCollection) ProbeBlooms(chain, indexPath string, addr base.Address, firstBlock, lastBlock uint64, confirm bool) (*BloomProbeResult, error) {
+	var search func(rng string) (int, error)
+	if confirm {
+		search = func(rng string) (int, error) {
+			return indexAppearances(indexFile(indexPath, rng), addr)
+		}
+	}
+
+	ret, err := probeBlooms(indexPath, addr, firstBlock, lastBlock, search)
+	if err != nil {
+		return nil, err
+	}
+
+	probesMu.Lock()
+	probes[chain] = ret
+	probesMu.Unlock()
+	return ret, nil
+}
+
+// probeBlooms does the work of ProbeBlooms. If search is nil the hits are not confirmed.
+func probeBlooms(indexPath string, addr base.Address, firstBlock, lastBlock uint64, search func(rng string) (int, error)) (*BloomProbeResult, error) {
+	if lastBlock != 0 && lastBlock < firstBlock {
+		return nil, fmt.Errorf("invalid block range %d-%d", firstBlock, lastBlock)
+	}
+
+	ret := &BloomProbeResult{
+		Address:    addr.Hex(),
+		FirstBlock: firstBlock,
+		LastBlock:  lastBlock,
+		Confirmed:  search != nil,
+		Hits:       []BloomProbe{},
+	}
+
+	rngs := localRanges(filepath.Join(indexPath, "blooms"), ".bloom")
+	sort.Strings(rngs)
+	for _, rng := range rngs {
+		first, last, err := parseRangeString(rng)
+		if err != nil || last < firstBlock || (lastBlock != 0 && first > lastBlock) {
+			continue
+		}
+		ret.NChunks++
+
+		path := bloomFile(indexPath, rng)
+		hit, err := bloomHas(path, addr)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+		}
+		if !hit {
+			continue
+		}
+
+		probe := BloomProbe{Range: rng, ExpectedRate: expectedRate(path)}
+		if search != nil {
+			if n, err := search(rng); err != nil {
+				probe.Error = err.Error()
+			} else {
+				probe.Checked = true
+				probe.Appearances = n
+				probe.FalsePositive = n == 0
+			}
+		}
+		if probe.FalsePositive {
+			ret.NFalsePositives++
+		}
+		ret.NHits++
+		ret.Hits = append(ret.Hits, probe)
+	}
+
+	if ret.NHits > 0 {
+		ret.FalsePositiveRate = float64(ret.NFalsePositives) / float64(ret.NHits)
+	}
+	return ret, nil
+}
+
+func bloomHas(path string, addr base.Address) (bool, error) {
+	bl, err := index.OpenBloom(path, false /* check */)
+	if err != nil {
+		return false, err
+	}
+	defer bl.Close()
+	return bl.IsMember(addr), nil
+}
+
+func indexAppearances(path string, addr base.Address) (int, error) {
+	chunk, err := index.OpenIndex(path, false /* check */)
+	if err != nil {
+		return 0, err
+	}
+	defer chunk.Close()
+
+	res := chunk.ReadAppearances(addr)
+	if res.Err != nil {
+		return 0, res.Err
+	}
+	if res.AppRecords == nil {
+		return 0, nil
+	}
+	return len(*res.AppRecords), nil
+}
+
+// expectedRate is the chance that an address not in the chunk still lights all five of
+// its bits in one of the chunk's blooms, taking the addresses to be spread evenly over them
+func expectedRate(path string) float64 {
+	_, count, inserted, err := index.ReadBloomMetadata(path, false /* check */, true /* verbose */)
+	if err != nil || count == 0 {
+		return 0
+	}
+	const k = 5
+	perBloom := float64(inserted) / float64(count)
+	one := math.Pow(1-math.Exp(-k*perBloom/index.BLOOM_WIDTH_IN_BITS), k)
+	return -math.Expm1(float64(count) * math.Log1p(-one)) // 1-(1-one)^count, exact for tiny rates
+}
+
+// withProbeSeries returns buckets with the latest probe on chain added as two more series,
+// a count of the chunks claiming a hit and of those that were false positives
+func withProbeSeries(buckets *types.Buckets, chain string) *types.Buckets {
+	probesMu.Lock()
+	probe := probes[chain]
+	probesMu.Unlock()
+	if probe == nil || buckets == nil {
+		return buckets
+	}
+
+	ret := *buckets
+	ret.Series = make(map[string][]types.Bucket, len(buckets.Series)+2)
+	for name, series := range buckets.Series {
+		ret.Series[name] = series
+	}
+
+	size := ret.GridInfo.Size
+	hits := []types.Bucket{}
+	falsePositives := []types.Bucket{}
+	for _, hit := range probe.Hits {
+		first, last, err := parseRangeString(hit.Range)
+		if err != nil {
+			continue
+		}
+		lastBucketIndex := int(last / size)
+		ensureBucketsExist(&hits, lastBucketIndex, size)
+		ensureBucketsExist(&falsePositives, lastBucketIndex, size)
+		distributeToBuckets(&hits, first, last, 1, size)
+		if hit.FalsePositive {
+			distributeToBuckets(&falsePositives, first, last, 1, size)
+		}
+		updateGridInfo(&ret.GridInfo, len(hits), last)
+	}
+	ret.SetSeries(probeHitsSeries, hits)
+	ret.SetSeries(probeFalsePositivesSeries, falsePositives)
+	return &ret
+}
+
+// addDynamicFields adds the metrics that chart the latest probe
+func (c *ChunksCollection) addDynamicFields(cfg *types.ViewConfig) {
+	addProbeMetrics(cfg)
+}
+
+// addProbeMetrics lets the blooms heatmap show the latest probe
+func addProbeMetrics(cfg *types.ViewConfig) {
+	facet, ok := cfg.Facets["blooms"]
+	if !ok || facet.PanelChartConfig == nil {
+		return
+	}
+	facet.PanelChartConfig.Metrics = append(facet.PanelChartConfig.Metrics,
+		types.MetricConfig{Key: probeHitsSeries, Label: "Probe Hits", BucketsField: probeHitsSeries},
+		types.MetricConfig{Key: probeFalsePositivesSeries, Label: "Probe False Positives", BucketsField: probeFalsePositivesSeries},
+	)
+	cfg.Facets["blooms"] = facet
+}
@@ -0,0 +1,144 @@
+package chunks
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/file"
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/index"
+)
+
+// writeBloom writes a bloom file for rng holding addrs in the layout chifra reads
+func writeBloom(t *testing.T, indexPath, rng string, addrs ...base.Address) {
+	t.Helper()
+	var bl index.Bloom
+	for _, addr := range addrs {
+		bl.InsertAddress(addr)
+	}
+
+	var buf bytes.Buffer
+	header := struct {
+		Magic uint16
+		Hash  base.Hash
+	}{Magic: file.SmallMagicNumber}
+	_ = binary.Write(&buf, binary.LittleEndian, header)
+	_ = binary.Write(&buf, binary.LittleEndian, bl.Count)
+	for _, bb := range bl.Blooms {
+		_ = binary.Write(&buf, binary.LittleEndian, bb.NInserted)
+		buf.Write(bb.Bytes)
+	}
+	if err := os.WriteFile(bloomFile(indexPath, rng), buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestProbeBlooms(t *testing.T) {
+	indexPath := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(indexPath, "blooms"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	target := base.HexToAddress("0x1111111111111111111111111111111111111111")
+	other := base.HexToAddress("0x2222222222222222222222222222222222222222")
+	writeBloom(t, indexPath, "000000000-000099999", target)
+	writeBloom(t, indexPath, "000100000-000199999", other)
+	writeBloom(t, indexPath, "000200000-000299999", target, other)
+	writeBloom(t, indexPath, "000300000-000399999", target)
+
+	// The third chunk's index does not have the address, so its hit is a false positive
+	appearances := map[string]int{
+		"000000000-000099999": 3,
+		"000200000-000299999": 0,
+	}
+	searched := []string{}
+	search := func(rng string) (int, error) {
+		searched = append(searched, rng)
+		return appearances[rng], nil
+	}
+
+	res, err := probeBlooms(indexPath, target, 0, 250000, search)
+	if err != nil {
+		t.Fatalf("probeBlooms: %v", err)
+	}
+	if res.NChunks != 3 {
+		t.Errorf("Expected 3 chunks in range, got %d", res.NChunks)
+	}
+	if res.NHits != 2 || len(res.Hits) != 2 {
+		t.Fatalf("Expected 2 hits, got %d", res.NHits)
+	}
+	if res.Hits[0].Range != "000000000-000099999" || res.Hits[0].Appearances != 3 || res.Hits[0].FalsePositive {
+		t.Errorf("Unexpected first hit %+v", res.Hits[0])
+	}
+	if res.Hits[1].Range != "000200000-000299999" || !res.Hits[1].FalsePositive {
+		t.Errorf("Unexpected second hit %+v", res.Hits[1])
+	}
+	if res.NFalsePositives != 1 || res.FalsePositiveRate != 0.5 {
+		t.Errorf("Expected one false positive of two hits, got %d (%f)", res.NFalsePositives, res.FalsePositiveRate)
+	}
+	if len(searched) != 2 {
+		t.Errorf("Expected only the hits to be searched, got %v", searched)
+	}
+	if res.Hits[0].ExpectedRate <= 0 || res.Hits[0].ExpectedRate >= 1e-6 {
+		t.Errorf("Expected a tiny false positive rate for a nearly empty bloom, got %g", res.Hits[0].ExpectedRate)
+	}
+
+	// Without confirmation nothing is searched and no hit is a false positive
+	res, err = probeBlooms(indexPath, target, 0, 0, nil)
+	if err != nil {
+		t.Fatalf("probeBlooms: %v", err)
+	}
+	if res.Confirmed || res.NChunks != 4 || res.NHits != 3 || res.NFalsePositives != 0 || res.Hits[0].Checked {
+		t.Errorf("Unexpected unconfirmed result %+v", res)
+	}
+
+	if _, err := probeBlooms(indexPath, target, 10, 5, nil); err == nil {
+		t.Error("Expected an error for a reversed range")
+	}
+}
+
+func TestWithProbeSeries(t *testing.T) {
+	chain := "probe-test"
+	probesMu.Lock()
+	probes[chain] = &BloomProbeResult{Hits: []BloomProbe{
+		{Range: "000000000-000099999"},
+		{Range: "000200000-000299999", FalsePositive: true},
+	}}
+	probesMu.Unlock()
+	defer func() {
+		probesMu.Lock()
+		delete(probes, chain)
+		probesMu.Unlock()
+	}()
+
+	buckets := types.NewBuckets()
+	buckets.SetSeries("nBlooms", []types.Bucket{types.NewBucket("0", 0, 99999)})
+
+	got := withProbeSeries(buckets, chain)
+	hits := got.GetSeries(probeHitsSeries)
+	if len(hits) != 3 || hits[0].Total != 1 || hits[1].Total != 0 || hits[2].Total != 1 {
+		t.Errorf("Unexpected hits series %+v", hits)
+	}
+	falsePositives := got.GetSeries(probeFalsePositivesSeries)
+	if len(falsePositives) != 3 || falsePositives[0].Total != 0 || falsePositives[2].Total != 1 {
+		t.Errorf("Unexpected false positive series %+v", falsePositives)
+	}
+	if len(got.GetSeries("nBlooms")) != 1 {
+		t.Error("Expected the facet's own series to be kept")
+	}
+	if _, ok := buckets.Series[probeHitsSeries]; ok {
+		t.Error("Expected the facet's buckets to be left alone")
+	}
+	if got.GridInfo.BucketCount != 3 {
+		t.Errorf("Expected 3 buckets in the grid, got %d", got.GridInfo.BucketCount)
+	}
+
+	if withProbeSeries(buckets, "no-probe") != buckets {
+		t.Error("Expected the buckets unchanged when there is no probe")
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/TrueBlocks/trueblocks-explorer/pkg/store"
 	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
 
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/config"
 	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/output"
 	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
 )
@@ -35,6 +36,9 @@ var (
 	indexStore   = make(map[string]*store.Store[Index])
 	indexStoreMu sync.Mutex
 
+	integrityStore   = make(map[string]*store.Store[Integrity])
+	integrityStoreMu sync.Mutex
+
 	manifestStore   = make(map[string]*store.Store[Manifest])
 	manifestStoreMu sync.Mutex
 
@@ -154,6 +158,59 @@ func (c *ChunksCollection) getIndexStore(payload *types.Payload, facet types.Dat
 	return theStore
 }
 
+func (c *ChunksCollection) getIntegrityStore(payload *types.Payload, facet types.DataFacet) *store.Store[Integrity] {
+	integrityStoreMu.Lock()
+	defer integrityStoreMu.Unlock()
+
+	// EXISTING_CODE
+	// EXISTING_CODE
+
+	storeKey := getStoreKey(payload)
+	theStore := integrityStore[storeKey]
+	if theStore == nil {
+		queryFunc := func(ctx *output.RenderCtx) error {
+			// EXISTING_CODE
+			go func() {
+				defer close(ctx.ModelChan)
+				defer close(ctx.ErrorChan)
+				if err := streamIntegrity(ctx, payload.ActiveChain, config.PathToIndex(payload.ActiveChain)); err != nil {
+					wrappedErr := types.NewSDKError("chunks", ChunksIntegrity, "fetch", err)
+					logging.LogBEWarning(fmt.Sprintf("Chunks integrity query error: %v", wrappedErr))
+					select {
+					case ctx.ErrorChan <- wrappedErr:
+					case <-ctx.Ctx.Done():
+					}
+				}
+			}()
+			// EXISTING_CODE
+			return nil
+		}
+
+		processFunc := func(item interface{}) *Integrity {
+			if it, ok := item.(*Integrity); ok {
+				// EXISTING_CODE
+				// EXISTING_CODE
+				return it
+			}
+			return nil
+		}
+
+		mappingFunc := func(item *Integrity) (key string, includeInMap bool) {
+			return "", false
+		}
+
+		storeName := c.getStoreName(payload, facet)
+		theStore = store.NewStore(storeName, queryFunc, processFunc, mappingFunc)
+
+		// EXISTING_CODE
+		// EXISTING_CODE
+
+		integrityStore[storeKey] = theStore
+	}
+
+	return theStore
+}
+
 func (c *ChunksCollection) getManifestStore(payload *types.Payload, facet types.DataFacet) *store.Store[Manifest] {
 	manifestStoreMu.Lock()
 	defer manifestStoreMu.Unlock()
@@ -280,6 +337,8 @@ func (c *ChunksCollection) getStoreName(payload *types.Payload, facet types.Data
 		name = "chunks-blooms"
 	case ChunksManifest:
 		name = "chunks-manifest"
+	case ChunksIntegrity:
+		name = "chunks-integrity"
 	default:
 		return ""
 	}
@@ -37,7 +37,7 @@ func init() {
 }
 
 type ComparitoorCollection struct {
-	comparitoorFacet *facets.Facet[Transaction]
+	comparitoorFacet *facets.Facet[Appearance]
 	chifraFacet      *facets.Facet[Transaction]
 	etherscanFacet   *facets.Facet[Transaction]
 	covalentFacet    *facets.Facet[Transaction]
@@ -57,8 +57,8 @@ func (c *ComparitoorCollection) initializeFacets(payload *types.Payload) {
 	c.comparitoorFacet = facets.NewFacet(
 		ComparitoorComparitoor,
 		isComparitoor,
-		isDupTransaction(),
-		c.getTransactionStore(payload, ComparitoorComparitoor),
+		isDupAppearance(),
+		c.getAppearanceStore(payload, ComparitoorComparitoor),
 		"comparitoor",
 		c,
 		false,
@@ -105,7 +105,7 @@ func (c *ComparitoorCollection) initializeFacets(payload *types.Payload) {
 	)
 }
 
-func isComparitoor(item *Transaction) bool {
+func isComparitoor(item *Appearance) bool {
 	// EXISTING_CODE
 	return true
 	// EXISTING_CODE
@@ -135,6 +135,22 @@ func isAlchemy(item *Transaction) bool {
 	// EXISTING_CODE
 }
 
+func isDupAppearance() func(existing []*Appearance, newItem *Appearance) bool {
+	// EXISTING_CODE
+	return func(existing []*Appearance, newItem *Appearance) bool {
+		if newItem == nil {
+			return true
+		}
+		for _, it := range existing {
+			if it != nil && it.BlockNumber == newItem.BlockNumber && it.TransactionIndex == newItem.TransactionIndex {
+				return true
+			}
+		}
+		return false
+	}
+	// EXISTING_CODE
+}
+
 func isDupTransaction() func(existing []*Transaction, newItem *Transaction) bool {
 	// EXISTING_CODE
 	return func(existing []*Transaction, newItem *Transaction) bool {
@@ -225,15 +241,24 @@ func (c *ComparitoorCollection) NeedsUpdate(payload *types.Payload) bool {
 
 func (c *ComparitoorCollection) AccumulateItem(item interface{}, summary *types.Summary) {
 	// EXISTING_CODE
-	if summary == nil {
-		return
-	}
-	if summary.FacetCounts == nil {
-		summary.FacetCounts = make(map[types.DataFacet]int)
+	// Only the diff is counted; a source's transactions do not say which source they came from
+	if app, ok := item.(*Appearance); ok {
+		c.summaryMutex.Lock()
+		defer c.summaryMutex.Unlock()
+
+		c.summary.TotalCount++
+		if c.summary.FacetCounts == nil {
+			c.summary.FacetCounts = make(map[types.DataFacet]int)
+		}
+		if c.summary.CustomData == nil {
+			c.summary.CustomData = make(map[string]interface{})
+		}
+		c.summary.FacetCounts[ComparitoorComparitoor]++
+		key := app.Status + "Count"
+		count, _ := c.summary.CustomData[key].(int)
+		c.summary.CustomData[key] = count + 1
+		c.summary.LastUpdated = time.Now().Unix()
 	}
-	summary.TotalCount++
-	// We cannot easily know which facet invoked this from here without extra context; leave hook.
-	// Optionally could classify by inspecting item fields later.
 	// EXISTING_CODE
 }
 
@@ -32,10 +32,10 @@ func (c *ComparitoorCollection) buildStaticFacets() map[string]types.FacetConfig
 	return map[string]types.FacetConfig{
 		"comparitoor": {
 			Name:          "Comparitoor",
-			Store:         "transaction",
+			Store:         "appearance",
 			ViewType:      "custom",
 			DividerBefore: false,
-			Fields:        getTransactionFields(),
+			Fields:        getAppearanceFields(),
 			Actions:       []string{},
 			HeaderActions: []string{"export"},
 		},
@@ -94,6 +94,24 @@ func (c *ComparitoorCollection) buildActions() map[string]types.ActionConfig {
 	}
 }
 
+func getAppearanceFields() []types.FieldConfig {
+	ret := []types.FieldConfig{
+		{Section: "Context", Key: "blockNumber", Type: "blknum"},
+		{Section: "Context", Key: "transactionIndex", Type: "txnum"},
+		{Section: "Context", Key: "hash", Type: "hash"},
+		{Section: "Comparison", Key: "status", Type: "string"},
+		{Section: "Comparison", Key: "chifra", Type: "boolean"},
+		{Section: "Comparison", Key: "etherscan", Type: "boolean"},
+		{Section: "Comparison", Key: "covalent", Type: "boolean"},
+		{Section: "Comparison", Key: "alchemy", Type: "boolean"},
+		{Section: "Comparison", Key: "nSources", Type: "uint64"},
+		{Section: "Comparison", Key: "missing", Type: "string"},
+		{Section: "", Key: "actions", Type: "actions", NoDetail: true},
+	}
+	types.NormalizeFields(&ret)
+	return ret
+}
+
 func getTransactionFields() []types.FieldConfig {
 	ret := []types.FieldConfig{
 		{Section: "Context", Key: "blockNumber", Type: "blknum"},
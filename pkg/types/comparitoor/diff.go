@@ -0,0 +1,161 @@
+package comparitoor
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+)
+
+// The status of an appearance compared to chifra
+const (
+	StatusAgreed  = "agreed"  // every source that has data reports it
+	StatusPartial = "partial" // chifra and some, but not all, other sources report it
+	StatusMissing = "missing" // another source reports it, chifra does not
+	StatusExtra   = "extra"   // only chifra reports it
+)
+
+// Appearance is one (block, txIndex) pair reported by at least one source. The per-source
+// booleans say which sources report it; Missing lists the sources that had data for the
+// address but not this appearance.
+type Appearance struct {
+	BlockNumber      base.Blknum `json:"blockNumber"`
+	TransactionIndex base.Txnum  `json:"transactionIndex"`
+	Hash             base.Hash   `json:"hash"`
+	Status           string      `json:"status"`
+	Chifra           bool        `json:"chifra"`
+	Etherscan        bool        `json:"etherscan"`
+	Covalent         bool        `json:"covalent"`
+	Alchemy          bool        `json:"alchemy"`
+	NSources         uint64      `json:"nSources"`
+	Missing          string      `json:"missing"`
+}
+
+func (a *Appearance) Model(chain, format string, verbose bool, extraOpts map[string]any) sdk.Model {
+	return sdk.Model{
+		Data: map[string]any{
+			"blockNumber":      a.BlockNumber,
+			"transactionIndex": a.TransactionIndex,
+			"hash":             a.Hash.Hex(),
+			"status":           a.Status,
+			"chifra":           a.Chifra,
+			"etherscan":        a.Etherscan,
+			"covalent":         a.Covalent,
+			"alchemy":          a.Alchemy,
+			"nSources":         a.NSources,
+			"missing":          a.Missing,
+		},
+		Order: []string{
+			"blockNumber", "transactionIndex", "hash", "status", "chifra", "etherscan",
+			"covalent", "alchemy", "nSources", "missing",
+		},
+	}
+}
+
+func (a *Appearance) setPresent(facet types.DataFacet) {
+	switch facet {
+	case ComparitoorChifra:
+		a.Chifra = true
+	case ComparitoorEtherscan:
+		a.Etherscan = true
+	case ComparitoorCovalent:
+		a.Covalent = true
+	case ComparitoorAlchemy:
+		a.Alchemy = true
+	}
+}
+
+func (a *Appearance) isPresent(facet types.DataFacet) bool {
+	switch facet {
+	case ComparitoorChifra:
+		return a.Chifra
+	case ComparitoorEtherscan:
+		return a.Etherscan
+	case ComparitoorCovalent:
+		return a.Covalent
+	case ComparitoorAlchemy:
+		return a.Alchemy
+	}
+	return false
+}
+
+// sourceResult is what one source reported for an address. A source that is not available
+// has no data for the address and is left out of the comparison.
+type sourceResult struct {
+	facet     types.DataFacet
+	available bool
+	txs       []*Transaction
+}
+
+type appearanceKey struct {
+	block   base.Blknum
+	txIndex base.Txnum
+}
+
+// diffSources lines up every available source's transactions by (block, txIndex) and
+// reports, for each appearance, which sources have it and how it compares to chifra
+func diffSources(results []sourceResult) []*Appearance {
+	byKey := make(map[appearanceKey]*Appearance)
+	for _, result := range results {
+		if !result.available {
+			continue
+		}
+		for _, tx := range result.txs {
+			key := appearanceKey{tx.BlockNumber, tx.TransactionIndex}
+			app := byKey[key]
+			if app == nil {
+				app = &Appearance{BlockNumber: tx.BlockNumber, TransactionIndex: tx.TransactionIndex}
+				byKey[key] = app
+			}
+			if app.isPresent(result.facet) {
+				continue
+			}
+			app.setPresent(result.facet)
+			app.NSources++
+			if app.Hash.IsZero() {
+				app.Hash = tx.Hash
+			}
+		}
+	}
+
+	others := 0
+	for _, result := range results {
+		if result.available && result.facet != ComparitoorChifra {
+			others++
+		}
+	}
+
+	ret := make([]*Appearance, 0, len(byKey))
+	for _, app := range byKey {
+		missing := make([]string, 0)
+		for _, result := range results {
+			if result.available && !app.isPresent(result.facet) {
+				missing = append(missing, string(result.facet))
+			}
+		}
+		app.Missing = strings.Join(missing, ",")
+
+		switch {
+		case !app.Chifra:
+			app.Status = StatusMissing
+		case len(missing) == 0:
+			app.Status = StatusAgreed
+		case app.NSources == 1 && others > 0:
+			app.Status = StatusExtra
+		default:
+			app.Status = StatusPartial
+		}
+		ret = append(ret, app)
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].BlockNumber != ret[j].BlockNumber {
+			return ret[i].BlockNumber < ret[j].BlockNumber
+		}
+		return ret[i].TransactionIndex < ret[j].TransactionIndex
+	})
+	return ret
+}
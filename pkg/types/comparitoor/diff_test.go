@@ -0,0 +1,99 @@
+package comparitoor
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func txs(pairs ...uint64) []*Transaction {
+	ret := make([]*Transaction, 0, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		ret = append(ret, &Transaction{BlockNumber: base.Blknum(pairs[i]), TransactionIndex: base.Txnum(pairs[i+1])})
+	}
+	return ret
+}
+
+func TestDiffSourcesReportsStatus(t *testing.T) {
+	appearances := diffSources([]sourceResult{
+		{facet: ComparitoorChifra, available: true, txs: txs(10, 0, 20, 1, 30, 2)},
+		{facet: ComparitoorEtherscan, available: true, txs: txs(10, 0, 20, 1, 40, 3)},
+		{facet: ComparitoorCovalent, available: false},
+		{facet: ComparitoorAlchemy, available: true, txs: txs(10, 0, 40, 3)},
+	})
+	require.Len(t, appearances, 4)
+
+	byBlock := make(map[base.Blknum]*Appearance)
+	for _, app := range appearances {
+		byBlock[app.BlockNumber] = app
+	}
+
+	assert.Equal(t, StatusAgreed, byBlock[10].Status, "every available source has it")
+	assert.Equal(t, uint64(3), byBlock[10].NSources)
+	assert.Equal(t, StatusPartial, byBlock[20].Status)
+	assert.Equal(t, "alchemy", byBlock[20].Missing)
+	assert.Equal(t, StatusExtra, byBlock[30].Status)
+	assert.Equal(t, StatusMissing, byBlock[40].Status)
+	assert.Equal(t, "chifra", byBlock[40].Missing, "an unavailable source is never listed as missing")
+	assert.False(t, byBlock[40].Covalent)
+}
+
+func TestParseFixtureFormats(t *testing.T) {
+	csvData := "blockNumber,transactionIndex,hash\n100,2,0x01\n101,0,0x02\n"
+	fromCSV, err := parseFixture([]byte(csvData))
+	require.NoError(t, err)
+	require.Len(t, fromCSV, 2)
+	assert.Equal(t, base.Txnum(2), fromCSV[0].TransactionIndex)
+
+	jsonData := `{"status":"1","result":[{"blockNumber":"100","transactionIndex":"0x2","hash":"0x01"},{"blockNumber":101,"transactionIndex":0}]}`
+	fromJSON, err := parseFixture([]byte(jsonData))
+	require.NoError(t, err)
+	require.Len(t, fromJSON, 2)
+	assert.Equal(t, base.Blknum(100), fromJSON[0].BlockNumber)
+	assert.Equal(t, base.Txnum(2), fromJSON[0].TransactionIndex)
+
+	_, err = parseFixture([]byte(`[{"hash":"0x01"}]`))
+	assert.Error(t, err, "rows without a position are rejected")
+}
+
+func TestFixtureProviders(t *testing.T) {
+	embedded := getProvider(ComparitoorEtherscan)
+	got, err := embedded.Transactions("mainnet", fixtureAddress)
+	require.NoError(t, err)
+	assert.NotEmpty(t, got)
+	_, available, err := fetchSource(ComparitoorEtherscan, "mainnet", base.HexToAddress("0x01"))
+	require.NoError(t, err)
+	assert.False(t, available, "the embedded fixtures cover only the address they were captured for")
+
+	dir := t.TempDir()
+	addr := base.HexToAddress("0x00000000000000000000000000000000000000a1")
+	path := filepath.Join(dir, strings.ToLower(addr.Hex())+".csv")
+	require.NoError(t, os.WriteFile(path, []byte("blockNumber,transactionIndex\n5,1\n"), 0o644))
+	fromFile, err := NewFixtureProvider("covalent", filepath.Join(dir, "{address}.csv")).Transactions("mainnet", addr)
+	require.NoError(t, err)
+	assert.Len(t, fromFile, 1)
+	_, err = NewFixtureProvider("covalent", filepath.Join(dir, "{address}.csv")).Transactions("mainnet", fixtureAddress)
+	assert.ErrorIs(t, err, ErrUnavailable)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/mainnet/"+strings.ToLower(addr.Hex()) {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`[{"blockNumber":7,"transactionIndex":3}]`))
+	}))
+	defer server.Close()
+	fromHTTP, err := NewFixtureProvider("alchemy", server.URL+"/{chain}/{address}").Transactions("mainnet", addr)
+	require.NoError(t, err)
+	require.Len(t, fromHTTP, 1)
+	assert.Equal(t, base.Blknum(7), fromHTTP[0].BlockNumber)
+
+	assert.Error(t, SetProvider(ComparitoorComparitoor, embedded), "the diff is not a source")
+}
@@ -14,6 +14,8 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/query"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/sorting"
 	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
 	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
 )
@@ -22,26 +24,12 @@ import (
 
 type ComparitoorPage struct {
 	Facet         types.DataFacet  `json:"facet"`
+	Appearance    []Appearance     `json:"appearance"`
 	Transaction   []Transaction    `json:"transaction"`
 	TotalItems    int              `json:"totalItems"`
 	ExpectedTotal int              `json:"expectedTotal"`
 	State         types.StoreState `json:"state"`
 	// EXISTING_CODE
-	// Per-source arrays and counts
-	Chifra         []*Transaction `json:"chifra"`
-	ChifraCount    int            `json:"chifraCount"`
-	Etherscan      []*Transaction `json:"etherscan"`
-	EtherscanCount int            `json:"etherscanCount"`
-	Covalent       []*Transaction `json:"covalent"`
-	CovalentCount  int            `json:"covalentCount"`
-	Alchemy        []*Transaction `json:"alchemy"`
-	AlchemyCount   int            `json:"alchemyCount"`
-	// Overlap/union/intersection statistics
-	OverlapCount      int `json:"overlapCount"`
-	UnionCount        int `json:"unionCount"`
-	IntersectionCount int `json:"intersectionCount"`
-	// Optionally, a map of overlap details (e.g., how many sources each tx appears in)
-	OverlapDetails map[string]int `json:"overlapDetails,omitempty"`
 	// EXISTING_CODE
 }
 
@@ -82,19 +70,20 @@ func (c *ComparitoorCollection) GetPage(
 
 	case ComparitoorComparitoor:
 		facet := c.comparitoorFacet
-		var filterFunc func(*Transaction) bool
+		var filterFunc func(*Appearance) bool
 		if filter != "" {
-			filterFunc = func(item *Transaction) bool {
-				return c.matchesComparitoorFilter(item, filter)
+			var err error
+			if filterFunc, err = query.NewFilter(filter, query.FacetFields(c, dataFacet), c.matchesComparitoorFilter); err != nil {
+				return nil, types.NewValidationError("comparitoor", dataFacet, "GetPage", err)
 			}
 		}
-		sortFunc := func(items []Transaction, sort sdk.SortSpec) error {
-			return sdk.SortTransaction(items, sort)
+		sortFunc := func(items []Appearance, sort sdk.SortSpec) error {
+			return sorting.SortBy(items, sort)
 		}
-		if result, err := facet.GetPage(first, pageSize, filterFunc, sortSpec, sortFunc); err != nil {
+		if result, err := facet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
 			return nil, types.NewStoreError("comparitoor", dataFacet, "GetPage", err)
 		} else {
-			page.Transaction = result.Items
+			page.Appearance = result.Items
 			page.TotalItems = result.TotalItems
 			page.State = result.State
 		}
@@ -103,14 +92,15 @@ func (c *ComparitoorCollection) GetPage(
 		facet := c.chifraFacet
 		var filterFunc func(*Transaction) bool
 		if filter != "" {
-			filterFunc = func(item *Transaction) bool {
-				return c.matchesChifraFilter(item, filter)
+			var err error
+			if filterFunc, err = query.NewFilter(filter, query.FacetFields(c, dataFacet), c.matchesChifraFilter); err != nil {
+				return nil, types.NewValidationError("comparitoor", dataFacet, "GetPage", err)
 			}
 		}
 		sortFunc := func(items []Transaction, sort sdk.SortSpec) error {
-			return sdk.SortTransaction(items, sort)
+			return sorting.SortBy(items, sort)
 		}
-		if result, err := facet.GetPage(first, pageSize, filterFunc, sortSpec, sortFunc); err != nil {
+		if result, err := facet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
 			return nil, types.NewStoreError("comparitoor", dataFacet, "GetPage", err)
 		} else {
 			page.Transaction = result.Items
@@ -122,14 +112,15 @@ func (c *ComparitoorCollection) GetPage(
 		facet := c.etherscanFacet
 		var filterFunc func(*Transaction) bool
 		if filter != "" {
-			filterFunc = func(item *Transaction) bool {
-				return c.matchesEtherscanFilter(item, filter)
+			var err error
+			if filterFunc, err = query.NewFilter(filter, query.FacetFields(c, dataFacet), c.matchesEtherscanFilter); err != nil {
+				return nil, types.NewValidationError("comparitoor", dataFacet, "GetPage", err)
 			}
 		}
 		sortFunc := func(items []Transaction, sort sdk.SortSpec) error {
-			return sdk.SortTransaction(items, sort)
+			return sorting.SortBy(items, sort)
 		}
-		if result, err := facet.GetPage(first, pageSize, filterFunc, sortSpec, sortFunc); err != nil {
+		if result, err := facet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
 			return nil, types.NewStoreError("comparitoor", dataFacet, "GetPage", err)
 		} else {
 			page.Transaction = result.Items
@@ -141,14 +132,15 @@ func (c *ComparitoorCollection) GetPage(
 		facet := c.covalentFacet
 		var filterFunc func(*Transaction) bool
 		if filter != "" {
-			filterFunc = func(item *Transaction) bool {
-				return c.matchesCovalentFilter(item, filter)
+			var err error
+			if filterFunc, err = query.NewFilter(filter, query.FacetFields(c, dataFacet), c.matchesCovalentFilter); err != nil {
+				return nil, types.NewValidationError("comparitoor", dataFacet, "GetPage", err)
 			}
 		}
 		sortFunc := func(items []Transaction, sort sdk.SortSpec) error {
-			return sdk.SortTransaction(items, sort)
+			return sorting.SortBy(items, sort)
 		}
-		if result, err := facet.GetPage(first, pageSize, filterFunc, sortSpec, sortFunc); err != nil {
+		if result, err := facet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
 			return nil, types.NewStoreError("comparitoor", dataFacet, "GetPage", err)
 		} else {
 			page.Transaction = result.Items
@@ -160,14 +152,15 @@ func (c *ComparitoorCollection) GetPage(
 		facet := c.alchemyFacet
 		var filterFunc func(*Transaction) bool
 		if filter != "" {
-			filterFunc = func(item *Transaction) bool {
-				return c.matchesAlchemyFilter(item, filter)
+			var err error
+			if filterFunc, err = query.NewFilter(filter, query.FacetFields(c, dataFacet), c.matchesAlchemyFilter); err != nil {
+				return nil, types.NewValidationError("comparitoor", dataFacet, "GetPage", err)
 			}
 		}
 		sortFunc := func(items []Transaction, sort sdk.SortSpec) error {
-			return sdk.SortTransaction(items, sort)
+			return sorting.SortBy(items, sort)
 		}
-		if result, err := facet.GetPage(first, pageSize, filterFunc, sortSpec, sortFunc); err != nil {
+		if result, err := facet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
 			return nil, types.NewStoreError("comparitoor", dataFacet, "GetPage", err)
 		} else {
 			page.Transaction = result.Items
@@ -253,117 +246,24 @@ func preprocessPage(
 	_ = pageSize
 	_ = sortSpec
 	// EXISTING_CODE
-	// Always populate per-source arrays for frontend, with missing/unique flags
-	// First, gather all keys and counts
-	// Helper: convert []T to []*T
-	// slicePtrs := func(items []Transaction) []*Transaction {
-	// 	out := make([]*Transaction, len(items))
-	// 	for i := range items {
-	// 		out[i] = &items[i]
-	// 	}
-	// 	return out
-	// }
-	// // Key as string: "blockNum:txIdx"
-	// key := func(tx *Transaction) string {
-	// 	if tx == nil {
-	// 		return ""
-	// 	}
-	// 	return fmt.Sprintf("%v:%v", tx.BlockNumber, tx.TransactionIndex)
-	// }
-	// // Get per-source results
-	// var chifraResult, esResult, cvResult, alResult *facets.PageResult[Transaction]
-	// if c.chifraFacet != nil {
-	// 	chifraResult, _ = c.chifraFacet.GetPage(first, pageSize, nil, sortSpec, nil)
-	// }
-	// if c.etherscanFacet != nil {
-	// 	esResult, _ = c.etherscanFacet.GetPage(first, pageSize, nil, sortSpec, nil)
-	// }
-	// if c.covalentFacet != nil {
-	// 	cvResult, _ = c.covalentFacet.GetPage(first, pageSize, nil, sortSpec, nil)
-	// }
-	// if c.alchemyFacet != nil {
-	// 	alResult, _ = c.alchemyFacet.GetPage(first, pageSize, nil, sortSpec, nil)
-	// }
-	// sets := [][]*Transaction{
-	// 	slicePtrs(chifraResult.Items),
-	// 	slicePtrs(esResult.Items),
-	// 	slicePtrs(cvResult.Items),
-	// 	slicePtrs(alResult.Items),
-	// }
-	// keyCount := make(map[string]int)
-	// for _, arr := range sets {
-	// 	for _, tx := range arr {
-	// 		k := key(tx)
-	// 		if k != "" {
-	// 			keyCount[k]++
-	// 		}
-	// 	}
-	// }
-	// // Helper to build annotated array for a source
-	// buildAnnotated := func(arr []Transaction) []*Transaction {
-	// 	present := make(map[string]*Transaction)
-	// 	for i := range arr {
-	// 		tx := &arr[i]
-	// 		present[key(tx)] = tx
-	// 	}
-	// 	out := make([]*Transaction, 0, len(keyCount))
-	// 	for k := range keyCount {
-	// 		found, ok := present[k]
-	// 		missing := !ok
-	// 		unique := false
-	// 		if !missing && keyCount[k] == 1 {
-	// 			unique = true
-	// 		}
-	// 		if found != nil {
-	// 			found.Missing = false
-	// 			found.Unique = unique
-	// 			// Use existing entry, with missing=false and unique flag set
-	// 			out = append(out, found)
-	// 		} else {
-	// 			// Synthesize a missing entry (types must match SDK)
-	// 			// Parse block/tx from key
-	// 			var blk, txidx uint64
-	// 			_, _ = fmt.Sscanf(k, "%d:%d", &blk, &txidx)
-	// 			tx := sdk.Transaction{
-	// 				BlockNumber:      base.Blknum(blk),
-	// 				TransactionIndex: base.Txnum(txidx),
-	// 			}
-	// 			out = append(out, &tx)
-	// 		}
-	// 	}
-	// 	return out
-	// }
-	// page.Chifra = buildAnnotated(chifraResult.Items)
-	// page.Etherscan = buildAnnotated(esResult.Items)
-	// page.Covalent = buildAnnotated(cvResult.Items)
-	// page.Alchemy = buildAnnotated(alResult.Items)
-	// page.ChifraCount = len(chifraResult.Items)
-	// page.EtherscanCount = len(esResult.Items)
-	// page.CovalentCount = len(cvResult.Items)
-	// page.AlchemyCount = len(alResult.Items)
-	// // Compute overlap/union/intersection statistics
-	// union := 0
-	// intersection := 0
-	// overlap := 0
-	// for _, count := range keyCount {
-	// 	if count > 1 {
-	// 		overlap++
-	// 	}
-	// 	if count == len(sets) {
-	// 		intersection++
-	// 	}
-	// 	union++
-	// }
-	// page.OverlapCount = overlap
-	// page.UnionCount = union
-	// page.IntersectionCount = intersection
-	// page.OverlapDetails = keyCount
 	// EXISTING_CODE
 	return nil
 }
 
 // EXISTING_CODE
-func (c *ComparitoorCollection) matchesComparitoorFilter(item *Transaction, filter string) bool {
+// matchesComparitoorFilter matches plain text against an appearance's position, hash, and
+// status, so typing "missing" or "extra" finds the rows chifra disagrees on. Use
+// status:missing for an exact match.
+func (c *ComparitoorCollection) matchesComparitoorFilter(item *Appearance, filter string) bool {
+	if filter == "" || item == nil {
+		return true
+	}
+	filter = strings.ToLower(strings.TrimSpace(filter))
+	s := fmt.Sprintf("%d.%d %s %s %s", item.BlockNumber, item.TransactionIndex, item.Hash.Hex(), item.Status, item.Missing)
+	return strings.Contains(strings.ToLower(s), filter)
+}
+
+func (c *ComparitoorCollection) matchesTransactionFilter(item *Transaction, filter string) bool {
 	if filter == "" || item == nil {
 		return true
 	}
@@ -405,19 +305,19 @@ func (c *ComparitoorCollection) matchesComparitoorFilter(item *Transaction, filt
 }
 
 func (c *ComparitoorCollection) matchesChifraFilter(item *Transaction, filter string) bool {
-	return c.matchesComparitoorFilter(item, filter)
+	return c.matchesTransactionFilter(item, filter)
 }
 
 func (c *ComparitoorCollection) matchesEtherscanFilter(item *Transaction, filter string) bool {
-	return c.matchesComparitoorFilter(item, filter)
+	return c.matchesTransactionFilter(item, filter)
 }
 
 func (c *ComparitoorCollection) matchesCovalentFilter(item *Transaction, filter string) bool {
-	return c.matchesComparitoorFilter(item, filter)
+	return c.matchesTransactionFilter(item, filter)
 }
 
 func (c *ComparitoorCollection) matchesAlchemyFilter(item *Transaction, filter string) bool {
-	return c.matchesComparitoorFilter(item, filter)
+	return c.matchesTransactionFilter(item, filter)
 }
 
 // EXISTING_CODE
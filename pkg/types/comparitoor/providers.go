@@ -0,0 +1,294 @@
+package comparitoor
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+)
+
+// Provider is a source of the transactions on which an address appears
+type Provider interface {
+	Name() string
+	Transactions(chain string, address base.Address) ([]*Transaction, error)
+}
+
+// ErrUnavailable is returned by a provider that has no data at all for an address. The
+// source is then left out of the comparison rather than reported as missing everything.
+var ErrUnavailable = errors.New("source has no data for this address")
+
+// sourceFacets lists the facets backed by a provider, in the order they are compared.
+// Chifra comes first; every other source is measured against it.
+var sourceFacets = []types.DataFacet{
+	ComparitoorChifra,
+	ComparitoorEtherscan,
+	ComparitoorCovalent,
+	ComparitoorAlchemy,
+}
+
+//go:embed testdata/alchemy.csv
+var alchemyCSV []byte
+
+//go:embed testdata/chifra.csv
+var chifraCSV []byte
+
+//go:embed testdata/etherscan.csv
+var etherscanCSV []byte
+
+//go:embed testdata/covalent.csv
+var covalentCSV []byte
+
+// fixtureAddress is the address the embedded testdata was captured for
+var fixtureAddress = base.HexToAddress("0xf503017d7baf7fbc0fff7492b751025c6a78179b")
+
+var (
+	providers = map[types.DataFacet]Provider{
+		ComparitoorChifra:    &ChifraProvider{},
+		ComparitoorEtherscan: newEmbeddedProvider("etherscan", etherscanCSV),
+		ComparitoorCovalent:  newEmbeddedProvider("covalent", covalentCSV),
+		ComparitoorAlchemy:   newEmbeddedProvider("alchemy", alchemyCSV),
+	}
+	providersMu sync.RWMutex
+)
+
+// SetProvider replaces the provider behind one of the source facets (chifra, etherscan,
+// covalent, or alchemy)
+func SetProvider(facet types.DataFacet, provider Provider) error {
+	if !isSourceFacet(facet) {
+		return fmt.Errorf("%s is not a comparitoor source", facet)
+	}
+	providersMu.Lock()
+	defer providersMu.Unlock()
+	providers[facet] = provider
+	return nil
+}
+
+func getProvider(facet types.DataFacet) Provider {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+	return providers[facet]
+}
+
+func isSourceFacet(facet types.DataFacet) bool {
+	for _, f := range sourceFacets {
+		if f == facet {
+			return true
+		}
+	}
+	return false
+}
+
+// fetchSource asks the facet's provider for the address's transactions. The boolean is
+// false if the provider has no data for the address.
+func fetchSource(facet types.DataFacet, chain string, address base.Address) ([]*Transaction, bool, error) {
+	provider := getProvider(facet)
+	if provider == nil {
+		return nil, false, nil
+	}
+	txs, err := provider.Transactions(chain, address)
+	if errors.Is(err, ErrUnavailable) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, fmt.Errorf("%s: %w", provider.Name(), err)
+	}
+	return txs, true, nil
+}
+
+// ChifraProvider lists an address's transactions with chifra export
+type ChifraProvider struct{}
+
+func (p *ChifraProvider) Name() string {
+	return "chifra"
+}
+
+func (p *ChifraProvider) Transactions(chain string, address base.Address) ([]*Transaction, error) {
+	opts := sdk.ExportOptions{
+		Globals: sdk.Globals{Cache: true, Chain: chain},
+		Addrs:   []string{address.Hex()},
+	}
+	txs, _, err := opts.Export()
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*Transaction, 0, len(txs))
+	for i := range txs {
+		ret = append(ret, &txs[i])
+	}
+	return ret, nil
+}
+
+// FixtureProvider reads a source's transaction list from a saved response: a file on disk
+// or a document served over HTTP. The source may contain {chain} and {address}, which are
+// replaced before reading, so one provider can serve a folder of per-address fixtures.
+//
+// The document is either a CSV file with a header row or a JSON array (optionally under an
+// Etherscan-style "result" key). Each row needs blockNumber and transactionIndex; hash,
+// from, to, and timestamp are used if present.
+type FixtureProvider struct {
+	name    string
+	source  string
+	data    []byte
+	address base.Address
+}
+
+// NewFixtureProvider returns a provider that reads from source, a path or an http(s) URL
+func NewFixtureProvider(name, source string) *FixtureProvider {
+	return &FixtureProvider{name: name, source: source}
+}
+
+// newEmbeddedProvider returns a provider over data captured for fixtureAddress
+func newEmbeddedProvider(name string, data []byte) *FixtureProvider {
+	return &FixtureProvider{name: name, data: data, address: fixtureAddress}
+}
+
+func (p *FixtureProvider) Name() string {
+	return p.name
+}
+
+func (p *FixtureProvider) Transactions(chain string, address base.Address) ([]*Transaction, error) {
+	if p.data != nil {
+		if address != p.address {
+			return nil, ErrUnavailable
+		}
+		return parseFixture(p.data)
+	}
+
+	source := strings.NewReplacer("{chain}", chain, "{address}", strings.ToLower(address.Hex())).Replace(p.source)
+	data, err := readFixture(source)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrUnavailable
+	} else if err != nil {
+		return nil, err
+	}
+	return parseFixture(data)
+}
+
+var fixtureClient = &http.Client{Timeout: 30 * time.Second}
+
+func readFixture(source string) ([]byte, error) {
+	if !strings.HasPrefix(source, "http://") && !strings.HasPrefix(source, "https://") {
+		return os.ReadFile(source)
+	}
+
+	resp, err := fixtureClient.Get(source)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrUnavailable
+	} else if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching %s: %s", source, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func parseFixture(data []byte) ([]*Transaction, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && (trimmed[0] == '[' || trimmed[0] == '{') {
+		return parseFixtureJSON(trimmed)
+	}
+	return parseFixtureCSV(data)
+}
+
+func parseFixtureCSV(data []byte) ([]*Transaction, error) {
+	r := csv.NewReader(bufio.NewReader(bytes.NewReader(data)))
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) < 2 {
+		return []*Transaction{}, nil
+	}
+
+	rows := make([]map[string]any, 0, len(records)-1)
+	header := records[0]
+	for _, rec := range records[1:] {
+		row := make(map[string]any, len(header))
+		for i, key := range header {
+			if i < len(rec) {
+				row[strings.TrimSpace(key)] = strings.TrimSpace(rec[i])
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rowsToTransactions(rows)
+}
+
+func parseFixtureJSON(data []byte) ([]*Transaction, error) {
+	var rows []map[string]any
+	if data[0] == '{' {
+		var wrapped struct {
+			Result []map[string]any `json:"result"`
+		}
+		if err := json.Unmarshal(data, &wrapped); err != nil {
+			return nil, err
+		}
+		rows = wrapped.Result
+	} else if err := json.Unmarshal(data, &rows); err != nil {
+		return nil, err
+	}
+	return rowsToTransactions(rows)
+}
+
+func rowsToTransactions(rows []map[string]any) ([]*Transaction, error) {
+	ret := make([]*Transaction, 0, len(rows))
+	for i, row := range rows {
+		blk, ok1 := fixtureUint(row["blockNumber"])
+		idx, ok2 := fixtureUint(row["transactionIndex"])
+		if !ok1 || !ok2 {
+			return nil, fmt.Errorf("row %d: blockNumber and transactionIndex are required", i+1)
+		}
+		tx := &Transaction{
+			BlockNumber:      base.Blknum(blk),
+			TransactionIndex: base.Txnum(idx),
+		}
+		if hash, ok := row["hash"].(string); ok {
+			tx.Hash = base.HexToHash(hash)
+		}
+		if from, ok := row["from"].(string); ok {
+			tx.From = base.HexToAddress(from)
+		}
+		if to, ok := row["to"].(string); ok {
+			tx.To = base.HexToAddress(to)
+		}
+		if ts, ok := fixtureUint(row["timestamp"]); ok {
+			tx.Timestamp = base.Timestamp(ts)
+		}
+		ret = append(ret, tx)
+	}
+	return ret, nil
+}
+
+// fixtureUint reads a number that a third-party API may send as a JSON number, a decimal
+// string, or a hex string
+func fixtureUint(value any) (uint64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return uint64(v), v >= 0
+	case string:
+		if strings.HasPrefix(v, "0x") {
+			n, err := strconv.ParseUint(v[2:], 16, 64)
+			return n, err == nil
+		}
+		n, err := strconv.ParseUint(v, 10, 64)
+		return n, err == nil
+	}
+	return 0, false
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/TrueBlocks/trueblocks-explorer/pkg/types/names"
 	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
 
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
 	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/output"
 )
 
@@ -25,6 +26,69 @@ type Transaction = sdk.Transaction
 
 // EXISTING_CODE
 
+var (
+	appearanceStore   = make(map[string]*store.Store[Appearance])
+	appearanceStoreMu sync.Mutex
+)
+
+func (c *ComparitoorCollection) getAppearanceStore(payload *types.Payload, facet types.DataFacet) *store.Store[Appearance] {
+	appearanceStoreMu.Lock()
+	defer appearanceStoreMu.Unlock()
+
+	// EXISTING_CODE
+	// EXISTING_CODE
+
+	storeKey := getStoreKey(payload)
+	theStore := appearanceStore[storeKey]
+	if theStore == nil {
+		queryFunc := func(ctx *output.RenderCtx) error {
+			// EXISTING_CODE
+			address := base.HexToAddress(payload.ActiveAddress)
+			results := make([]sourceResult, 0, len(sourceFacets))
+			for _, source := range sourceFacets {
+				txs, available, err := fetchSource(source, payload.ActiveChain, address)
+				if err != nil {
+					return types.NewSDKError("comparitoor", facet, "fetch", err)
+				}
+				results = append(results, sourceResult{facet: source, available: available, txs: txs})
+			}
+			appearances := diffSources(results)
+			go func() {
+				defer close(ctx.ModelChan)
+				defer close(ctx.ErrorChan)
+				for _, app := range appearances {
+					ctx.ModelChan <- app
+				}
+			}()
+			// EXISTING_CODE
+			return nil
+		}
+
+		processFunc := func(item interface{}) *Appearance {
+			if it, ok := item.(*Appearance); ok {
+				// EXISTING_CODE
+				// EXISTING_CODE
+				return it
+			}
+			return nil
+		}
+
+		mappingFunc := func(item *Appearance) (key string, includeInMap bool) {
+			return "", false
+		}
+
+		storeName := c.getStoreName(payload, facet)
+		theStore = store.NewStore(storeName, queryFunc, processFunc, mappingFunc)
+
+		// EXISTING_CODE
+		// EXISTING_CODE
+
+		appearanceStore[storeKey] = theStore
+	}
+
+	return theStore
+}
+
 var (
 	transactionStore   = make(map[string]*store.Store[Transaction])
 	transactionStoreMu sync.Mutex
@@ -35,6 +99,12 @@ func (c *ComparitoorCollection) getTransactionStore(payload *types.Payload, face
 	defer transactionStoreMu.Unlock()
 
 	// EXISTING_CODE
+	// Every source facet keeps its own transactions, so the source is part of the store's key
+	address := base.HexToAddress(payload.ActiveAddress)
+	payload = &types.Payload{
+		ActiveChain:   payload.ActiveChain,
+		ActiveAddress: payload.ActiveAddress + "-" + string(facet),
+	}
 	// EXISTING_CODE
 
 	storeKey := getStoreKey(payload)
@@ -42,28 +112,14 @@ func (c *ComparitoorCollection) getTransactionStore(payload *types.Payload, face
 	if theStore == nil {
 		queryFunc := func(ctx *output.RenderCtx) error {
 			// EXISTING_CODE
+			txs, _, err := fetchSource(facet, payload.ActiveChain, address)
+			if err != nil {
+				return types.NewSDKError("comparitoor", facet, "fetch", err)
+			}
 			go func() {
 				defer close(ctx.ModelChan)
 				defer close(ctx.ErrorChan)
-				var src []*Transaction
-				// Import mock variables from mocks.go
-				// (They are package-level, so no explicit import needed)
-				switch facet {
-				case ComparitoorChifra:
-					src = mockChifra
-				case ComparitoorEtherscan:
-					src = mockEtherscan
-				case ComparitoorCovalent:
-					src = mockCovalent
-				case ComparitoorAlchemy:
-					src = mockAlchemy
-				case ComparitoorComparitoor:
-					src = append([]*Transaction{}, mockChifra...)
-					src = append(src, mockEtherscan...)
-					src = append(src, mockCovalent...)
-					src = append(src, mockAlchemy...)
-				}
-				for _, tx := range src {
+				for _, tx := range txs {
 					ctx.ModelChan <- tx
 				}
 			}()
@@ -106,7 +162,7 @@ func (c *ComparitoorCollection) getStoreName(payload *types.Payload, facet types
 
 	switch facet {
 	case ComparitoorComparitoor:
-		name = "comparitoor-transaction"
+		name = "comparitoor-appearance"
 	case ComparitoorChifra:
 		name = "comparitoor-transaction"
 	case ComparitoorEtherscan:
@@ -29,6 +29,9 @@ func (c *ContractsCollection) GetBuckets(payload *types.Payload) (*types.Buckets
 
 	buckets := facet.GetBuckets()
 	// EXISTING_CODE
+	if payload.DataFacet == ContractsDashboard {
+		buckets = withTimelineSeries(buckets, payload.ActiveChain, payload.ActiveContract)
+	}
 	// EXISTING_CODE
 	return buckets, nil
 }
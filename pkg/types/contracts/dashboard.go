@@ -0,0 +1,155 @@
+package contracts
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/rpcpool"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types/abis"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types/names"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/articulate"
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/config"
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/output"
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+)
+
+var (
+	contractsSource   func() []string
+	contractsSourceMu sync.RWMutex
+)
+
+// SetContractsSource installs the function the dashboard calls for the contracts of the
+// active project. Without one, only the active contract is shown.
+func SetContractsSource(fn func() []string) {
+	contractsSourceMu.Lock()
+	defer contractsSourceMu.Unlock()
+	contractsSource = fn
+}
+
+// contractAddresses returns the project's contracts with active, if it is not among them,
+// first. Anything that is not an address is skipped.
+func contractAddresses(active string) []base.Address {
+	contractsSourceMu.RLock()
+	fn := contractsSource
+	contractsSourceMu.RUnlock()
+
+	list := []string{active}
+	if fn != nil {
+		list = append(list, fn()...)
+	}
+
+	ret := []base.Address{}
+	seen := make(map[base.Address]bool)
+	for _, s := range list {
+		if !base.IsValidAddress(s) {
+			continue
+		}
+		addr := base.HexToAddress(s)
+		if !seen[addr] {
+			seen[addr] = true
+			ret = append(ret, addr)
+		}
+	}
+	return ret
+}
+
+// streamContracts reads each contract at the latest block from chain's RPC provider and
+// sends it to the store
+func streamContracts(renderCtx *output.RenderCtx, chain string, addrs []base.Address) {
+	rpcURL := rpcpool.Provider(chain, config.GetChain(chain).GetRpcProvider())
+	getAbi := func(addr base.Address) (*sdk.Abi, error) {
+		return abis.GetAbi(chain, addr)
+	}
+	for _, addr := range addrs {
+		contract := readContract(rpcURL, addr, getAbi)
+		contract.Name = names.NameAddress(addr)
+		select {
+		case renderCtx.ModelChan <- contract:
+		case <-renderCtx.Ctx.Done():
+			return
+		}
+	}
+}
+
+// readContract returns the contract at addr with its ABI and the results of calling each
+// of its zero-argument view and pure functions. A function that fails or reverts carries
+// the error in its message and is counted in the contract's errors.
+func readContract(rpcURL string, addr base.Address, getAbi func(base.Address) (*sdk.Abi, error)) *Contract {
+	ret := &Contract{
+		Address:     addr,
+		LastUpdated: base.Timestamp(time.Now().Unix()),
+		ReadResults: make(map[string]interface{}),
+	}
+
+	abi, err := getAbi(addr)
+	if err != nil {
+		ret.ErrorCount = 1
+		ret.LastError = fmt.Sprintf("could not get the abi: %v", err)
+		return ret
+	}
+	ret.Abi = abi
+
+	for i := range abi.Functions {
+		fn := &abi.Functions[i]
+		if !isReadFunction(fn) {
+			continue
+		}
+		value, err := callFunction(rpcURL, addr, fn, "latest")
+		if err != nil {
+			fn.Message = err.Error()
+			ret.ErrorCount++
+			ret.LastError = fmt.Sprintf("%s: %v", fn.Name, err)
+			continue
+		}
+		ret.ReadResults[fn.Name] = value
+	}
+	return ret
+}
+
+func isReadFunction(fn *sdk.Function) bool {
+	if fn.FunctionType != "function" || len(fn.Inputs) > 0 {
+		return false
+	}
+	return fn.StateMutability == "view" || fn.StateMutability == "pure" || fn.Constant
+}
+
+// callFunction calls fn on addr at block, a hex number or tag, and decodes its outputs into
+// fn. It returns the value of a single output, or all of them in order.
+func callFunction(rpcURL string, addr base.Address, fn *sdk.Function, block string) (any, error) {
+	data, err := fn.Pack(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	call := map[string]string{"to": addr.Hex(), "data": "0x" + hex.EncodeToString(data)}
+	raw, err := rpcpool.CallString(rpcURL, "eth_call", call, block)
+	if err != nil {
+		return nil, errors.New(strings.TrimPrefix(err.Error(), "eth_call: "))
+	}
+
+	out := strings.TrimPrefix(raw, "0x")
+	if len(fn.Outputs) == 0 {
+		return nil, nil
+	}
+	if out == "" {
+		return nil, fmt.Errorf("no data returned")
+	}
+	if err := articulate.ArticulateFunction(fn, "", out); err != nil {
+		return nil, err
+	}
+
+	if len(fn.Outputs) == 1 {
+		return fn.Outputs[0].Value, nil
+	}
+	values := make([]any, len(fn.Outputs))
+	for i, o := range fn.Outputs {
+		values[i] = o.Value
+	}
+	return values, nil
+}
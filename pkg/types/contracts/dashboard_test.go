@@ -0,0 +1,129 @@
+package contracts
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+)
+
+// word left-pads hex to one 32-byte ABI word
+func word(hex string) string {
+	return strings.Repeat("0", 64-len(hex)) + hex
+}
+
+// ethCallServer stands in for a node, answering eth_call by the selector of the call data
+func ethCallServer(t *testing.T) string {
+	results := map[string]string{
+		// name() returns the string "Panvala pan"
+		"06fdde03": word("20") + word("b") + "50616e76616c612070616e" + strings.Repeat("0", 42),
+		// decimals() returns 18
+		"313ce567": word("12"),
+		// getReserves() returns (1000, 2000, 1700000000)
+		"0902f1ac": word("3e8") + word("7d0") + word("6553f100"),
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		var call struct {
+			Data string `json:"data"`
+		}
+		_ = json.Unmarshal(req.Params[0], &call)
+		if result, ok := results[strings.TrimPrefix(call.Data, "0x")]; ok {
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x` + result + `"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":3,"message":"execution reverted: Pausable: not supported"}}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+func testAbi() *sdk.Abi {
+	view := func(name string, outputs ...string) sdk.Function {
+		fn := sdk.Function{Name: name, FunctionType: "function", StateMutability: "view", Inputs: []sdk.Parameter{}}
+		for _, o := range outputs {
+			fn.Outputs = append(fn.Outputs, sdk.Parameter{ParameterType: o})
+		}
+		return fn
+	}
+	balanceOf := view("balanceOf", "uint256")
+	balanceOf.Inputs = []sdk.Parameter{{Name: "owner", ParameterType: "address"}}
+	transfer := sdk.Function{Name: "transfer", FunctionType: "function", StateMutability: "nonpayable"}
+	return &sdk.Abi{Functions: []sdk.Function{
+		view("name", "string"),
+		view("decimals", "uint8"),
+		view("getReserves", "uint112", "uint112", "uint32"),
+		view("paused", "bool"),
+		balanceOf,
+		transfer,
+		{Name: "Transfer", FunctionType: "event"},
+	}}
+}
+
+func TestReadContract(t *testing.T) {
+	addr := base.HexToAddress("0xd56dac73a4d6766464b38ec6d91eb45ce7457c44")
+	contract := readContract(ethCallServer(t), addr, func(base.Address) (*sdk.Abi, error) {
+		return testAbi(), nil
+	})
+
+	if contract.Address != addr || contract.Abi == nil {
+		t.Fatalf("Expected the contract and its abi, got %+v", contract)
+	}
+	if got := contract.ReadResults["name"]; got != "Panvala pan" {
+		t.Errorf("Expected name to be decoded, got %v", got)
+	}
+	if got := contract.ReadResults["decimals"]; got != "18" {
+		t.Errorf("Expected decimals to be decoded, got %v", got)
+	}
+	reserves, ok := contract.ReadResults["getReserves"].([]any)
+	if !ok || len(reserves) != 3 || reserves[0] != "1000" || reserves[1] != "2000" || reserves[2] != "1700000000" {
+		t.Errorf("Expected every output of getReserves, got %v", contract.ReadResults["getReserves"])
+	}
+	if contract.Abi.Functions[2].Outputs[1].Value != "2000" {
+		t.Errorf("Expected the outputs in the abi to carry the values, got %+v", contract.Abi.Functions[2].Outputs)
+	}
+
+	// The revert is reported on its function; functions with inputs and writes are not called
+	if _, ok := contract.ReadResults["paused"]; ok {
+		t.Error("Expected no result for a reverted call")
+	}
+	if msg := contract.Abi.Functions[3].Message; msg != "execution reverted: Pausable: not supported" {
+		t.Errorf("Expected the revert on the function, got %q", msg)
+	}
+	if contract.ErrorCount != 1 || !strings.HasPrefix(contract.LastError, "paused: ") {
+		t.Errorf("Expected one error, got %d %q", contract.ErrorCount, contract.LastError)
+	}
+	if len(contract.ReadResults) != 3 {
+		t.Errorf("Expected only the three readable functions, got %v", contract.ReadResults)
+	}
+}
+
+func TestReadContractWithoutAbi(t *testing.T) {
+	contract := readContract("http://127.0.0.1:1", base.HexToAddress("0x1"), func(base.Address) (*sdk.Abi, error) {
+		return nil, errors.New("not verified")
+	})
+	if contract.Abi != nil || contract.ErrorCount != 1 || contract.LastError != "could not get the abi: not verified" {
+		t.Errorf("Expected the abi error, got %+v", contract)
+	}
+}
+
+func TestContractAddresses(t *testing.T) {
+	SetContractsSource(func() []string {
+		return []string{"0x2222222222222222222222222222222222222222", "not an address", "0x1111111111111111111111111111111111111111"}
+	})
+	defer SetContractsSource(nil)
+
+	got := contractAddresses("0x1111111111111111111111111111111111111111")
+	if len(got) != 2 || got[0].Hex() != "0x1111111111111111111111111111111111111111" || got[1].Hex() != "0x2222222222222222222222222222222222222222" {
+		t.Errorf("Expected the active contract first and no duplicates, got %v", got)
+	}
+}
@@ -13,6 +13,8 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/query"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/sorting"
 	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
 	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
 )
@@ -69,14 +71,15 @@ func (c *ContractsCollection) GetPage(
 		facet := c.dashboardFacet
 		var filterFunc func(*Contract) bool
 		if filter != "" {
-			filterFunc = func(item *Contract) bool {
-				return c.matchesDashboardFilter(item, filter)
+			var err error
+			if filterFunc, err = query.NewFilter(filter, query.FacetFields(c, dataFacet), c.matchesDashboardFilter); err != nil {
+				return nil, types.NewValidationError("contracts", dataFacet, "GetPage", err)
 			}
 		}
 		sortFunc := func(items []Contract, sort sdk.SortSpec) error {
-			return sdk.SortContracts(items, sort)
+			return sorting.SortBy(items, sort)
 		}
-		if result, err := facet.GetPage(first, pageSize, filterFunc, sortSpec, sortFunc); err != nil {
+		if result, err := facet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
 			return nil, types.NewStoreError("contracts", dataFacet, "GetPage", err)
 		} else {
 			page.Contracts = result.Items
@@ -88,14 +91,15 @@ func (c *ContractsCollection) GetPage(
 		facet := c.executeFacet
 		var filterFunc func(*Contract) bool
 		if filter != "" {
-			filterFunc = func(item *Contract) bool {
-				return c.matchesExecuteFilter(item, filter)
+			var err error
+			if filterFunc, err = query.NewFilter(filter, query.FacetFields(c, dataFacet), c.matchesExecuteFilter); err != nil {
+				return nil, types.NewValidationError("contracts", dataFacet, "GetPage", err)
 			}
 		}
 		sortFunc := func(items []Contract, sort sdk.SortSpec) error {
-			return sdk.SortContracts(items, sort)
+			return sorting.SortBy(items, sort)
 		}
-		if result, err := facet.GetPage(first, pageSize, filterFunc, sortSpec, sortFunc); err != nil {
+		if result, err := facet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
 			return nil, types.NewStoreError("contracts", dataFacet, "GetPage", err)
 		} else {
 			page.Contracts = result.Items
@@ -107,14 +111,15 @@ func (c *ContractsCollection) GetPage(
 		facet := c.eventsFacet
 		var filterFunc func(*Log) bool
 		if filter != "" {
-			filterFunc = func(item *Log) bool {
-				return c.matchesEventFilter(item, filter)
+			var err error
+			if filterFunc, err = query.NewFilter(filter, query.FacetFields(c, dataFacet), c.matchesEventFilter); err != nil {
+				return nil, types.NewValidationError("contracts", dataFacet, "GetPage", err)
 			}
 		}
 		sortFunc := func(items []Log, sort sdk.SortSpec) error {
-			return sdk.SortLogs(items, sort)
+			return sorting.SortBy(items, sort)
 		}
-		if result, err := facet.GetPage(first, pageSize, filterFunc, sortSpec, sortFunc); err != nil {
+		if result, err := facet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
 			return nil, types.NewStoreError("contracts", dataFacet, "GetPage", err)
 		} else {
 			page.Logs = result.Items
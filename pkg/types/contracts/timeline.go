@@ -0,0 +1,353 @@
+package contracts
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/rpcpool"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/store"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types/abis"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/config"
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+)
+
+// MaxTimelinePoints is the most blocks a timeline reads the contract at
+const MaxTimelinePoints = 250
+
+// TimelinePoint is the result of the dashboard's read functions at one block. A function
+// with more than one output has a result for each, named for the output or its position.
+type TimelinePoint struct {
+	Block     uint64            `json:"block"`
+	Timestamp int64             `json:"timestamp"`
+	Label     string            `json:"label"`
+	Results   map[string]any    `json:"results"`
+	Errors    map[string]string `json:"errors,omitempty"`
+}
+
+// ContractTimeline is a contract's state read at a series of blocks, oldest first. Keys
+// names the results in the order their functions appear in the ABI.
+type ContractTimeline struct {
+	Address string          `json:"address"`
+	Period  types.Period    `json:"period,omitempty"`
+	Keys    []string        `json:"keys"`
+	Points  []TimelinePoint `json:"points"`
+}
+
+// The latest timeline of each contract, by chain and address, which the dashboard facet's
+// buckets are drawn from
+var (
+	timelines   = make(map[string]*ContractTimeline)
+	timelinesMu sync.Mutex
+)
+
+func timelineKey(chain string, addr base.Address) string {
+	return chain + "_" + addr.Hex()
+}
+
+// ReadTimeline reads the contract at addr at each of blocks or, if there are none, at the
+// first block of each period from firstBlock to lastBlock, a lastBlock of zero meaning the
+// chain's head. The result is kept so the dashboard facet's buckets can chart it.
+func (c *ContractsCollection) ReadTimeline(chain string, addr base.Address, blocks []uint64, period types.Period, firstBlock, lastBlock uint64) (*ContractTimeline, error) {
+	rpcURL := rpcpool.Provider(chain, config.GetChain(chain).GetRpcProvider())
+
+	var points []TimelinePoint
+	var err error
+	if len(blocks) > 0 {
+		points, err = blockPoints(rpcURL, blocks)
+	} else {
+		points, err = periodPoints(rpcURL, firstBlock, lastBlock, period)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	abi, err := abis.GetAbi(chain, addr)
+	if err != nil {
+		return nil, fmt.Errorf("could not get the abi: %w", err)
+	}
+
+	ret := readTimeline(rpcURL, addr, abi, points)
+	if len(blocks) == 0 {
+		ret.Period = period
+	}
+
+	timelinesMu.Lock()
+	timelines[timelineKey(chain, addr)] = ret
+	timelinesMu.Unlock()
+	return ret, nil
+}
+
+// readTimeline calls each zero-argument view and pure function of abi on addr at every
+// point
+func readTimeline(rpcURL string, addr base.Address, abi *sdk.Abi, points []TimelinePoint) *ContractTimeline {
+	ret := &ContractTimeline{Address: addr.Hex(), Keys: []string{}, Points: points}
+
+	fns := []sdk.Function{}
+	for _, fn := range abi.Functions {
+		if isReadFunction(&fn) && len(fn.Outputs) > 0 {
+			fns = append(fns, fn)
+			ret.Keys = append(ret.Keys, resultKeys(&fn)...)
+		}
+	}
+
+	for i := range ret.Points {
+		point := &ret.Points[i]
+		point.Results = make(map[string]any)
+		block := fmt.Sprintf("0x%x", point.Block)
+		for _, fn := range fns {
+			// Each call decodes into its own copy of the outputs
+			fn.Outputs = append([]sdk.Parameter(nil), fn.Outputs...)
+			value, err := callFunction(rpcURL, addr, &fn, block)
+			if err != nil {
+				if point.Errors == nil {
+					point.Errors = make(map[string]string)
+				}
+				point.Errors[fn.Name] = err.Error()
+				continue
+			}
+			keys := resultKeys(&fn)
+			if len(keys) == 1 {
+				point.Results[keys[0]] = value
+				continue
+			}
+			for j, v := range value.([]any) {
+				point.Results[keys[j]] = v
+			}
+		}
+	}
+	return ret
+}
+
+// resultKeys names the results of fn: the function's name for a single output, otherwise
+// the name followed by each output's name or position
+func resultKeys(fn *sdk.Function) []string {
+	if len(fn.Outputs) == 1 {
+		return []string{fn.Name}
+	}
+	ret := make([]string, len(fn.Outputs))
+	for i, o := range fn.Outputs {
+		name := o.Name
+		if name == "" {
+			name = strconv.Itoa(i)
+		}
+		ret[i] = fn.Name + "." + name
+	}
+	return ret
+}
+
+// blockPoints returns a point for each of blocks, in order and without repeats
+func blockPoints(rpcURL string, blocks []uint64) ([]TimelinePoint, error) {
+	sorted := append([]uint64(nil), blocks...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	ret := []TimelinePoint{}
+	for i, bn := range sorted {
+		if i > 0 && bn == sorted[i-1] {
+			continue
+		}
+		if len(ret) == MaxTimelinePoints {
+			return nil, fmt.Errorf("too many blocks: a timeline reads at most %d", MaxTimelinePoints)
+		}
+		ts, err := blockTimestamp(rpcURL, bn)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, TimelinePoint{Block: bn, Timestamp: ts, Label: strconv.FormatUint(bn, 10)})
+	}
+	return ret, nil
+}
+
+// periodPoints returns a point at the first block of each period that starts between
+// firstBlock and lastBlock
+func periodPoints(rpcURL string, firstBlock, lastBlock uint64, period types.Period) ([]TimelinePoint, error) {
+	if period == "" || period == types.PeriodBlockly {
+		return nil, fmt.Errorf("choose the blocks or a period to read the contract at")
+	}
+	if lastBlock == 0 {
+		head, err := rpcpool.CallString(rpcURL, "eth_blockNumber")
+		if err != nil {
+			return nil, err
+		}
+		if lastBlock, err = parseHex(head); err != nil {
+			return nil, fmt.Errorf("bad block number %q", head)
+		}
+	}
+	if lastBlock < firstBlock {
+		return nil, fmt.Errorf("invalid block range %d-%d", firstBlock, lastBlock)
+	}
+
+	timestamps := map[uint64]int64{}
+	timestampOf := func(bn uint64) (int64, error) {
+		if ts, ok := timestamps[bn]; ok {
+			return ts, nil
+		}
+		ts, err := blockTimestamp(rpcURL, bn)
+		timestamps[bn] = ts
+		return ts, err
+	}
+
+	first, err := timestampOf(firstBlock)
+	if err != nil {
+		return nil, err
+	}
+	last, err := timestampOf(lastBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := []TimelinePoint{}
+	boundary := store.NormalizeToPeriod(first, period)
+	if boundary < first {
+		boundary = store.NextPeriodStart(first, period)
+	}
+	lo := firstBlock
+	for ; boundary <= last; boundary = store.NextPeriodStart(boundary, period) {
+		if len(ret) == MaxTimelinePoints {
+			return nil, fmt.Errorf("too many %s periods: a timeline reads at most %d", period, MaxTimelinePoints)
+		}
+		// The first block at or after the boundary, found by bisection
+		hi := lastBlock
+		for lo < hi {
+			mid := lo + (hi-lo)/2
+			ts, err := timestampOf(mid)
+			if err != nil {
+				return nil, err
+			}
+			if ts < boundary {
+				lo = mid + 1
+			} else {
+				hi = mid
+			}
+		}
+		ts, err := timestampOf(lo)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, TimelinePoint{Block: lo, Timestamp: ts, Label: periodLabel(boundary, period)})
+	}
+	return ret, nil
+}
+
+func periodLabel(ts int64, period types.Period) string {
+	t := time.Unix(ts, 0).UTC()
+	switch period {
+	case types.PeriodHourly:
+		return t.Format("2006-01-02 15:00")
+	case types.PeriodMonthly:
+		return t.Format("2006-01")
+	case types.PeriodQuarterly:
+		return fmt.Sprintf("%d-Q%d", t.Year(), (int(t.Month())-1)/3+1)
+	case types.PeriodAnnual:
+		return t.Format("2006")
+	default:
+		return t.Format("2006-01-02")
+	}
+}
+
+func blockTimestamp(rpcURL string, bn uint64) (int64, error) {
+	raw, err := rpcpool.Call(rpcURL, "eth_getBlockByNumber", fmt.Sprintf("0x%x", bn), false)
+	if err != nil {
+		return 0, err
+	}
+	var block struct {
+		Timestamp string `json:"timestamp"`
+	}
+	if err := json.Unmarshal(raw, &block); err != nil || block.Timestamp == "" {
+		return 0, fmt.Errorf("block %d not found", bn)
+	}
+	ts, err := parseHex(block.Timestamp)
+	if err != nil {
+		return 0, fmt.Errorf("bad timestamp %q for block %d", block.Timestamp, bn)
+	}
+	return int64(ts), nil
+}
+
+func parseHex(s string) (uint64, error) {
+	return strconv.ParseUint(strings.TrimPrefix(s, "0x"), 16, 64)
+}
+
+// withTimelineSeries returns buckets with a series for each result of the latest timeline
+// of contract on chain. A bucket spans from its point's block to the next point. Numbers and
+// booleans are charted as their value; anything else, such as an owner, as 1 where it
+// differs from the point before and 0 where it does not.
+func withTimelineSeries(buckets *types.Buckets, chain, contract string) *types.Buckets {
+	if !base.IsValidAddress(contract) {
+		return buckets
+	}
+	timelinesMu.Lock()
+	timeline := timelines[timelineKey(chain, base.HexToAddress(contract))]
+	timelinesMu.Unlock()
+	if timeline == nil || buckets == nil || len(timeline.Points) == 0 {
+		return buckets
+	}
+
+	ret := *buckets
+	ret.Series = make(map[string][]types.Bucket, len(buckets.Series)+len(timeline.Keys))
+	for name, series := range buckets.Series {
+		ret.Series[name] = series
+	}
+
+	points := timeline.Points
+	for _, key := range timeline.Keys {
+		series := make([]types.Bucket, 0, len(points))
+		var prev any
+		for i, point := range points {
+			end := point.Block
+			if i+1 < len(points) && points[i+1].Block > point.Block {
+				end = points[i+1].Block - 1
+			}
+			bucket := types.NewBucket(point.Label, point.Block, end)
+			value, ok := point.Results[key]
+			if ok {
+				if n, numeric := chartValue(value); numeric {
+					bucket.Total = n
+				} else if i > 0 && fmt.Sprint(value) != fmt.Sprint(prev) {
+					bucket.Total = 1
+				}
+				prev = value
+			}
+			bucket.ColorValue = bucket.Total
+			series = append(series, bucket)
+		}
+		ret.Series[key] = series
+	}
+
+	ret.GridInfo.BucketCount = len(points)
+	ret.GridInfo.MaxBlock = points[len(points)-1].Block
+	if len(points) > 1 {
+		ret.GridInfo.Size = (points[len(points)-1].Block - points[0].Block) / uint64(len(points)-1)
+	}
+	if ret.GridInfo.Columns > 0 {
+		ret.GridInfo.Rows = (ret.GridInfo.BucketCount + ret.GridInfo.Columns - 1) / ret.GridInfo.Columns
+	}
+	return &ret
+}
+
+// chartValue returns value as a number if it is one. Decoded integers are strings, as they
+// may not fit a float exactly.
+func chartValue(value any) (float64, bool) {
+	switch v := value.(type) {
+	case bool:
+		if v {
+			return 1, true
+		}
+		return 0, true
+	case float64:
+		return v, true
+	case string:
+		if strings.HasPrefix(v, "0x") {
+			return 0, false
+		}
+		n, err := strconv.ParseFloat(v, 64)
+		return n, err == nil
+	}
+	return 0, false
+}
@@ -0,0 +1,177 @@
+package contracts
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+)
+
+// One block an hour from the start of 2024, 24 * 70 blocks in all
+var chainStart = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Unix()
+
+const chainHead = 24 * 70
+
+// historyServer stands in for an archive node. totalSupply is ten times the block number
+// and owner changes at block 500.
+func historyServer(t *testing.T) string {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		reply := func(result string) {
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":` + result + `}`))
+		}
+
+		switch req.Method {
+		case "eth_blockNumber":
+			reply(fmt.Sprintf(`"0x%x"`, chainHead))
+		case "eth_getBlockByNumber":
+			var tag string
+			_ = json.Unmarshal(req.Params[0], &tag)
+			bn, _ := strconv.ParseUint(strings.TrimPrefix(tag, "0x"), 16, 64)
+			reply(fmt.Sprintf(`{"number":"%s","timestamp":"0x%x"}`, tag, chainStart+int64(bn)*3600))
+		case "eth_call":
+			var call struct {
+				Data string `json:"data"`
+			}
+			var tag string
+			_ = json.Unmarshal(req.Params[0], &call)
+			_ = json.Unmarshal(req.Params[1], &tag)
+			bn, _ := strconv.ParseUint(strings.TrimPrefix(tag, "0x"), 16, 64)
+			switch strings.TrimPrefix(call.Data, "0x") {
+			case "18160ddd": // totalSupply()
+				reply(`"0x` + word(strconv.FormatUint(bn*10, 16)) + `"`)
+			case "8da5cb5b": // owner()
+				owner := "1111111111111111111111111111111111111111"
+				if bn >= 500 {
+					owner = "2222222222222222222222222222222222222222"
+				}
+				reply(`"0x` + word(owner) + `"`)
+			default:
+				_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":3,"message":"execution reverted"}}`))
+			}
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+func historyAbi() *sdk.Abi {
+	view := func(name, output string) sdk.Function {
+		return sdk.Function{
+			Name:            name,
+			FunctionType:    "function",
+			StateMutability: "view",
+			Inputs:          []sdk.Parameter{},
+			Outputs:         []sdk.Parameter{{ParameterType: output}},
+		}
+	}
+	return &sdk.Abi{Functions: []sdk.Function{
+		view("totalSupply", "uint256"),
+		view("owner", "address"),
+		view("paused", "bool"),
+	}}
+}
+
+func TestTimelineAtBlocks(t *testing.T) {
+	url := historyServer(t)
+	points, err := blockPoints(url, []uint64{600, 100, 600, 400})
+	if err != nil {
+		t.Fatalf("blockPoints: %v", err)
+	}
+	if len(points) != 3 || points[0].Block != 100 || points[2].Block != 600 || points[1].Label != "400" {
+		t.Fatalf("Expected the blocks sorted without repeats, got %+v", points)
+	}
+
+	addr := base.HexToAddress("0x3333333333333333333333333333333333333333")
+	timeline := readTimeline(url, addr, historyAbi(), points)
+	if strings.Join(timeline.Keys, ",") != "totalSupply,owner,paused" {
+		t.Errorf("Unexpected keys %v", timeline.Keys)
+	}
+	if got := timeline.Points[1].Results["totalSupply"]; got != "4000" {
+		t.Errorf("Expected totalSupply at block 400, got %v", got)
+	}
+	if got := timeline.Points[2].Results["owner"]; got != "0x2222222222222222222222222222222222222222" {
+		t.Errorf("Expected the new owner at block 600, got %v", got)
+	}
+	if timeline.Points[0].Errors["paused"] != "execution reverted" {
+		t.Errorf("Expected the revert to be kept with its point, got %v", timeline.Points[0].Errors)
+	}
+}
+
+func TestTimelineByPeriod(t *testing.T) {
+	url := historyServer(t)
+	// Blocks 10 to 24*70 run from 10am on Jan 1 to Mar 11
+	points, err := periodPoints(url, 10, 0, types.PeriodMonthly)
+	if err != nil {
+		t.Fatalf("periodPoints: %v", err)
+	}
+	if len(points) != 2 {
+		t.Fatalf("Expected the starts of February and March, got %+v", points)
+	}
+	if points[0].Block != 31*24 || points[0].Label != "2024-02" || points[1].Block != 60*24 || points[1].Label != "2024-03" {
+		t.Errorf("Unexpected points %+v", points)
+	}
+
+	points, err = periodPoints(url, 0, 24*3, types.PeriodDaily)
+	if err != nil {
+		t.Fatalf("periodPoints: %v", err)
+	}
+	if len(points) != 4 || points[0].Block != 0 || points[3].Block != 72 || points[3].Label != "2024-01-04" {
+		t.Errorf("Expected each midnight including the first, got %+v", points)
+	}
+
+	if _, err := periodPoints(url, 0, 0, types.PeriodHourly); err == nil {
+		t.Error("Expected an error for more points than a timeline reads")
+	}
+	if _, err := periodPoints(url, 0, 0, types.PeriodBlockly); err == nil {
+		t.Error("Expected an error without a period")
+	}
+}
+
+func TestWithTimelineSeries(t *testing.T) {
+	url := historyServer(t)
+	addr := base.HexToAddress("0x3333333333333333333333333333333333333333")
+	points, _ := blockPoints(url, []uint64{100, 400, 600, 800})
+	timelinesMu.Lock()
+	timelines[timelineKey("timeline-test", addr)] = readTimeline(url, addr, historyAbi(), points)
+	timelinesMu.Unlock()
+	defer func() {
+		timelinesMu.Lock()
+		delete(timelines, timelineKey("timeline-test", addr))
+		timelinesMu.Unlock()
+	}()
+
+	buckets := types.NewBuckets()
+	got := withTimelineSeries(buckets, "timeline-test", addr.Hex())
+
+	supply := got.GetSeries("totalSupply")
+	if len(supply) != 4 || supply[1].Total != 4000 || supply[1].StartBlock != 400 || supply[1].EndBlock != 599 || supply[3].EndBlock != 800 {
+		t.Errorf("Unexpected totalSupply series %+v", supply)
+	}
+	owner := got.GetSeries("owner")
+	if len(owner) != 4 || owner[0].Total != 0 || owner[1].Total != 0 || owner[2].Total != 1 || owner[3].Total != 0 {
+		t.Errorf("Expected the owner change to be marked once, got %+v", owner)
+	}
+	if got.GridInfo.BucketCount != 4 || got.GridInfo.MaxBlock != 800 {
+		t.Errorf("Unexpected grid %+v", got.GridInfo)
+	}
+	if len(buckets.Series) != 0 {
+		t.Error("Expected the facet's buckets to be left alone")
+	}
+	if withTimelineSeries(buckets, "timeline-test", "not an address") != buckets {
+		t.Error("Expected the buckets unchanged without a contract")
+	}
+}
@@ -13,8 +13,8 @@ import (
 	"fmt"
 	"strings"
 
-	dalle "github.com/TrueBlocks/trueblocks-dalle/v6"
-	"github.com/TrueBlocks/trueblocks-dalle/v6/pkg/model"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/query"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/sorting"
 	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
 	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
 )
@@ -78,14 +78,15 @@ func (c *DressesCollection) GetPage(
 		facet := c.generatorFacet
 		var filterFunc func(*DalleDress) bool
 		if filter != "" {
-			filterFunc = func(item *DalleDress) bool {
-				return c.matchesGeneratorFilter(item, filter)
+			var err error
+			if filterFunc, err = query.NewFilter(filter, query.FacetFields(c, dataFacet), c.matchesGeneratorFilter); err != nil {
+				return nil, types.NewValidationError("dresses", dataFacet, "GetPage", err)
 			}
 		}
 		sortFunc := func(items []DalleDress, sort sdk.SortSpec) error {
-			return dalle.SortDalleDress(items, sort)
+			return sorting.SortBy(items, sort)
 		}
-		if result, err := facet.GetPage(first, pageSize, filterFunc, sortSpec, sortFunc); err != nil {
+		if result, err := facet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
 			return nil, types.NewStoreError("dresses", dataFacet, "GetPage", err)
 		} else {
 			page.DalleDress = result.Items
@@ -97,14 +98,15 @@ func (c *DressesCollection) GetPage(
 		facet := c.seriesFacet
 		var filterFunc func(*Series) bool
 		if filter != "" {
-			filterFunc = func(item *Series) bool {
-				return c.matchesSeriesFilter(item, filter)
+			var err error
+			if filterFunc, err = query.NewFilter(filter, query.FacetFields(c, dataFacet), c.matchesSeriesFilter); err != nil {
+				return nil, types.NewValidationError("dresses", dataFacet, "GetPage", err)
 			}
 		}
 		sortFunc := func(items []Series, sort sdk.SortSpec) error {
-			return dalle.SortSeries(items, sort)
+			return sorting.SortBy(items, sort)
 		}
-		if result, err := facet.GetPage(first, pageSize, filterFunc, sortSpec, sortFunc); err != nil {
+		if result, err := facet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
 			return nil, types.NewStoreError("dresses", dataFacet, "GetPage", err)
 		} else {
 			page.Series = result.Items
@@ -116,14 +118,15 @@ func (c *DressesCollection) GetPage(
 		facet := c.databasesFacet
 		var filterFunc func(*Database) bool
 		if filter != "" {
-			filterFunc = func(item *Database) bool {
-				return c.matchesDatabaseFilter(item, filter)
+			var err error
+			if filterFunc, err = query.NewFilter(filter, query.FacetFields(c, dataFacet), c.matchesDatabaseFilter); err != nil {
+				return nil, types.NewValidationError("dresses", dataFacet, "GetPage", err)
 			}
 		}
 		sortFunc := func(items []Database, sort sdk.SortSpec) error {
-			return dalle.SortDatabases(items, sort)
+			return sorting.SortBy(items, sort)
 		}
-		if result, err := facet.GetPage(first, pageSize, filterFunc, sortSpec, sortFunc); err != nil {
+		if result, err := facet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
 			return nil, types.NewStoreError("dresses", dataFacet, "GetPage", err)
 		} else {
 			page.Databases = result.Items
@@ -135,14 +138,15 @@ func (c *DressesCollection) GetPage(
 		facet := c.itemsFacet
 		var filterFunc func(*Item) bool
 		if filter != "" {
-			filterFunc = func(item *Item) bool {
-				return c.matchesItemFilter(item, filter)
+			var err error
+			if filterFunc, err = query.NewFilter(filter, query.FacetFields(c, dataFacet), c.matchesItemFilter); err != nil {
+				return nil, types.NewValidationError("dresses", dataFacet, "GetPage", err)
 			}
 		}
 		sortFunc := func(items []Item, sort sdk.SortSpec) error {
-			return model.SortItems(items, sort)
+			return sorting.SortBy(items, sort)
 		}
-		if result, err := facet.GetPage(first, pageSize, filterFunc, sortSpec, sortFunc); err != nil {
+		if result, err := facet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
 			return nil, types.NewStoreError("dresses", dataFacet, "GetPage", err)
 		} else {
 			page.Items = result.Items
@@ -154,14 +158,15 @@ func (c *DressesCollection) GetPage(
 		facet := c.eventsFacet
 		var filterFunc func(*Log) bool
 		if filter != "" {
-			filterFunc = func(item *Log) bool {
-				return c.matchesEventFilter(item, filter)
+			var err error
+			if filterFunc, err = query.NewFilter(filter, query.FacetFields(c, dataFacet), c.matchesEventFilter); err != nil {
+				return nil, types.NewValidationError("dresses", dataFacet, "GetPage", err)
 			}
 		}
 		sortFunc := func(items []Log, sort sdk.SortSpec) error {
-			return sdk.SortLogs(items, sort)
+			return sorting.SortBy(items, sort)
 		}
-		if result, err := facet.GetPage(first, pageSize, filterFunc, sortSpec, sortFunc); err != nil {
+		if result, err := facet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
 			return nil, types.NewStoreError("dresses", dataFacet, "GetPage", err)
 		} else {
 			page.Logs = result.Items
@@ -173,14 +178,15 @@ func (c *DressesCollection) GetPage(
 		facet := c.galleryFacet
 		var filterFunc func(*DalleDress) bool
 		if filter != "" {
-			filterFunc = func(item *DalleDress) bool {
-				return c.matchesGalleryFilter(item, filter)
+			var err error
+			if filterFunc, err = query.NewFilter(filter, query.FacetFields(c, dataFacet), c.matchesGalleryFilter); err != nil {
+				return nil, types.NewValidationError("dresses", dataFacet, "GetPage", err)
 			}
 		}
 		sortFunc := func(items []DalleDress, sort sdk.SortSpec) error {
-			return dalle.SortDalleDress(items, sort)
+			return sorting.SortBy(items, sort)
 		}
-		if result, err := facet.GetPage(first, pageSize, filterFunc, sortSpec, sortFunc); err != nil {
+		if result, err := facet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
 			return nil, types.NewStoreError("dresses", dataFacet, "GetPage", err)
 		} else {
 			page.DalleDress = result.Items
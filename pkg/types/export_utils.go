@@ -11,6 +11,26 @@ import (
 	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
 )
 
+// ExportFormats are the formats ExportData writes: comma separated, tab separated, and JSON
+var ExportFormats = []string{"csv", "txt", "json"}
+
+// IsExportFormat reports whether ExportData can write format
+func IsExportFormat(format string) bool {
+	for _, f := range ExportFormats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// ExportsFolder is the folder beside the project file at projectPath that exports are written to
+func ExportsFolder(projectPath string) string {
+	projectName := filepath.Base(projectPath)
+	projectNameWithoutExt := strings.TrimSuffix(projectName, filepath.Ext(projectName))
+	return filepath.Join(filepath.Dir(projectPath), projectNameWithoutExt+".Exports")
+}
+
 // ExportData is the unified export function that handles file creation with proper extension and format
 func ExportData[T any](data []T, payload *Payload, typeName string) (string, error) {
 	format := payload.Format
@@ -26,10 +46,7 @@ func ExportData[T any](data []T, payload *Payload, typeName string) (string, err
 		return "", fmt.Errorf("project path not provided in payload")
 	}
 
-	projectDir := filepath.Dir(payload.ProjectPath)
-	projectName := filepath.Base(payload.ProjectPath)
-	projectNameWithoutExt := strings.TrimSuffix(projectName, filepath.Ext(projectName))
-	outputDirPath := filepath.Join(projectDir, projectNameWithoutExt+".Exports")
+	outputDirPath := ExportsFolder(payload.ProjectPath)
 
 	// Construct filename from payload information
 	addressPart := "noaddr"
@@ -84,6 +84,19 @@ func timestampToDailyBucket(timestamp int64) string {
 	return fmt.Sprintf("%04d%02d%02d", t.Year(), t.Month(), t.Day())
 }
 
+// assetChartsConfig returns the assetcharts facet's chart configuration or the defaults
+func (c *ExportsCollection) assetChartsConfig() types.FacetChartConfig {
+	if viewConfig, err := c.GetConfig(); err == nil {
+		if facetConfig, exists := viewConfig.Facets["assetcharts"]; exists && facetConfig.FacetChartConfig != nil {
+			return *facetConfig.FacetChartConfig
+		}
+	}
+	return types.FacetChartConfig{
+		SeriesStrategy:  AddressWithSymbol,
+		SeriesPrefixLen: 12,
+	}
+}
+
 // updateStatementsBucket processes a single Statement and updates asset chart buckets incrementally
 func (c *ExportsCollection) updateStatementsBucket(statement *Statement) {
 	if statement == nil {
@@ -91,25 +104,7 @@ func (c *ExportsCollection) updateStatementsBucket(statement *Statement) {
 	}
 
 	c.assetchartsFacet.UpdateBuckets(func(buckets *types.Buckets) {
-		// Get the actual facet configuration
-		var config types.FacetChartConfig
-		if viewConfig, err := c.GetConfig(); err == nil {
-			if facetConfig, exists := viewConfig.Facets["assetcharts"]; exists && facetConfig.FacetChartConfig != nil {
-				config = *facetConfig.FacetChartConfig
-			} else {
-				// Fallback to defaults
-				config = types.FacetChartConfig{
-					SeriesStrategy:  AddressWithSymbol,
-					SeriesPrefixLen: 12,
-				}
-			}
-		} else {
-			// Fallback if config unavailable
-			config = types.FacetChartConfig{
-				SeriesStrategy:  AddressWithSymbol,
-				SeriesPrefixLen: 12,
-			}
-		}
+		config := c.assetChartsConfig()
 
 		// Generate asset identifier for this statement
 		assetIdentifier := generateAssetIdentifier(statement.Asset.Hex(), statement.Symbol, config)
@@ -130,7 +125,7 @@ func (c *ExportsCollection) updateStatementsBucket(statement *Statement) {
 		}
 
 		// Update each metric series incrementally
-		metricNames := []string{"frequency", "volume", "endBalEth"}
+		metricNames := []string{"frequency", "volume", "endBalEth", "value"}
 		for _, metricName := range metricNames {
 			seriesName := fmt.Sprintf("%s.%s", assetIdentifier, metricName)
 			buckets.EnsureSeriesExists(seriesName)
@@ -147,9 +142,12 @@ func (c *ExportsCollection) updateStatementsBucket(statement *Statement) {
 				amountOut := statementValueToFloat64(&statement.AmountOut, decimals)
 				volume := amountIn + amountOut
 				series[bucketIndex].Total += volume
-			case "endBal":
+			case "endBalEth":
 				endBal := statementValueToFloat64(&statement.EndBal, decimals)
 				series[bucketIndex].Total = endBal // EndBal is absolute, not cumulative
+			case "value":
+				// Like endBalEth, the value at the end of the day, in the configured currency
+				series[bucketIndex].Total = statementFiatValue(statement)
 				// case "neighbors":
 				//	 // Count unique counterparties (simplified - could track actual unique count)
 				//	 series[bucketIndex].Total += 1.0
@@ -22,6 +22,8 @@ func (c *ExportsCollection) GetBuckets(payload *types.Payload) (*types.Buckets,
 		facet = c.assetchartsFacet
 	case ExportsBalances:
 		facet = c.balancesFacet
+	case ExportsGains:
+		facet = c.gainsFacet
 	case ExportsTransfers:
 		facet = c.transfersFacet
 	case ExportsOpenApprovals:
@@ -22,6 +22,8 @@ func (c *ExportsCollection) GetConfig() (*types.ViewConfig, error) {
 		Actions:    c.buildActions(),
 	}
 
+	c.addDynamicFields(cfg)
+
 	types.DeriveFacets(cfg)
 	types.SortFields(cfg)
 	types.SetMenuOrder(cfg)
@@ -68,6 +70,16 @@ func (c *ExportsCollection) buildStaticFacets() map[string]types.FacetConfig {
 			Actions:       []string{},
 			HeaderActions: []string{"export"},
 		},
+		"gains": {
+			Name:          "Gains",
+			Store:         "gains",
+			ViewType:      "table",
+			Panel:         "custom",
+			DividerBefore: false,
+			Fields:        getGainsFields(),
+			Actions:       []string{},
+			HeaderActions: []string{"export"},
+		},
 		"transfers": {
 			Name:          "Transfers",
 			Store:         "transfers",
@@ -164,6 +176,7 @@ func (c *ExportsCollection) buildFacetOrder() []string {
 		"assets",
 		"assetcharts",
 		"balances",
+		"gains",
 		"transfers",
 		"openapprovals",
 		"approvaltxs",
@@ -306,6 +319,32 @@ func getBalancesFields() []types.FieldConfig {
 	return ret
 }
 
+func getGainsFields() []types.FieldConfig {
+	ret := []types.FieldConfig{
+		{Section: "Context", Key: "dateSold", Type: "timestamp"},
+		{Section: "Context", Key: "blockNumber", Type: "blknum"},
+		{Section: "Context", Key: "transactionIndex", Type: "txnum", NoTable: true},
+		{Section: "Context", Key: "transactionHash", Type: "hash", NoTable: true},
+		{Section: "Asset", Key: "asset", Type: "address"},
+		{Section: "Asset", Key: "assetName", Type: "string"},
+		{Section: "Asset", Key: "symbol", Type: "string"},
+		{Section: "Asset", Key: "decimals", Type: "value", NoTable: true},
+		{Section: "Lots", Key: "quantityEth", Type: "ether"},
+		{Section: "Lots", Key: "quantity", Type: "int256", NoTable: true},
+		{Section: "Lots", Key: "dateAcquired", Type: "timestamp"},
+		{Section: "Lots", Key: "various", Type: "bool", NoTable: true},
+		{Section: "Lots", Key: "term", Type: "string"},
+		{Section: "Lots", Key: "method", Type: "string", NoTable: true},
+		{Section: "Lots", Key: "unmatched", Type: "int256", NoTable: true},
+		{Section: "Gains", Key: "proceeds", Type: "float64"},
+		{Section: "Gains", Key: "costBasis", Type: "float64"},
+		{Section: "Gains", Key: "realizedGain", Type: "float64"},
+		{Section: "Gains", Key: "unrealizedGain", Type: "float64", NoTable: true},
+	}
+	types.NormalizeFields(&ret)
+	return ret
+}
+
 func getLogsFields() []types.FieldConfig {
 	ret := []types.FieldConfig{
 		{Section: "Context", Key: "blockNumber", Type: "blknum"},
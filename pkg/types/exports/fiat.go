@@ -0,0 +1,210 @@
+package exports
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/fiat"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
+)
+
+// FiatValue is the value of one row of a page in the configured currency. Price is for one
+// whole unit; Source says whether it came from the statement's spot price or the price
+// file, and is empty when no price is known.
+type FiatValue struct {
+	Currency string  `json:"currency"`
+	Price    float64 `json:"price"`
+	Value    float64 `json:"value"`
+	Source   string  `json:"source"`
+}
+
+var (
+	fiatSource   func() *fiat.Converter
+	fiatSourceMu sync.RWMutex
+)
+
+// SetFiatSource installs the function the exports collection calls to find the converter
+// used to value balances, assets and charts. Without one, values are in USD and come from
+// spot prices alone.
+func SetFiatSource(fn func() *fiat.Converter) {
+	fiatSourceMu.Lock()
+	defer fiatSourceMu.Unlock()
+	fiatSource = fn
+}
+
+func currentConverter() *fiat.Converter {
+	fiatSourceMu.RLock()
+	fn := fiatSource
+	fiatSourceMu.RUnlock()
+	if fn != nil {
+		if conv := fn(); conv != nil {
+			return conv
+		}
+	}
+	return fiat.NewConverter(fiat.DefaultCurrency)
+}
+
+// AddFiatValues fills page.Fiat with the value of each of the page's statements, assets
+// or balances. Other facets are left without values.
+func (c *ExportsCollection) AddFiatValues(page *ExportsPage) {
+	if page == nil {
+		return
+	}
+	conv := currentConverter()
+	page.Currency = conv.Currency()
+
+	switch page.Facet {
+	case ExportsStatements, ExportsAssetCharts:
+		page.Fiat = make([]FiatValue, len(page.Statements))
+		for i := range page.Statements {
+			page.Fiat[i] = valueStatement(conv, &page.Statements[i])
+		}
+	case ExportsAssets:
+		page.Fiat = make([]FiatValue, len(page.Assets))
+		for i := range page.Assets {
+			page.Fiat[i] = valueStatement(conv, &page.Assets[i])
+		}
+	case ExportsBalances:
+		prices := newSpotPrices(c.statementsFacet.GetStore().GetItems(false))
+		page.Fiat = make([]FiatValue, len(page.Balances))
+		for i := range page.Balances {
+			page.Fiat[i] = valueBalance(conv, &page.Balances[i], prices)
+		}
+	}
+}
+
+// spotPrices holds, for each asset, the statements that carry a spot price in block order
+type spotPrices map[base.Address][]*Statement
+
+func newSpotPrices(statements []*Statement) spotPrices {
+	ret := make(spotPrices)
+	for _, stmt := range statements {
+		if stmt.SpotPrice.Float64() > 0 {
+			ret[stmt.Asset] = append(ret[stmt.Asset], stmt)
+		}
+	}
+	for _, list := range ret {
+		sort.SliceStable(list, func(i, j int) bool {
+			return list[i].BlockNumber < list[j].BlockNumber
+		})
+	}
+	return ret
+}
+
+// latest returns the asset's last statement at or before block, or nil if there is none
+func (p spotPrices) latest(asset base.Address, block base.Blknum) *Statement {
+	list := p[asset]
+	i := sort.Search(len(list), func(i int) bool {
+		return list[i].BlockNumber > block
+	})
+	if i == 0 {
+		return nil
+	}
+	return list[i-1]
+}
+
+// valueStatement values a statement's ending balance at the statement's own price
+func valueStatement(conv *fiat.Converter, stmt *Statement) FiatValue {
+	units := statementValueToFloat64(&stmt.EndBal, decimalsOrDefault(uint64(stmt.Decimals)))
+	value, quote := conv.Value(stmt.Asset, stmt.Symbol, stmt.Timestamp, stmt.SpotPrice.Float64(), units)
+	return FiatValue{Currency: quote.Currency, Price: quote.Price, Value: value, Source: quote.Source}
+}
+
+// valueBalance values a token balance. Balances carry no price, so the spot price of the
+// asset's latest statement at or before the balance's block is used.
+func valueBalance(conv *fiat.Converter, bal *Balance, prices spotPrices) FiatValue {
+	latest := prices.latest(bal.Address, bal.BlockNumber)
+	ts, spot := bal.Timestamp, 0.0
+	if latest != nil {
+		spot = latest.SpotPrice.Float64()
+		if ts == 0 {
+			ts = latest.Timestamp
+		}
+	}
+	units := statementValueToFloat64(&bal.Balance, decimalsOrDefault(bal.Decimals))
+	value, quote := conv.Value(bal.Address, bal.Symbol, ts, spot, units)
+	return FiatValue{Currency: quote.Currency, Price: quote.Price, Value: value, Source: quote.Source}
+}
+
+// statementFiatValue is the value plotted in an asset chart's value series
+func statementFiatValue(stmt *Statement) float64 {
+	return valueStatement(currentConverter(), stmt).Value
+}
+
+// RevalueCharts rebuilds the value series of every open asset chart. The series are
+// valued as statements arrive, so they must be rebuilt when the currency or price file
+// changes.
+func RevalueCharts() {
+	collectionsMu.Lock()
+	list := make([]*ExportsCollection, 0, len(collections))
+	for _, c := range collections {
+		list = append(list, c)
+	}
+	collectionsMu.Unlock()
+
+	conv := currentConverter()
+	for _, c := range list {
+		c.revalueCharts(conv)
+	}
+}
+
+// revalueCharts replaces the collection's value series with ones valued by conv
+func (c *ExportsCollection) revalueCharts(conv *fiat.Converter) {
+	statements := c.statementsFacet.GetStore().GetItems(false)
+	config := c.assetChartsConfig()
+
+	values := make(map[string][]types.Bucket)
+	for _, stmt := range statements {
+		seriesName := generateAssetIdentifier(stmt.Asset.Hex(), stmt.Symbol, config) + ".value"
+		series := values[seriesName]
+		bucketIndex := findOrCreateBucket(&series, timestampToDailyBucket(int64(stmt.Timestamp)))
+		series[bucketIndex].Total = valueStatement(conv, stmt).Value
+		values[seriesName] = series
+	}
+
+	c.assetchartsFacet.UpdateBuckets(func(buckets *types.Buckets) {
+		for seriesName := range buckets.Series {
+			if strings.HasSuffix(seriesName, ".value") {
+				delete(buckets.Series, seriesName)
+			}
+		}
+		for seriesName, series := range values {
+			buckets.SetSeries(seriesName, series)
+		}
+	})
+}
+
+func decimalsOrDefault(decimals uint64) int {
+	if decimals == 0 {
+		return 18
+	}
+	return int(decimals)
+}
+
+// addDynamicFields adds the columns whose labels follow the user's chosen currency
+func (c *ExportsCollection) addDynamicFields(cfg *types.ViewConfig) {
+	addFiatFields(cfg)
+}
+
+// addFiatFields adds the price and value columns to the facets AddFiatValues fills. The
+// values travel beside the rows in ExportsPage.Fiat rather than on them, so the columns
+// can be neither sorted nor filtered.
+func addFiatFields(cfg *types.ViewConfig) {
+	currency := currentConverter().Currency()
+	for _, name := range []string{"statements", "assets", "balances"} {
+		facet, ok := cfg.Facets[name]
+		if !ok {
+			continue
+		}
+		facet.Fields = append(facet.Fields,
+			types.FieldConfig{Section: "Value", Key: "fiatPrice", Type: "float64", Label: fmt.Sprintf("Price (%s)", currency), NoFilter: true},
+			types.FieldConfig{Section: "Value", Key: "fiatValue", Type: "float64", Label: fmt.Sprintf("Value (%s)", currency), NoFilter: true},
+			types.FieldConfig{Section: "Value", Key: "fiatSource", Type: "string", Label: "Price Source", NoTable: true, NoFilter: true},
+		)
+		types.NormalizeFields(&facet.Fields)
+		cfg.Facets[name] = facet
+	}
+}
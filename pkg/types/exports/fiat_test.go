@@ -0,0 +1,36 @@
+package exports
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/fiat"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+)
+
+func TestValueBalanceUsesLatestSpotPrice(t *testing.T) {
+	token := base.HexToAddress("0x6b175474e89094c44da98b954eedeac495271d0f")
+	statements := newSpotPrices([]*Statement{
+		{Asset: token, BlockNumber: 30, Timestamp: 1_700_200_000, SpotPrice: *base.NewFloat(9.0)},
+		{Asset: token, BlockNumber: 20, Timestamp: 1_700_100_000, SpotPrice: *base.NewFloat(2.0)},
+		{Asset: token, BlockNumber: 22, Timestamp: 1_700_120_000},
+		{Asset: token, BlockNumber: 10, Timestamp: 1_700_000_000, SpotPrice: *base.NewFloat(1.5)},
+	})
+	bal := &Balance{Address: token, BlockNumber: 25, Decimals: 2, Balance: *base.NewWei(300)}
+
+	got := valueBalance(fiat.NewConverter(""), bal, statements)
+	if got.Source != fiat.SourceSpot || got.Price != 2.0 || got.Value != 6.0 {
+		t.Errorf("expected the price at block 20, got %+v", got)
+	}
+
+	conv := fiat.NewConverter("EUR")
+	if err := conv.Load(strings.NewReader("asset,date,price\nDAI,2023-01-01,0.5\n")); err != nil {
+		t.Fatal(err)
+	}
+	bal.Symbol = "DAI"
+	got = valueBalance(conv, bal, statements)
+	if got.Source != fiat.SourceFile || got.Value != 1.5 || got.Currency != "EUR" {
+		t.Errorf("expected the price file to be used when spot prices cannot be converted, got %+v", got)
+	}
+}
@@ -0,0 +1,205 @@
+package exports
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/costbasis"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/store"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+)
+
+// Gain is one row of a capital gains report: the part of a disposal that falls in a single
+// holding term, matched against the lots it consumed. Quantity and Unmatched are in the
+// asset's smallest unit; money is in USD at the statement's spot price.
+type Gain struct {
+	Asset            base.Address   `json:"asset"`
+	AssetName        string         `json:"assetName,omitempty"`
+	Symbol           string         `json:"symbol"`
+	Decimals         uint64         `json:"decimals"`
+	BlockNumber      base.Blknum    `json:"blockNumber"`
+	TransactionIndex base.Txnum     `json:"transactionIndex"`
+	TransactionHash  base.Hash      `json:"transactionHash"`
+	DateAcquired     base.Timestamp `json:"dateAcquired"`
+	Various          bool           `json:"various"`
+	DateSold         base.Timestamp `json:"dateSold"`
+	Term             string         `json:"term"`
+	Method           string         `json:"method"`
+	Quantity         base.Wei       `json:"quantity"`
+	QuantityEth      string         `json:"quantityEth"`
+	Unmatched        base.Wei       `json:"unmatched"`
+	Proceeds         float64        `json:"proceeds"`
+	CostBasis        float64        `json:"costBasis"`
+	RealizedGain     float64        `json:"realizedGain"`
+	UnrealizedGain   float64        `json:"unrealizedGain"`
+}
+
+// Model lays the row out in the column order of IRS Form 8949 (description, date acquired,
+// date sold, proceeds, cost basis, adjustment code and amount, gain) followed by the
+// details the form does not carry
+func (g *Gain) Model(chain, format string, verbose bool, extraOpts map[string]any) sdk.Model {
+	acquired := formatGainDate(g.DateAcquired)
+	if g.Various {
+		acquired = "VARIOUS"
+	} else if g.DateAcquired == 0 {
+		acquired = ""
+	}
+	adjustmentCode := ""
+	if g.Unmatched.BigInt().Sign() > 0 {
+		adjustmentCode = "B" // basis reported here is not known
+	}
+
+	return sdk.Model{
+		Data: map[string]any{
+			"description":     fmt.Sprintf("%s %s", g.QuantityEth, g.Symbol),
+			"dateAcquired":    acquired,
+			"dateSold":        formatGainDate(g.DateSold),
+			"proceeds":        fmt.Sprintf("%.2f", g.Proceeds),
+			"costBasis":       fmt.Sprintf("%.2f", g.CostBasis),
+			"adjustmentCode":  adjustmentCode,
+			"adjustment":      "0.00",
+			"realizedGain":    fmt.Sprintf("%.2f", g.RealizedGain),
+			"term":            g.Term,
+			"asset":           g.Asset.Hex(),
+			"symbol":          g.Symbol,
+			"quantity":        g.Quantity.String(),
+			"unrealizedGain":  fmt.Sprintf("%.2f", g.UnrealizedGain),
+			"blockNumber":     g.BlockNumber,
+			"transactionHash": g.TransactionHash.Hex(),
+			"method":          g.Method,
+			"unmatched":       g.Unmatched.String(),
+		},
+		Order: []string{
+			"description", "dateAcquired", "dateSold", "proceeds", "costBasis",
+			"adjustmentCode", "adjustment", "realizedGain", "term", "asset", "symbol",
+			"quantity", "unrealizedGain", "blockNumber", "transactionHash", "method", "unmatched",
+		},
+	}
+}
+
+func formatGainDate(ts base.Timestamp) string {
+	return time.Unix(int64(ts), 0).UTC().Format("01/02/2006")
+}
+
+var (
+	costBasisMethod   func() string
+	costBasisMethodMu sync.RWMutex
+)
+
+// SetCostBasisSource installs the function the gains facet calls to learn which cost
+// basis method (fifo, lifo, or hifo) to use. Without one, or if it returns something
+// unknown, the facet uses FIFO.
+func SetCostBasisSource(fn func() string) {
+	costBasisMethodMu.Lock()
+	defer costBasisMethodMu.Unlock()
+	costBasisMethod = fn
+}
+
+func currentCostBasisMethod() costbasis.Method {
+	costBasisMethodMu.RLock()
+	fn := costBasisMethod
+	costBasisMethodMu.RUnlock()
+	if fn == nil {
+		return costbasis.FIFO
+	}
+	method, err := costbasis.ParseMethod(fn())
+	if err != nil {
+		return costbasis.FIFO
+	}
+	return method
+}
+
+// resetGainsSummary clears the gains totals before the facet is rebuilt (after a change of
+// method, for example) so they are not counted twice
+func (c *ExportsCollection) resetGainsSummary() {
+	c.summaryMutex.Lock()
+	defer c.summaryMutex.Unlock()
+	if c.summary.FacetCounts != nil {
+		delete(c.summary.FacetCounts, ExportsGains)
+	}
+	if c.summary.CustomData != nil {
+		delete(c.summary.CustomData, "realizedGain")
+	}
+}
+
+// gainsInvalidator watches the statements a gains store is built from and marks the gains
+// stale as soon as the statements start to change (a reload, a refresh for newer blocks,
+// or a reset), so the report is rebuilt from the new statements when next shown
+type gainsInvalidator struct {
+	gains *store.Store[Gain]
+}
+
+func (g *gainsInvalidator) OnNewItem(item *Statement, index int) {}
+
+func (g *gainsInvalidator) OnStateChanged(state types.StoreState, reason string) {
+	switch state {
+	case types.StateStale, types.StateFetching, types.StateCached:
+		if g.gains.GetState() == types.StateLoaded {
+			g.gains.MarkStale("statements changed: " + reason)
+		}
+	}
+}
+
+// buildGains matches the outflows in statements against the lots created by their inflows
+func buildGains(statements []*Statement, method costbasis.Method) []*Gain {
+	events := make([]costbasis.Event, 0, len(statements))
+	symbols := make(map[base.Address]string)
+	for _, stmt := range statements {
+		if stmt == nil {
+			continue
+		}
+		in, out := stmt.TotalIn(), stmt.TotalOut()
+		events = append(events, costbasis.Event{
+			Asset:     stmt.Asset,
+			Decimals:  uint64(stmt.Decimals),
+			Block:     stmt.BlockNumber,
+			TxIndex:   stmt.TransactionIndex,
+			Timestamp: stmt.Timestamp,
+			Hash:      stmt.TransactionHash,
+			In:        new(big.Int).Set(in.BigInt()),
+			Out:       new(big.Int).Set(out.BigInt()),
+			Price:     stmt.SpotPrice.Float64(),
+		})
+		if stmt.Symbol != "" {
+			symbols[stmt.Asset] = stmt.Symbol
+		}
+	}
+
+	disposals := costbasis.Compute(method, events)
+	ret := make([]*Gain, 0, len(disposals))
+	for _, d := range disposals {
+		gain := &Gain{
+			Asset:            d.Event.Asset,
+			Symbol:           symbols[d.Event.Asset],
+			Decimals:         d.Event.Decimals,
+			BlockNumber:      d.Event.Block,
+			TransactionIndex: d.Event.TxIndex,
+			TransactionHash:  d.Event.Hash,
+			DateAcquired:     d.Acquired,
+			Various:          d.Various,
+			DateSold:         d.Event.Timestamp,
+			Term:             string(d.Term),
+			Method:           string(method),
+			Quantity:         toWei(d.Quantity),
+			QuantityEth:      (*base.Wei)(d.Quantity).ToFloatString(int(d.Event.Decimals)),
+			Proceeds:         d.Proceeds,
+			CostBasis:        d.CostBasis,
+			RealizedGain:     d.Realized,
+			UnrealizedGain:   d.Unrealized,
+		}
+		if d.Unmatched != nil {
+			gain.Unmatched = toWei(d.Unmatched)
+		}
+		ret = append(ret, gain)
+	}
+	return ret
+}
+
+func toWei(x *big.Int) base.Wei {
+	return base.Wei(*new(big.Int).Set(x))
+}
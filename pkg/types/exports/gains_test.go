@@ -0,0 +1,72 @@
+package exports
+
+import (
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/costbasis"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/store"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+)
+
+func gainStatement(block uint64, ts int64, in, out int64, price float64) *Statement {
+	return &Statement{
+		Asset:       base.HexToAddress("0xeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeee"),
+		Symbol:      "WEI",
+		BlockNumber: base.Blknum(block),
+		Timestamp:   base.Timestamp(ts),
+		AmountIn:    *base.NewWei(in),
+		AmountOut:   *base.NewWei(out),
+		SpotPrice:   *base.NewFloat(price),
+	}
+}
+
+func TestBuildGainsFromStatements(t *testing.T) {
+	statements := []*Statement{
+		gainStatement(1, 1_600_000_000, 10, 0, 2),
+		gainStatement(2, 1_610_000_000, 10, 0, 4),
+		gainStatement(3, 1_620_000_000, 0, 15, 5),
+	}
+
+	gains := buildGains(statements, costbasis.HIFO)
+	if len(gains) != 1 {
+		t.Fatalf("expected one short-term disposal, got %d", len(gains))
+	}
+	g := gains[0]
+	if g.Term != "short" || !g.Various || g.Method != "hifo" {
+		t.Errorf("unexpected gain: term=%s various=%v method=%s", g.Term, g.Various, g.Method)
+	}
+	// HIFO sells the ten at 4 before five of the ten at 2
+	if g.Proceeds != 75 || g.CostBasis != 50 || g.RealizedGain != 25 {
+		t.Errorf("proceeds=%v costBasis=%v realized=%v", g.Proceeds, g.CostBasis, g.RealizedGain)
+	}
+	if g.UnrealizedGain != 15 {
+		t.Errorf("unrealized=%v, want 15 on the five units still held at 2", g.UnrealizedGain)
+	}
+
+	model := g.Model("mainnet", "csv", false, nil)
+	if model.Order[0] != "description" || model.Data["dateAcquired"] != "VARIOUS" || model.Data["proceeds"] != "75.00" {
+		t.Errorf("model does not follow the Form 8949 layout: %v", model.Data)
+	}
+}
+
+func TestGainsGoStaleWhenStatementsChange(t *testing.T) {
+	statements := store.NewStore[Statement]("test-gains-statements", nil, nil, nil)
+	gains := store.NewStore[Gain]("test-gains", nil, nil, nil)
+	statements.RegisterObserver(&gainsInvalidator{gains: gains})
+
+	for _, state := range []types.StoreState{types.StateFetching, types.StateCached, types.StateStale} {
+		gains.ChangeState(types.StateLoaded, "")
+		statements.ChangeState(state, "test")
+		if got := gains.GetState(); got != types.StateStale {
+			t.Errorf("statements %s: gains are %s, want stale", state, got)
+		}
+	}
+
+	gains.ChangeState(types.StateLoaded, "")
+	statements.ChangeState(types.StateLoaded, "test")
+	if got := gains.GetState(); got != types.StateLoaded {
+		t.Errorf("statements finishing a load should not touch the gains, got %s", got)
+	}
+}
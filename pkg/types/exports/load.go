@@ -0,0 +1,74 @@
+package exports
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/facets"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
+)
+
+// LoadStatements returns every statement for address on chain, fetching the address's
+// statements first if they are not already loaded. It lets other collections (the
+// portfolio, for example) build on the same stores the Exports view uses.
+func LoadStatements(chain, address string) ([]*Statement, error) {
+	payload := &types.Payload{
+		Collection:    "exports",
+		DataFacet:     ExportsStatements,
+		ActiveChain:   chain,
+		ActiveAddress: address,
+	}
+	return loadItems(GetExportsCollection(payload).statementsFacet)
+}
+
+// LoadBalances returns every token balance for address on chain, fetching them first
+// if they are not already loaded
+func LoadBalances(chain, address string) ([]*Balance, error) {
+	payload := &types.Payload{
+		Collection:    "exports",
+		DataFacet:     ExportsBalances,
+		ActiveChain:   chain,
+		ActiveAddress: address,
+	}
+	return loadItems(GetExportsCollection(payload).balancesFacet)
+}
+
+// loadItems returns every item in facet's store once it is loaded. A facet that is not
+// loaded is fetched the same way the UI fetches it, so a load already in progress is
+// waited on rather than cancelled and restarted.
+func loadItems[T any](facet *facets.Facet[T]) ([]*T, error) {
+	theStore := facet.GetStore()
+	waiter := &loadWaiter[T]{done: make(chan types.StoreState, 1)}
+	theStore.RegisterObserver(waiter)
+	defer theStore.UnregisterObserver(waiter)
+
+	if theStore.GetState() != types.StateLoaded {
+		if err := facet.FetchFacet(); err != nil && !errors.Is(err, facets.ErrAlreadyLoading) {
+			return nil, err
+		}
+		if theStore.GetState() != types.StateLoaded {
+			if state := <-waiter.done; state != types.StateLoaded {
+				return nil, fmt.Errorf("%s did not finish loading", theStore.GetContextKey())
+			}
+		}
+	}
+	return theStore.GetItems(false), nil
+}
+
+// loadWaiter reports the first state a store settles in: loaded, or stale if its fetch
+// failed or was reset
+type loadWaiter[T any] struct {
+	done chan types.StoreState
+}
+
+func (w *loadWaiter[T]) OnNewItem(item *T, index int) {}
+
+func (w *loadWaiter[T]) OnStateChanged(state types.StoreState, reason string) {
+	if state != types.StateLoaded && state != types.StateStale {
+		return
+	}
+	select {
+	case w.done <- state:
+	default:
+	}
+}
@@ -0,0 +1,70 @@
+package exports
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/facets"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/store"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadItemsWaitsForInFlightFetch(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	theStore := store.NewStore("test-load-items",
+		func(ctx *output.RenderCtx) error {
+			calls.Add(1)
+			go func() {
+				defer close(ctx.ModelChan)
+				defer close(ctx.ErrorChan)
+				<-release
+				ctx.ModelChan <- &Statement{BlockNumber: 1}
+				ctx.ModelChan <- &Statement{BlockNumber: 2}
+			}()
+			return nil
+		},
+		func(item interface{}) *Statement {
+			it, _ := item.(*Statement)
+			return it
+		},
+		nil)
+	facet := facets.NewFacet[Statement](ExportsStatements, nil, nil, theStore, "exports", nil, false)
+
+	// The UI starts the load, and another collection asks for the rows while it runs
+	require.NoError(t, facet.FetchFacet())
+	require.Eventually(t, func() bool { return calls.Load() == 1 }, time.Second, time.Millisecond)
+
+	type result struct {
+		items []*Statement
+		err   error
+	}
+	done := make(chan result, 1)
+	go func() {
+		items, err := loadItems(facet)
+		done <- result{items, err}
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	select {
+	case got := <-done:
+		require.NoError(t, got.err)
+		assert.Len(t, got.items, 2)
+	case <-time.After(2 * time.Second):
+		t.Fatal("loadItems did not return")
+	}
+	assert.Equal(t, int32(1), calls.Load(), "the in-flight fetch is not restarted")
+	assert.Equal(t, types.StateLoaded, theStore.GetState())
+
+	items, err := loadItems(facet)
+	require.NoError(t, err)
+	assert.Len(t, items, 2)
+	assert.Equal(t, int32(1), calls.Load(), "a loaded store is not fetched again")
+}
@@ -14,6 +14,8 @@ import (
 	"strings"
 
 	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/query"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/sorting"
 	storePkg "github.com/TrueBlocks/trueblocks-explorer/pkg/store"
 	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
 	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
@@ -27,6 +29,7 @@ type ExportsPage struct {
 	ApprovalTxs   []ApprovalTx     `json:"approvaltxs"`
 	Assets        []Asset          `json:"assets"`
 	Balances      []Balance        `json:"balances"`
+	Gains         []Gain           `json:"gains"`
 	Logs          []Log            `json:"logs"`
 	OpenApprovals []OpenApproval   `json:"openapprovals"`
 	Receipts      []Receipt        `json:"receipts"`
@@ -39,6 +42,8 @@ type ExportsPage struct {
 	ExpectedTotal int              `json:"expectedTotal"`
 	State         types.StoreState `json:"state"`
 	// EXISTING_CODE
+	Currency string      `json:"currency,omitempty"`
+	Fiat     []FiatValue `json:"fiat,omitempty"`
 	// EXISTING_CODE
 }
 
@@ -81,14 +86,15 @@ func (c *ExportsCollection) GetPage(
 		facet := c.statementsFacet
 		var filterFunc func(*Statement) bool
 		if filter != "" {
-			filterFunc = func(item *Statement) bool {
-				return c.matchesStatementFilter(item, filter)
+			var err error
+			if filterFunc, err = query.NewFilter(filter, query.FacetFields(c, dataFacet), c.matchesStatementFilter); err != nil {
+				return nil, types.NewValidationError("exports", dataFacet, "GetPage", err)
 			}
 		}
 		sortFunc := func(items []Statement, sort sdk.SortSpec) error {
-			return sdk.SortStatements(items, sort)
+			return sorting.SortBy(items, sort)
 		}
-		if result, err := facet.GetPage(first, pageSize, filterFunc, sortSpec, sortFunc); err != nil {
+		if result, err := facet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
 			return nil, types.NewStoreError("exports", dataFacet, "GetPage", err)
 		} else {
 			page.Statements = result.Items
@@ -100,14 +106,15 @@ func (c *ExportsCollection) GetPage(
 		facet := c.assetsFacet
 		var filterFunc func(*Asset) bool
 		if filter != "" {
-			filterFunc = func(item *Asset) bool {
-				return c.matchesAssetFilter(item, filter)
+			var err error
+			if filterFunc, err = query.NewFilter(filter, query.FacetFields(c, dataFacet), c.matchesAssetFilter); err != nil {
+				return nil, types.NewValidationError("exports", dataFacet, "GetPage", err)
 			}
 		}
 		sortFunc := func(items []Asset, sort sdk.SortSpec) error {
-			return sdk.SortAssets(items, sort)
+			return sorting.SortBy(items, sort)
 		}
-		if result, err := facet.GetPage(first, pageSize, filterFunc, sortSpec, sortFunc); err != nil {
+		if result, err := facet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
 			return nil, types.NewStoreError("exports", dataFacet, "GetPage", err)
 		} else {
 			page.Assets = result.Items
@@ -119,14 +126,15 @@ func (c *ExportsCollection) GetPage(
 		facet := c.assetchartsFacet
 		var filterFunc func(*Statement) bool
 		if filter != "" {
-			filterFunc = func(item *Statement) bool {
-				return c.matchesAssetChartFilter(item, filter)
+			var err error
+			if filterFunc, err = query.NewFilter(filter, query.FacetFields(c, dataFacet), c.matchesAssetChartFilter); err != nil {
+				return nil, types.NewValidationError("exports", dataFacet, "GetPage", err)
 			}
 		}
 		sortFunc := func(items []Statement, sort sdk.SortSpec) error {
-			return sdk.SortStatements(items, sort)
+			return sorting.SortBy(items, sort)
 		}
-		if result, err := facet.GetPage(first, pageSize, filterFunc, sortSpec, sortFunc); err != nil {
+		if result, err := facet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
 			return nil, types.NewStoreError("exports", dataFacet, "GetPage", err)
 		} else {
 			page.Statements = result.Items
@@ -138,14 +146,15 @@ func (c *ExportsCollection) GetPage(
 		facet := c.balancesFacet
 		var filterFunc func(*Balance) bool
 		if filter != "" {
-			filterFunc = func(item *Balance) bool {
-				return c.matchesBalanceFilter(item, filter)
+			var err error
+			if filterFunc, err = query.NewFilter(filter, query.FacetFields(c, dataFacet), c.matchesBalanceFilter); err != nil {
+				return nil, types.NewValidationError("exports", dataFacet, "GetPage", err)
 			}
 		}
 		sortFunc := func(items []Balance, sort sdk.SortSpec) error {
-			return sdk.SortBalances(items, sort)
+			return sorting.SortBy(items, sort)
 		}
-		if result, err := facet.GetPage(first, pageSize, filterFunc, sortSpec, sortFunc); err != nil {
+		if result, err := facet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
 			return nil, types.NewStoreError("exports", dataFacet, "GetPage", err)
 		} else {
 			page.Balances = result.Items
@@ -153,18 +162,39 @@ func (c *ExportsCollection) GetPage(
 			page.State = result.State
 		}
 		page.ExpectedTotal = facet.ExpectedCount()
+	case ExportsGains:
+		facet := c.gainsFacet
+		var filterFunc func(*Gain) bool
+		if filter != "" {
+			var err error
+			if filterFunc, err = query.NewFilter(filter, query.FacetFields(c, dataFacet), c.matchesGainFilter); err != nil {
+				return nil, types.NewValidationError("exports", dataFacet, "GetPage", err)
+			}
+		}
+		sortFunc := func(items []Gain, sort sdk.SortSpec) error {
+			return sorting.SortBy(items, sort)
+		}
+		if result, err := facet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
+			return nil, types.NewStoreError("exports", dataFacet, "GetPage", err)
+		} else {
+			page.Gains = result.Items
+			page.TotalItems = result.TotalItems
+			page.State = result.State
+		}
+		page.ExpectedTotal = facet.ExpectedCount()
 	case ExportsTransfers:
 		facet := c.transfersFacet
 		var filterFunc func(*Transfer) bool
 		if filter != "" {
-			filterFunc = func(item *Transfer) bool {
-				return c.matchesTransferFilter(item, filter)
+			var err error
+			if filterFunc, err = query.NewFilter(filter, query.FacetFields(c, dataFacet), c.matchesTransferFilter); err != nil {
+				return nil, types.NewValidationError("exports", dataFacet, "GetPage", err)
 			}
 		}
 		sortFunc := func(items []Transfer, sort sdk.SortSpec) error {
-			return sdk.SortTransfers(items, sort)
+			return sorting.SortBy(items, sort)
 		}
-		if result, err := facet.GetPage(first, pageSize, filterFunc, sortSpec, sortFunc); err != nil {
+		if result, err := facet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
 			return nil, types.NewStoreError("exports", dataFacet, "GetPage", err)
 		} else {
 			page.Transfers = result.Items
@@ -176,14 +206,15 @@ func (c *ExportsCollection) GetPage(
 		facet := c.openapprovalsFacet
 		var filterFunc func(*OpenApproval) bool
 		if filter != "" {
-			filterFunc = func(item *OpenApproval) bool {
-				return c.matchesOpenApprovalFilter(item, filter)
+			var err error
+			if filterFunc, err = query.NewFilter(filter, query.FacetFields(c, dataFacet), c.matchesOpenApprovalFilter); err != nil {
+				return nil, types.NewValidationError("exports", dataFacet, "GetPage", err)
 			}
 		}
 		sortFunc := func(items []OpenApproval, sort sdk.SortSpec) error {
-			return sdk.SortOpenApprovals(items, sort)
+			return sorting.SortBy(items, sort)
 		}
-		if result, err := facet.GetPage(first, pageSize, filterFunc, sortSpec, sortFunc); err != nil {
+		if result, err := facet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
 			return nil, types.NewStoreError("exports", dataFacet, "GetPage", err)
 		} else {
 			page.OpenApprovals = result.Items
@@ -195,14 +226,15 @@ func (c *ExportsCollection) GetPage(
 		facet := c.approvaltxsFacet
 		var filterFunc func(*ApprovalTx) bool
 		if filter != "" {
-			filterFunc = func(item *ApprovalTx) bool {
-				return c.matchesApprovalTxFilter(item, filter)
+			var err error
+			if filterFunc, err = query.NewFilter(filter, query.FacetFields(c, dataFacet), c.matchesApprovalTxFilter); err != nil {
+				return nil, types.NewValidationError("exports", dataFacet, "GetPage", err)
 			}
 		}
 		sortFunc := func(items []ApprovalTx, sort sdk.SortSpec) error {
-			return sdk.SortApprovalTxs(items, sort)
+			return sorting.SortBy(items, sort)
 		}
-		if result, err := facet.GetPage(first, pageSize, filterFunc, sortSpec, sortFunc); err != nil {
+		if result, err := facet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
 			return nil, types.NewStoreError("exports", dataFacet, "GetPage", err)
 		} else {
 			page.ApprovalTxs = result.Items
@@ -214,14 +246,15 @@ func (c *ExportsCollection) GetPage(
 		facet := c.approvallogsFacet
 		var filterFunc func(*ApprovalLog) bool
 		if filter != "" {
-			filterFunc = func(item *ApprovalLog) bool {
-				return c.matchesApprovalLogFilter(item, filter)
+			var err error
+			if filterFunc, err = query.NewFilter(filter, query.FacetFields(c, dataFacet), c.matchesApprovalLogFilter); err != nil {
+				return nil, types.NewValidationError("exports", dataFacet, "GetPage", err)
 			}
 		}
 		sortFunc := func(items []ApprovalLog, sort sdk.SortSpec) error {
-			return sdk.SortApprovalLogs(items, sort)
+			return sorting.SortBy(items, sort)
 		}
-		if result, err := facet.GetPage(first, pageSize, filterFunc, sortSpec, sortFunc); err != nil {
+		if result, err := facet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
 			return nil, types.NewStoreError("exports", dataFacet, "GetPage", err)
 		} else {
 			page.ApprovalLogs = result.Items
@@ -233,14 +266,15 @@ func (c *ExportsCollection) GetPage(
 		facet := c.transactionsFacet
 		var filterFunc func(*Transaction) bool
 		if filter != "" {
-			filterFunc = func(item *Transaction) bool {
-				return c.matchesTransactionFilter(item, filter)
+			var err error
+			if filterFunc, err = query.NewFilter(filter, query.FacetFields(c, dataFacet), c.matchesTransactionFilter); err != nil {
+				return nil, types.NewValidationError("exports", dataFacet, "GetPage", err)
 			}
 		}
 		sortFunc := func(items []Transaction, sort sdk.SortSpec) error {
-			return sdk.SortTransactions(items, sort)
+			return sorting.SortBy(items, sort)
 		}
-		if result, err := facet.GetPage(first, pageSize, filterFunc, sortSpec, sortFunc); err != nil {
+		if result, err := facet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
 			return nil, types.NewStoreError("exports", dataFacet, "GetPage", err)
 		} else {
 			page.Transactions = result.Items
@@ -252,14 +286,15 @@ func (c *ExportsCollection) GetPage(
 		facet := c.withdrawalsFacet
 		var filterFunc func(*Withdrawal) bool
 		if filter != "" {
-			filterFunc = func(item *Withdrawal) bool {
-				return c.matchesWithdrawalFilter(item, filter)
+			var err error
+			if filterFunc, err = query.NewFilter(filter, query.FacetFields(c, dataFacet), c.matchesWithdrawalFilter); err != nil {
+				return nil, types.NewValidationError("exports", dataFacet, "GetPage", err)
 			}
 		}
 		sortFunc := func(items []Withdrawal, sort sdk.SortSpec) error {
-			return sdk.SortWithdrawals(items, sort)
+			return sorting.SortBy(items, sort)
 		}
-		if result, err := facet.GetPage(first, pageSize, filterFunc, sortSpec, sortFunc); err != nil {
+		if result, err := facet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
 			return nil, types.NewStoreError("exports", dataFacet, "GetPage", err)
 		} else {
 			page.Withdrawals = result.Items
@@ -271,14 +306,15 @@ func (c *ExportsCollection) GetPage(
 		facet := c.receiptsFacet
 		var filterFunc func(*Receipt) bool
 		if filter != "" {
-			filterFunc = func(item *Receipt) bool {
-				return c.matchesReceiptFilter(item, filter)
+			var err error
+			if filterFunc, err = query.NewFilter(filter, query.FacetFields(c, dataFacet), c.matchesReceiptFilter); err != nil {
+				return nil, types.NewValidationError("exports", dataFacet, "GetPage", err)
 			}
 		}
 		sortFunc := func(items []Receipt, sort sdk.SortSpec) error {
-			return sdk.SortReceipts(items, sort)
+			return sorting.SortBy(items, sort)
 		}
-		if result, err := facet.GetPage(first, pageSize, filterFunc, sortSpec, sortFunc); err != nil {
+		if result, err := facet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
 			return nil, types.NewStoreError("exports", dataFacet, "GetPage", err)
 		} else {
 			page.Receipts = result.Items
@@ -290,14 +326,15 @@ func (c *ExportsCollection) GetPage(
 		facet := c.logsFacet
 		var filterFunc func(*Log) bool
 		if filter != "" {
-			filterFunc = func(item *Log) bool {
-				return c.matchesLogFilter(item, filter)
+			var err error
+			if filterFunc, err = query.NewFilter(filter, query.FacetFields(c, dataFacet), c.matchesLogFilter); err != nil {
+				return nil, types.NewValidationError("exports", dataFacet, "GetPage", err)
 			}
 		}
 		sortFunc := func(items []Log, sort sdk.SortSpec) error {
-			return sdk.SortLogs(items, sort)
+			return sorting.SortBy(items, sort)
 		}
-		if result, err := facet.GetPage(first, pageSize, filterFunc, sortSpec, sortFunc); err != nil {
+		if result, err := facet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
 			return nil, types.NewStoreError("exports", dataFacet, "GetPage", err)
 		} else {
 			page.Logs = result.Items
@@ -309,14 +346,15 @@ func (c *ExportsCollection) GetPage(
 		facet := c.tracesFacet
 		var filterFunc func(*Trace) bool
 		if filter != "" {
-			filterFunc = func(item *Trace) bool {
-				return c.matchesTraceFilter(item, filter)
+			var err error
+			if filterFunc, err = query.NewFilter(filter, query.FacetFields(c, dataFacet), c.matchesTraceFilter); err != nil {
+				return nil, types.NewValidationError("exports", dataFacet, "GetPage", err)
 			}
 		}
 		sortFunc := func(items []Trace, sort sdk.SortSpec) error {
-			return sdk.SortTraces(items, sort)
+			return sorting.SortBy(items, sort)
 		}
-		if result, err := facet.GetPage(first, pageSize, filterFunc, sortSpec, sortFunc); err != nil {
+		if result, err := facet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
 			return nil, types.NewStoreError("exports", dataFacet, "GetPage", err)
 		} else {
 			page.Traces = result.Items
@@ -573,6 +611,12 @@ func (c *ExportsCollection) matchesBalanceFilter(item *Balance, filter string) b
 	return strings.Contains(strings.ToLower(item.Address.Hex()), filter)
 }
 
+func (c *ExportsCollection) matchesGainFilter(item *Gain, filter string) bool {
+	return strings.Contains(strings.ToLower(item.Asset.Hex()), filter) ||
+		strings.Contains(strings.ToLower(item.Symbol), filter) ||
+		strings.Contains(strings.ToLower(item.TransactionHash.Hex()), filter)
+}
+
 func (c *ExportsCollection) matchesTransferFilter(item *Transfer, filter string) bool {
 	return strings.Contains(strings.ToLower(item.Asset.Hex()), filter) ||
 		strings.Contains(strings.ToLower(item.Sender.Hex()), filter) ||
@@ -0,0 +1,46 @@
+package exports
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/store"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+)
+
+// MarkStale marks every store built for addr on chain stale, so views open on them reload
+// when next asked for a page. It returns how many stores were marked.
+func MarkStale(chain string, addr base.Address, reason string) int {
+	key := chain + "_" + addr.Hex()
+	n := 0
+	n += markStale(&approvallogsStoreMu, approvallogsStore, key, reason)
+	n += markStale(&approvaltxsStoreMu, approvaltxsStore, key, reason)
+	n += markStale(&assetsStoreMu, assetsStore, key, reason)
+	n += markStale(&balancesStoreMu, balancesStore, key, reason)
+	n += markStale(&gainsStoreMu, gainsStore, key, reason)
+	n += markStale(&logsStoreMu, logsStore, key, reason)
+	n += markStale(&openapprovalsStoreMu, openapprovalsStore, key, reason)
+	n += markStale(&receiptsStoreMu, receiptsStore, key, reason)
+	n += markStale(&statementsStoreMu, statementsStore, key, reason)
+	n += markStale(&tracesStoreMu, tracesStore, key, reason)
+	n += markStale(&transactionsStoreMu, transactionsStore, key, reason)
+	n += markStale(&transfersStoreMu, transfersStore, key, reason)
+	n += markStale(&withdrawalsStoreMu, withdrawalsStore, key, reason)
+	return n
+}
+
+// markStale marks the stores in m under key stale. Store keys carry the address as the
+// frontend sent it, so they are compared without regard to case.
+func markStale[T any](mu *sync.Mutex, m map[string]*store.Store[T], key, reason string) int {
+	mu.Lock()
+	defer mu.Unlock()
+	n := 0
+	for k, s := range m {
+		if s != nil && strings.EqualFold(k, key) {
+			s.MarkStale(reason)
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,42 @@
+package exports
+
+import (
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/store"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkStale(t *testing.T) {
+	addr := base.HexToAddress("0xf503017d7baf7fbc0fff7492b751025c6a78179b")
+	other := base.HexToAddress("0x054993ab0f2b1acc0fdc65405ee203b4271bebe6")
+
+	newStore := func(key string) *store.Store[Transaction] {
+		s := store.NewStore[Transaction]("test-"+key, nil, nil, nil)
+		s.ChangeState(types.StateLoaded, "")
+		return s
+	}
+
+	transactionsStoreMu.Lock()
+	saved := transactionsStore
+	transactionsStore = map[string]*store.Store[Transaction]{
+		"mainnet_0xF503017D7bAf7FBC0FFF7492b751025c6A78179b": newStore("a"),
+		"sepolia_" + addr.Hex():                              newStore("b"),
+		"mainnet_" + other.Hex():                             newStore("c"),
+	}
+	stores := transactionsStore
+	transactionsStoreMu.Unlock()
+	defer func() {
+		transactionsStoreMu.Lock()
+		transactionsStore = saved
+		transactionsStoreMu.Unlock()
+	}()
+
+	assert.Equal(t, 1, MarkStale("mainnet", addr, "new appearances"))
+	assert.Equal(t, types.StateStale, stores["mainnet_0xF503017D7bAf7FBC0FFF7492b751025c6A78179b"].GetState())
+	assert.Equal(t, types.StateLoaded, stores["sepolia_"+addr.Hex()].GetState())
+	assert.Equal(t, types.StateLoaded, stores["mainnet_"+other.Hex()].GetState())
+}
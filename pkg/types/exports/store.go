@@ -18,6 +18,7 @@ import (
 	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
 	"github.com/TrueBlocks/trueblocks-explorer/pkg/types/names"
 
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
 	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/output"
 	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
 )
@@ -53,6 +54,9 @@ var (
 	balancesStore   = make(map[string]*store.Store[Balance])
 	balancesStoreMu sync.Mutex
 
+	gainsStore   = make(map[string]*store.Store[Gain])
+	gainsStoreMu sync.Mutex
+
 	logsStore   = make(map[string]*store.Store[Log])
 	logsStoreMu sync.Mutex
 
@@ -94,6 +98,7 @@ func (c *ExportsCollection) getApprovalLogsStore(payload *types.Payload, facet t
 				Globals:    sdk.Globals{Cache: true, Verbose: true, Chain: payload.ActiveChain},
 				RenderCtx:  ctx,
 				Addrs:      []string{payload.ActiveAddress},
+				FirstBlock: base.Blknum(theStore.ResumeBlock()),
 				Articulate: true,
 			}
 			if _, _, err := opts.ExportApprovalsLogs(); err != nil {
@@ -133,6 +138,8 @@ func (c *ExportsCollection) getApprovalLogsStore(payload *types.Payload, facet t
 		theStore = store.NewStore(storeName, queryFunc, processFunc, mappingFunc)
 
 		// EXISTING_CODE
+		theStore.EnableSnapshots()
+		theStore.SetIncremental(true)
 		// EXISTING_CODE
 
 		approvallogsStore[storeKey] = theStore
@@ -157,6 +164,7 @@ func (c *ExportsCollection) getApprovalTxsStore(payload *types.Payload, facet ty
 				Globals:    sdk.Globals{Cache: true, Verbose: true, Chain: payload.ActiveChain},
 				RenderCtx:  ctx,
 				Addrs:      []string{payload.ActiveAddress},
+				FirstBlock: base.Blknum(theStore.ResumeBlock()),
 				Articulate: true,
 				Unripe:     true,
 			}
@@ -187,6 +195,8 @@ func (c *ExportsCollection) getApprovalTxsStore(payload *types.Payload, facet ty
 		theStore = store.NewStore(storeName, queryFunc, processFunc, mappingFunc)
 
 		// EXISTING_CODE
+		theStore.EnableSnapshots()
+		theStore.SetIncremental(true)
 		// EXISTING_CODE
 
 		approvaltxsStore[storeKey] = theStore
@@ -211,6 +221,7 @@ func (c *ExportsCollection) getAssetsStore(payload *types.Payload, facet types.D
 				Globals:    sdk.Globals{Cache: true, Verbose: true, Chain: payload.ActiveChain},
 				RenderCtx:  ctx,
 				Addrs:      []string{payload.ActiveAddress},
+				FirstBlock: base.Blknum(theStore.ResumeBlock()),
 				Accounting: true, // Enable accounting for statements
 			}
 			if _, _, err := opts.ExportStatements(); err != nil {
@@ -260,6 +271,8 @@ func (c *ExportsCollection) getAssetsStore(payload *types.Payload, facet types.D
 		theStore = store.NewStore(storeName, queryFunc, processFunc, mappingFunc)
 
 		// EXISTING_CODE
+		theStore.EnableSnapshots()
+		theStore.SetIncremental(true)
 		theStore.SetMapSortFunc(func(a, b *Asset) bool {
 			if a.StatementId == b.StatementId {
 				if a.SpotPrice.Equal(&b.SpotPrice) {
@@ -290,9 +303,10 @@ func (c *ExportsCollection) getBalancesStore(payload *types.Payload, facet types
 		queryFunc := func(ctx *output.RenderCtx) error {
 			// EXISTING_CODE
 			opts := sdk.ExportOptions{
-				Globals:   sdk.Globals{Cache: true, Verbose: true, Chain: payload.ActiveChain, Ether: true},
-				RenderCtx: ctx,
-				Addrs:     []string{payload.ActiveAddress},
+				Globals:    sdk.Globals{Cache: true, Verbose: true, Chain: payload.ActiveChain, Ether: true},
+				RenderCtx:  ctx,
+				Addrs:      []string{payload.ActiveAddress},
+				FirstBlock: base.Blknum(theStore.ResumeBlock()),
 			}
 			if _, _, err := opts.ExportBalances(); err != nil {
 				wrappedErr := types.NewSDKError("exports", ExportsBalances, "fetch", err)
@@ -333,6 +347,8 @@ func (c *ExportsCollection) getBalancesStore(payload *types.Payload, facet types
 		theStore = store.NewStore(storeName, queryFunc, processFunc, mappingFunc)
 
 		// EXISTING_CODE
+		theStore.EnableSnapshots()
+		theStore.SetIncremental(true)
 		// EXISTING_CODE
 
 		balancesStore[storeKey] = theStore
@@ -341,6 +357,64 @@ func (c *ExportsCollection) getBalancesStore(payload *types.Payload, facet types
 	return theStore
 }
 
+func (c *ExportsCollection) getGainsStore(payload *types.Payload, facet types.DataFacet) *store.Store[Gain] {
+	gainsStoreMu.Lock()
+	defer gainsStoreMu.Unlock()
+
+	// EXISTING_CODE
+	// EXISTING_CODE
+
+	storeKey := getStoreKey(payload)
+	theStore := gainsStore[storeKey]
+	if theStore == nil {
+		queryFunc := func(ctx *output.RenderCtx) error {
+			// EXISTING_CODE
+			statements, err := loadItems(c.statementsFacet)
+			if err != nil {
+				wrappedErr := types.NewSDKError("exports", ExportsGains, "fetch", err)
+				logging.LogBEWarning(fmt.Sprintf("Exports gains query error: %v", wrappedErr))
+				return wrappedErr
+			}
+			gains := buildGains(statements, currentCostBasisMethod())
+			c.resetGainsSummary()
+			go func() {
+				defer close(ctx.ModelChan)
+				defer close(ctx.ErrorChan)
+				for _, gain := range gains {
+					ctx.ModelChan <- gain
+				}
+			}()
+			// EXISTING_CODE
+			return nil
+		}
+
+		processFunc := func(item interface{}) *Gain {
+			if it, ok := item.(*Gain); ok {
+				it.AssetName = names.NameAddress(it.Asset)
+				// EXISTING_CODE
+				// EXISTING_CODE
+				return it
+			}
+			return nil
+		}
+
+		mappingFunc := func(item *Gain) (key string, includeInMap bool) {
+			return "", false
+		}
+
+		storeName := c.getStoreName(payload, facet)
+		theStore = store.NewStore(storeName, queryFunc, processFunc, mappingFunc)
+
+		// EXISTING_CODE
+		c.getStatementsStore(payload, ExportsStatements).RegisterObserver(&gainsInvalidator{gains: theStore})
+		// EXISTING_CODE
+
+		gainsStore[storeKey] = theStore
+	}
+
+	return theStore
+}
+
 func (c *ExportsCollection) getLogsStore(payload *types.Payload, facet types.DataFacet) *store.Store[Log] {
 	logsStoreMu.Lock()
 	defer logsStoreMu.Unlock()
@@ -357,6 +431,7 @@ func (c *ExportsCollection) getLogsStore(payload *types.Payload, facet types.Dat
 				Globals:    sdk.Globals{Cache: true, Verbose: true, Chain: payload.ActiveChain},
 				RenderCtx:  ctx,
 				Addrs:      []string{payload.ActiveAddress},
+				FirstBlock: base.Blknum(theStore.ResumeBlock()),
 				Articulate: true,
 			}
 			if _, _, err := opts.ExportLogs(); err != nil {
@@ -397,6 +472,8 @@ func (c *ExportsCollection) getLogsStore(payload *types.Payload, facet types.Dat
 		theStore = store.NewStore(storeName, queryFunc, processFunc, mappingFunc)
 
 		// EXISTING_CODE
+		theStore.EnableSnapshots()
+		theStore.SetIncremental(true)
 		// EXISTING_CODE
 
 		logsStore[storeKey] = theStore
@@ -484,6 +561,7 @@ func (c *ExportsCollection) getReceiptsStore(payload *types.Payload, facet types
 				Globals:    sdk.Globals{Cache: true, Verbose: true, Chain: payload.ActiveChain},
 				RenderCtx:  ctx,
 				Addrs:      []string{payload.ActiveAddress},
+				FirstBlock: base.Blknum(theStore.ResumeBlock()),
 				Articulate: true,
 			}
 			if _, _, err := opts.ExportReceipts(); err != nil {
@@ -515,6 +593,8 @@ func (c *ExportsCollection) getReceiptsStore(payload *types.Payload, facet types
 		theStore = store.NewStore(storeName, queryFunc, processFunc, mappingFunc)
 
 		// EXISTING_CODE
+		theStore.EnableSnapshots()
+		theStore.SetIncremental(true)
 		// EXISTING_CODE
 
 		receiptsStore[storeKey] = theStore
@@ -539,6 +619,7 @@ func (c *ExportsCollection) getStatementsStore(payload *types.Payload, facet typ
 				Globals:    sdk.Globals{Cache: true, Verbose: true, Chain: payload.ActiveChain},
 				RenderCtx:  ctx,
 				Addrs:      []string{payload.ActiveAddress},
+				FirstBlock: base.Blknum(theStore.ResumeBlock()),
 				Accounting: true, // Enable accounting for statements
 			}
 			if _, _, err := opts.ExportStatements(); err != nil {
@@ -584,6 +665,8 @@ func (c *ExportsCollection) getStatementsStore(payload *types.Payload, facet typ
 		theStore = store.NewStore(storeName, queryFunc, processFunc, mappingFunc)
 
 		// EXISTING_CODE
+		theStore.EnableSnapshots()
+		theStore.SetIncremental(true)
 		// EXISTING_CODE
 
 		statementsStore[storeKey] = theStore
@@ -604,10 +687,16 @@ func (c *ExportsCollection) getTracesStore(payload *types.Payload, facet types.D
 	if theStore == nil {
 		queryFunc := func(ctx *output.RenderCtx) error {
 			// EXISTING_CODE
+			if err := tracesUnavailable(payload.ActiveChain); err != nil {
+				wrappedErr := types.NewSDKError("exports", ExportsTraces, "fetch", err)
+				logging.LogBEWarning(fmt.Sprintf("Exports traces SDK query error: %v", wrappedErr))
+				return wrappedErr
+			}
 			opts := sdk.ExportOptions{
 				Globals:    sdk.Globals{Cache: true, Verbose: true, Chain: payload.ActiveChain},
 				RenderCtx:  ctx,
 				Addrs:      []string{payload.ActiveAddress},
+				FirstBlock: base.Blknum(theStore.ResumeBlock()),
 				Articulate: true,
 			}
 			if _, _, err := opts.ExportTraces(); err != nil {
@@ -636,6 +725,8 @@ func (c *ExportsCollection) getTracesStore(payload *types.Payload, facet types.D
 		theStore = store.NewStore(storeName, queryFunc, processFunc, mappingFunc)
 
 		// EXISTING_CODE
+		theStore.EnableSnapshots()
+		theStore.SetIncremental(true)
 		// EXISTING_CODE
 
 		tracesStore[storeKey] = theStore
@@ -660,6 +751,7 @@ func (c *ExportsCollection) getTransactionsStore(payload *types.Payload, facet t
 				Globals:    sdk.Globals{Cache: true, Verbose: true, Chain: payload.ActiveChain},
 				RenderCtx:  ctx,
 				Addrs:      []string{payload.ActiveAddress},
+				FirstBlock: base.Blknum(theStore.ResumeBlock()),
 				Articulate: true,
 			}
 			if _, _, err := opts.Export(); err != nil {
@@ -690,6 +782,8 @@ func (c *ExportsCollection) getTransactionsStore(payload *types.Payload, facet t
 		theStore = store.NewStore(storeName, queryFunc, processFunc, mappingFunc)
 
 		// EXISTING_CODE
+		theStore.EnableSnapshots()
+		theStore.SetIncremental(true)
 		// EXISTING_CODE
 
 		transactionsStore[storeKey] = theStore
@@ -714,6 +808,7 @@ func (c *ExportsCollection) getTransfersStore(payload *types.Payload, facet type
 				Globals:    sdk.Globals{Cache: true, Verbose: true, Chain: payload.ActiveChain},
 				RenderCtx:  ctx,
 				Addrs:      []string{payload.ActiveAddress},
+				FirstBlock: base.Blknum(theStore.ResumeBlock()),
 				Accounting: true, // Enable accounting for transfers
 			}
 			if _, _, err := opts.ExportTransfers(); err != nil {
@@ -757,6 +852,8 @@ func (c *ExportsCollection) getTransfersStore(payload *types.Payload, facet type
 		theStore = store.NewStore(storeName, queryFunc, processFunc, mappingFunc)
 
 		// EXISTING_CODE
+		theStore.EnableSnapshots()
+		theStore.SetIncremental(true)
 		// EXISTING_CODE
 
 		transfersStore[storeKey] = theStore
@@ -778,9 +875,10 @@ func (c *ExportsCollection) getWithdrawalsStore(payload *types.Payload, facet ty
 		queryFunc := func(ctx *output.RenderCtx) error {
 			// EXISTING_CODE
 			opts := sdk.ExportOptions{
-				Globals:   sdk.Globals{Cache: true, Verbose: true, Chain: payload.ActiveChain},
-				RenderCtx: ctx,
-				Addrs:     []string{payload.ActiveAddress},
+				Globals:    sdk.Globals{Cache: true, Verbose: true, Chain: payload.ActiveChain},
+				RenderCtx:  ctx,
+				Addrs:      []string{payload.ActiveAddress},
+				FirstBlock: base.Blknum(theStore.ResumeBlock()),
 			}
 			if _, _, err := opts.ExportWithdrawals(); err != nil {
 				wrappedErr := types.NewSDKError("exports", ExportsTransfers, "fetch", err)
@@ -809,6 +907,8 @@ func (c *ExportsCollection) getWithdrawalsStore(payload *types.Payload, facet ty
 		theStore = store.NewStore(storeName, queryFunc, processFunc, mappingFunc)
 
 		// EXISTING_CODE
+		theStore.EnableSnapshots()
+		theStore.SetIncremental(true)
 		// EXISTING_CODE
 
 		withdrawalsStore[storeKey] = theStore
@@ -832,6 +932,8 @@ func (c *ExportsCollection) getStoreName(payload *types.Payload, facet types.Dat
 		name = "exports-statements"
 	case ExportsBalances:
 		name = "exports-balances"
+	case ExportsGains:
+		name = "exports-gains"
 	case ExportsTransfers:
 		name = "exports-transfers"
 	case ExportsOpenApprovals:
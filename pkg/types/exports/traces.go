@@ -0,0 +1,35 @@
+package exports
+
+import (
+	"fmt"
+	"sync"
+)
+
+var (
+	traceSupport   func(chain string) (known, supported bool)
+	traceSupportMu sync.RWMutex
+)
+
+// SetTraceSupport installs the function the traces facet asks whether a chain's RPC
+// provider answers trace queries. Without one, or if it does not know, the traces are
+// fetched as usual.
+func SetTraceSupport(fn func(chain string) (known, supported bool)) {
+	traceSupportMu.Lock()
+	defer traceSupportMu.Unlock()
+	traceSupport = fn
+}
+
+// tracesUnavailable returns an error if chain's provider is known not to serve traces, so
+// the facet fails at once instead of after a long export that cannot succeed
+func tracesUnavailable(chain string) error {
+	traceSupportMu.RLock()
+	fn := traceSupport
+	traceSupportMu.RUnlock()
+	if fn == nil {
+		return nil
+	}
+	if known, supported := fn(chain); known && !supported {
+		return fmt.Errorf("the rpc provider for %s does not support traces", chain)
+	}
+	return nil
+}
@@ -22,6 +22,8 @@ func (c *MonitorsCollection) GetConfig() (*types.ViewConfig, error) {
 		Actions:    c.buildActions(),
 	}
 
+	c.addDynamicFields(cfg)
+
 	types.DeriveFacets(cfg)
 	types.SortFields(cfg)
 	types.SetMenuOrder(cfg)
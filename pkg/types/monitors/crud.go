@@ -27,7 +27,7 @@ func (c *MonitorsCollection) Crud(
 	switch op {
 	case crud.Autoname:
 		// Delegate autoname operation to Names collection
-		if err = names.AutonameAddress(monitor.Address.Hex()); err != nil {
+		if err = names.AutonameAddress(payload.ActiveChain, monitor.Address.Hex()); err != nil {
 			msgs.EmitError("Monitors.Crud.Autoname", err)
 			return err
 		}
@@ -3,6 +3,7 @@ package monitors
 import (
 	"testing"
 
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/refresh"
 	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
 
 	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
@@ -118,3 +119,22 @@ func TestMonitorsCollectionDomainSpecific(t *testing.T) {
 //
 // -----------------------------------------------------------------------------
 // End of Test
+
+func TestAddRefreshStatusIsKeyedByAddress(t *testing.T) {
+	scheduled := base.HexToAddress("0xf503017d7baf7fbc0fff7492b751025c6a78179b")
+	other := base.HexToAddress("0x6b175474e89094c44da98b954eedeac495271d0f")
+	SetRefreshSource(func(chain string, addr base.Address) (refresh.Result, bool) {
+		return refresh.Result{NewRecords: 3}, chain == "mainnet" && addr == scheduled
+	})
+	t.Cleanup(func() { SetRefreshSource(nil) })
+
+	var payload types.Payload
+	page := &MonitorsPage{Monitors: []Monitor{{Address: other}, {Address: scheduled}}}
+	NewMonitorsCollection(&payload).AddRefreshStatus(page, "mainnet")
+
+	assert.Len(t, page.Refresh, 1)
+	status, ok := page.Refresh[scheduled.Hex()]
+	assert.True(t, ok)
+	assert.True(t, status.Scheduled)
+	assert.Equal(t, int64(3), status.NewRecords)
+}
@@ -13,6 +13,8 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/query"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/sorting"
 	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
 	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
 )
@@ -26,6 +28,7 @@ type MonitorsPage struct {
 	ExpectedTotal int              `json:"expectedTotal"`
 	State         types.StoreState `json:"state"`
 	// EXISTING_CODE
+	Refresh map[string]RefreshStatus `json:"refresh,omitempty"`
 	// EXISTING_CODE
 }
 
@@ -68,14 +71,15 @@ func (c *MonitorsCollection) GetPage(
 		facet := c.monitorsFacet
 		var filterFunc func(*Monitor) bool
 		if filter != "" {
-			filterFunc = func(item *Monitor) bool {
-				return c.matchesMonitorFilter(item, filter)
+			var err error
+			if filterFunc, err = query.NewFilter(filter, query.FacetFields(c, dataFacet), c.matchesMonitorFilter); err != nil {
+				return nil, types.NewValidationError("monitors", dataFacet, "GetPage", err)
 			}
 		}
 		sortFunc := func(items []Monitor, sort sdk.SortSpec) error {
-			return sdk.SortMonitors(items, sort)
+			return sorting.SortBy(items, sort)
 		}
-		if result, err := facet.GetPage(first, pageSize, filterFunc, sortSpec, sortFunc); err != nil {
+		if result, err := facet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
 			return nil, types.NewStoreError("monitors", dataFacet, "GetPage", err)
 		} else {
 			page.Monitors = result.Items
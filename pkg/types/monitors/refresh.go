@@ -0,0 +1,101 @@
+package monitors
+
+import (
+	"sync"
+	"time"
+
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/refresh"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+)
+
+// RefreshStatus is what the background refresh knows about one monitor. Times are
+// RFC3339 and empty until the monitor has been refreshed.
+type RefreshStatus struct {
+	Scheduled    bool   `json:"refreshScheduled"`
+	LastRefresh  string `json:"lastRefresh,omitempty"`
+	NextRefresh  string `json:"nextRefresh,omitempty"`
+	NewRecords   int64  `json:"newRecords"`
+	RefreshError string `json:"refreshError,omitempty"`
+}
+
+var (
+	refreshSource   func(chain string, addr base.Address) (refresh.Result, bool)
+	refreshSourceMu sync.RWMutex
+)
+
+// SetRefreshSource installs the function the monitors collection calls to find a
+// monitor's last background refresh. Without one, no monitor shows as scheduled.
+func SetRefreshSource(fn func(chain string, addr base.Address) (refresh.Result, bool)) {
+	refreshSourceMu.Lock()
+	defer refreshSourceMu.Unlock()
+	refreshSource = fn
+}
+
+// AddRefreshStatus fills page.Refresh with the refresh status of the page's scheduled
+// monitors on chain, keyed by address so that it does not depend on the rows' order
+func (c *MonitorsCollection) AddRefreshStatus(page *MonitorsPage, chain string) {
+	if page == nil {
+		return
+	}
+	refreshSourceMu.RLock()
+	fn := refreshSource
+	refreshSourceMu.RUnlock()
+
+	page.Refresh = make(map[string]RefreshStatus)
+	if fn == nil {
+		return
+	}
+	for i := range page.Monitors {
+		addr := page.Monitors[i].Address
+		if r, ok := fn(chain, addr); ok {
+			page.Refresh[addr.Hex()] = refreshStatus(r)
+		}
+	}
+}
+
+func refreshStatus(r refresh.Result) RefreshStatus {
+	status := RefreshStatus{Scheduled: true, NewRecords: r.NewRecords, RefreshError: r.Error}
+	if !r.LastRun.IsZero() {
+		status.LastRefresh = r.LastRun.Format(time.RFC3339)
+	}
+	if !r.NextRun.IsZero() {
+		status.NextRefresh = r.NextRun.Format(time.RFC3339)
+	}
+	return status
+}
+
+// addDynamicFields adds the columns the background refresh fills in
+func (c *MonitorsCollection) addDynamicFields(cfg *types.ViewConfig) {
+	addRefreshFields(cfg)
+}
+
+// addRefreshFields adds the refresh columns AddRefreshStatus fills to the monitors facet.
+// The status travels beside the rows rather than on them, so the columns can be neither
+// sorted nor filtered.
+func addRefreshFields(cfg *types.ViewConfig) {
+	facet, ok := cfg.Facets["monitors"]
+	if !ok {
+		return
+	}
+	facet.Fields = append(facet.Fields,
+		types.FieldConfig{Section: "Refresh", Key: "refreshScheduled", Type: "boolean", Label: "Auto Refresh", NoFilter: true},
+		types.FieldConfig{Section: "Refresh", Key: "lastRefresh", Type: "datetime", Label: "Last Refresh", NoFilter: true},
+		types.FieldConfig{Section: "Refresh", Key: "nextRefresh", Type: "datetime", Label: "Next Refresh", NoTable: true, NoFilter: true},
+		types.FieldConfig{Section: "Refresh", Key: "newRecords", Type: "uint64", Label: "New Records", NoFilter: true},
+		types.FieldConfig{Section: "Refresh", Key: "refreshError", Type: "string", Label: "Refresh Error", NoTable: true, NoFilter: true},
+	)
+	types.NormalizeFields(&facet.Fields)
+	cfg.Facets["monitors"] = facet
+}
+
+// MarkStale marks the monitors store of chain stale, so an open monitors view reloads
+// its record counts
+func MarkStale(chain, reason string) {
+	monitorsStoreMu.Lock()
+	defer monitorsStoreMu.Unlock()
+	if s := monitorsStore[chain]; s != nil {
+		s.MarkStale(reason)
+	}
+}
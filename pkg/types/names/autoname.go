@@ -0,0 +1,212 @@
+package names
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/msgs"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+)
+
+// DefaultAutonameWorkers is how many addresses of a job are named at once. Naming is
+// mostly waiting on the RPC, so a few run side by side; the names database serializes
+// the writes itself.
+const DefaultAutonameWorkers = 4
+
+// AutonameProgress is the payload of every names:autoname event. One is sent as each
+// address finishes and a last one, with Finished set, when the job completes.
+type AutonameProgress struct {
+	JobID    string `json:"jobId"`
+	Chain    string `json:"chain"`
+	Address  string `json:"address,omitempty"`
+	Error    string `json:"error,omitempty"`
+	Done     int    `json:"done"`
+	Failed   int    `json:"failed"`
+	Total    int    `json:"total"`
+	Finished bool   `json:"finished"`
+}
+
+// AutonameJob is a batch of addresses on one chain waiting in, or worked by, the queue
+type AutonameJob struct {
+	ID        string
+	Chain     string
+	Addresses []base.Address
+
+	mu     sync.Mutex
+	errs   map[base.Address]error
+	doneCh chan struct{}
+}
+
+// Wait blocks until the job completes and returns the failures, if any, joined
+func (j *AutonameJob) Wait() error {
+	<-j.doneCh
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	errs := make([]error, 0, len(j.errs))
+	for _, addr := range j.Addresses {
+		if err, ok := j.errs[addr]; ok {
+			errs = append(errs, fmt.Errorf("%s: %w", addr.Hex(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// AutonameQueue runs autoname jobs one after another, naming the addresses of each with
+// bounded concurrency. Jobs queue up behind each other rather than being refused.
+type AutonameQueue struct {
+	workers  int
+	autoname func(chain string, addr base.Address) error
+	onFinish func(job *AutonameJob)
+
+	mu      sync.Mutex
+	pending []*AutonameJob
+	running bool
+	nextID  int
+}
+
+// NewAutonameQueue returns a queue that names up to workers addresses at once with
+// autoname and calls onFinish once as each job completes
+func NewAutonameQueue(workers int, autoname func(chain string, addr base.Address) error, onFinish func(job *AutonameJob)) *AutonameQueue {
+	if workers < 1 {
+		workers = 1
+	}
+	return &AutonameQueue{workers: workers, autoname: autoname, onFinish: onFinish}
+}
+
+// Enqueue adds a job naming addresses on chain (mainnet if empty) and returns at once.
+// Repeated addresses are named once; an invalid address refuses the whole job.
+func (q *AutonameQueue) Enqueue(chain string, addresses []string) (*AutonameJob, error) {
+	if chain == "" {
+		chain = "mainnet"
+	}
+	if len(addresses) == 0 {
+		return nil, errors.New("no addresses to autoname")
+	}
+
+	seen := make(map[base.Address]bool, len(addresses))
+	addrs := make([]base.Address, 0, len(addresses))
+	for _, address := range addresses {
+		if !base.IsValidAddress(address) {
+			return nil, fmt.Errorf("cannot autoname %q: not an address", address)
+		}
+		addr := base.HexToAddress(address)
+		if !seen[addr] {
+			seen[addr] = true
+			addrs = append(addrs, addr)
+		}
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.nextID++
+	job := &AutonameJob{
+		ID:        fmt.Sprintf("autoname-%d", q.nextID),
+		Chain:     chain,
+		Addresses: addrs,
+		errs:      make(map[base.Address]error),
+		doneCh:    make(chan struct{}),
+	}
+	q.pending = append(q.pending, job)
+	if !q.running {
+		q.running = true
+		go q.run()
+	}
+	return job, nil
+}
+
+// Pending returns the number of jobs waiting behind the one being worked
+func (q *AutonameQueue) Pending() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.pending)
+}
+
+func (q *AutonameQueue) run() {
+	for {
+		q.mu.Lock()
+		if len(q.pending) == 0 {
+			q.running = false
+			q.mu.Unlock()
+			return
+		}
+		job := q.pending[0]
+		q.pending = q.pending[1:]
+		q.mu.Unlock()
+
+		q.process(job)
+	}
+}
+
+func (q *AutonameQueue) process(job *AutonameJob) {
+	progress := AutonameProgress{JobID: job.ID, Chain: job.Chain, Total: len(job.Addresses)}
+	sem := make(chan struct{}, q.workers)
+	var wg sync.WaitGroup
+
+	for _, addr := range job.Addresses {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(addr base.Address) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			err := q.autoname(job.Chain, addr)
+
+			job.mu.Lock()
+			defer job.mu.Unlock()
+			progress.Done++
+			if err != nil {
+				job.errs[addr] = err
+				progress.Failed++
+			}
+			update := progress
+			update.Address = addr.Hex()
+			if err != nil {
+				update.Error = err.Error()
+			}
+			msgs.EmitAutoname(update.Address, update)
+		}(addr)
+	}
+	wg.Wait()
+
+	if q.onFinish != nil {
+		q.onFinish(job)
+	}
+
+	progress.Finished = true
+	msgs.EmitAutoname(job.ID, progress)
+	if progress.Failed > 0 {
+		msgs.EmitError(fmt.Sprintf("autoname %s", job.ID), fmt.Errorf("%d of %d addresses failed", progress.Failed, progress.Total))
+	} else {
+		msgs.EmitStatus(fmt.Sprintf("autonamed %d addresses on %s", progress.Total, job.Chain))
+	}
+	close(job.doneCh)
+}
+
+var autonameQueue = NewAutonameQueue(DefaultAutonameWorkers, sdkAutoname, resetAfterAutoname)
+
+func sdkAutoname(chain string, addr base.Address) error {
+	opts := sdk.NamesOptions{
+		Globals: sdk.Globals{
+			Chain: chain,
+		},
+	}
+	_, _, err := opts.NamesAutoname(addr)
+	return err
+}
+
+// resetAfterAutoname drops the names facets once per job so they reload with the new names
+func resetAfterAutoname(_ *AutonameJob) {
+	collection := GetNamesCollection(&types.Payload{Collection: "names", DataFacet: NamesCustom})
+	collection.resetAllFacets()
+}
+
+// AutonameAddresses queues a job naming addresses on chain and returns without waiting.
+// Progress is reported through names:autoname events.
+func AutonameAddresses(chain string, addresses []string) (*AutonameJob, error) {
+	return autonameQueue.Enqueue(chain, addresses)
+}
@@ -0,0 +1,110 @@
+package names
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/msgs"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testAddresses(n int) []string {
+	addrs := make([]string, 0, n)
+	for i := 1; i <= n; i++ {
+		addrs = append(addrs, fmt.Sprintf("0x%040x", i))
+	}
+	return addrs
+}
+
+func TestAutonameQueueBoundsConcurrencyAndReports(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	failing := base.HexToAddress(testAddresses(3)[2])
+	autoname := func(chain string, addr base.Address) error {
+		n := inFlight.Add(1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		inFlight.Add(-1)
+		if addr == failing {
+			return errors.New("not a token")
+		}
+		return nil
+	}
+
+	var finished []string
+	var mu sync.Mutex
+	q := NewAutonameQueue(3, autoname, func(job *AutonameJob) {
+		mu.Lock()
+		finished = append(finished, job.ID)
+		mu.Unlock()
+	})
+
+	var events []AutonameProgress
+	untap := msgs.Tap(func(eventType msgs.EventType, data ...interface{}) {
+		if eventType != msgs.EventAutoname {
+			return
+		}
+		for _, d := range data {
+			if p, ok := d.(AutonameProgress); ok {
+				mu.Lock()
+				events = append(events, p)
+				mu.Unlock()
+			}
+		}
+	})
+	defer untap()
+
+	addrs := testAddresses(12)
+	first, err := q.Enqueue("sepolia", append(addrs, addrs[0]))
+	require.NoError(t, err)
+	second, err := q.Enqueue("", addrs[:2])
+	require.NoError(t, err, "a second job queues rather than failing")
+
+	err = first.Wait()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), failing.Hex())
+	require.NoError(t, second.Wait())
+
+	assert.LessOrEqual(t, maxInFlight.Load(), int32(3))
+	assert.Equal(t, "mainnet", second.Chain)
+	assert.Len(t, first.Addresses, 12, "repeated addresses are named once")
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{first.ID, second.ID}, finished, "each job finishes once, in order")
+
+	var last AutonameProgress
+	perAddress := 0
+	for _, e := range events {
+		if e.JobID != first.ID {
+			continue
+		}
+		if e.Finished {
+			last = e
+		} else {
+			perAddress++
+		}
+	}
+	assert.Equal(t, 12, perAddress)
+	assert.Equal(t, AutonameProgress{JobID: first.ID, Chain: "sepolia", Done: 12, Failed: 1, Total: 12, Finished: true}, last)
+}
+
+func TestAutonameQueueRejectsBadInput(t *testing.T) {
+	q := NewAutonameQueue(2, func(string, base.Address) error { return nil }, nil)
+	_, err := q.Enqueue("mainnet", nil)
+	assert.Error(t, err)
+	_, err = q.Enqueue("mainnet", []string{testAddresses(1)[0], "vitalik"})
+	assert.Error(t, err)
+	assert.Equal(t, 0, q.Pending())
+}
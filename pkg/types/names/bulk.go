@@ -0,0 +1,430 @@
+package names
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/msgs"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/config"
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/crud"
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+)
+
+// BulkAction is what a bulk import will do with one row
+type BulkAction string
+
+const (
+	BulkCreate  BulkAction = "create"
+	BulkUpdate  BulkAction = "update"
+	BulkSkip    BulkAction = "skip"
+	BulkInvalid BulkAction = "invalid"
+)
+
+// BulkItem is one row of a bulk import and what will be done with it
+type BulkItem struct {
+	Line      int        `json:"line"`
+	Address   string     `json:"address"`
+	Name      string     `json:"name"`
+	Tags      string     `json:"tags"`
+	Source    string     `json:"source"`
+	Action    BulkAction `json:"action"`
+	Reason    string     `json:"reason,omitempty"`
+	Collision string     `json:"collision,omitempty"`
+	Existing  *Name      `json:"existing,omitempty"`
+}
+
+// BulkPreview lists every row of a bulk import with its action
+type BulkPreview struct {
+	Items   []BulkItem `json:"items"`
+	Creates int        `json:"creates"`
+	Updates int        `json:"updates"`
+	Skips   int        `json:"skips"`
+	Invalid int        `json:"invalid"`
+}
+
+// BulkChange records one name written by a batch, with what it replaced
+type BulkChange struct {
+	Op      crud.Operation `json:"op"`
+	Address string         `json:"address"`
+	Before  *Name          `json:"before,omitempty"`
+	After   *Name          `json:"after"`
+}
+
+// BulkBatch records an applied bulk import so it can be rolled back
+type BulkBatch struct {
+	ID         string       `json:"id"`
+	AppliedAt  string       `json:"appliedAt"`
+	Changes    []BulkChange `json:"changes"`
+	RolledBack bool         `json:"rolledBack"`
+}
+
+// bulkBatchesDir is where applied batches are kept, one JSON file each, beside the custom
+// names database so that a batch can be rolled back after a restart
+var bulkBatchesDir = func() string {
+	return filepath.Join(config.MustGetPathToChainConfig("mainnet"), "names_bulk")
+}
+
+var bulkBatchesMu sync.Mutex
+
+// ErrNamesBusy is returned when another names operation holds the names database
+var ErrNamesBusy = errors.New("another names operation is in progress")
+
+// ParseBulkNames reads rows of address, name, tags and source from data. The format is
+// csv, tsv (or txt) or json. Delimited files may start with a header naming the columns
+// (in any order); without one the columns are taken in that order. JSON is an array of objects with those
+// keys.
+func ParseBulkNames(data []byte, format string) ([]BulkItem, error) {
+	switch strings.ToLower(format) {
+	case "json":
+		var rows []struct {
+			Address string `json:"address"`
+			Name    string `json:"name"`
+			Tags    string `json:"tags"`
+			Source  string `json:"source"`
+		}
+		if err := json.Unmarshal(data, &rows); err != nil {
+			return nil, fmt.Errorf("reading names: %w", err)
+		}
+		items := make([]BulkItem, 0, len(rows))
+		for i, r := range rows {
+			items = append(items, BulkItem{Line: i + 1, Address: r.Address, Name: r.Name, Tags: r.Tags, Source: r.Source})
+		}
+		return items, nil
+	case "csv":
+		return parseDelimited(data, ',')
+	case "tsv", "txt":
+		return parseDelimited(data, '\t')
+	default:
+		return nil, fmt.Errorf("unsupported names format %q (use csv, tsv or json)", format)
+	}
+}
+
+func parseDelimited(data []byte, comma rune) ([]BulkItem, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = comma
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	columns := map[string]int{"address": 0, "name": 1, "tags": 2, "source": 3}
+	items := []BulkItem{}
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading names: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+
+		if first && isHeader(record) {
+			columns = map[string]int{}
+			for i, heading := range record {
+				columns[strings.ToLower(strings.TrimSpace(heading))] = i
+			}
+			if _, ok := columns["name"]; !ok {
+				return nil, fmt.Errorf("reading names: the header has no name column")
+			}
+			continue
+		}
+
+		field := func(column string) string {
+			if i, ok := columns[column]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		items = append(items, BulkItem{Line: line, Address: field("address"), Name: field("name"), Tags: field("tags"), Source: field("source")})
+	}
+	return items, nil
+}
+
+// isHeader reports whether a record names the columns rather than holding a name
+func isHeader(record []string) bool {
+	for _, cell := range record {
+		if strings.EqualFold(strings.TrimSpace(cell), "address") {
+			return true
+		}
+	}
+	return false
+}
+
+// PreviewBulkNames decides what importing items would do, without changing anything
+func PreviewBulkNames(items []BulkItem) *BulkPreview {
+	return previewBulkNames(items, NameFromAddress)
+}
+
+// previewBulkNames validates each row and compares it with the existing name of its
+// address. A custom name is updated (or skipped if identical). Regular and prefund names
+// are never changed; a custom name is created over them and the collision is flagged.
+// Only the first row for an address is used.
+func previewBulkNames(items []BulkItem, lookup func(base.Address) (*Name, bool)) *BulkPreview {
+	preview := &BulkPreview{Items: make([]BulkItem, 0, len(items))}
+	seen := make(map[base.Address]int)
+
+	for _, item := range items {
+		item.Action, item.Reason, item.Collision, item.Existing = "", "", "", nil
+		switch {
+		case !base.IsValidAddress(item.Address):
+			item.Action, item.Reason = BulkInvalid, "not a valid address"
+		case base.HexToAddress(item.Address) == base.ZeroAddr:
+			item.Action, item.Reason = BulkInvalid, "the zero address cannot be named"
+		case item.Name == "":
+			item.Action, item.Reason = BulkInvalid, "the name is empty"
+		}
+		if item.Action == BulkInvalid {
+			preview.Invalid++
+			preview.Items = append(preview.Items, item)
+			continue
+		}
+
+		addr := base.HexToAddress(item.Address)
+		item.Address = addr.Hex()
+		if line, ok := seen[addr]; ok {
+			item.Action, item.Reason = BulkSkip, fmt.Sprintf("repeats the address on line %d", line)
+			preview.Skips++
+			preview.Items = append(preview.Items, item)
+			continue
+		}
+		seen[addr] = item.Line
+
+		existing, found := lookup(addr)
+		switch {
+		case !found || existing == nil:
+			item.Action = BulkCreate
+		case isCustom(existing):
+			item.Existing, item.Collision = existing, "custom"
+			if existing.Name == item.Name && existing.Tags == item.Tags && existing.Source == item.Source && !existing.Deleted {
+				item.Action, item.Reason = BulkSkip, "unchanged"
+			} else {
+				item.Action, item.Reason = BulkUpdate, fmt.Sprintf("replaces custom name %q", existing.Name)
+			}
+		default:
+			item.Existing, item.Collision = existing, "regular"
+			if isPrefund(existing) {
+				item.Collision = "prefund"
+			}
+			item.Action, item.Reason = BulkCreate, fmt.Sprintf("overrides %s name %q", item.Collision, existing.Name)
+		}
+
+		switch item.Action {
+		case BulkCreate:
+			preview.Creates++
+		case BulkUpdate:
+			preview.Updates++
+		case BulkSkip:
+			preview.Skips++
+		}
+		preview.Items = append(preview.Items, item)
+	}
+	return preview
+}
+
+// nameWriter applies one change to the custom names database
+type nameWriter func(op crud.Operation, name *Name) error
+
+func sdkNameWriter(op crud.Operation, name *Name) error {
+	opts := sdk.NamesOptions{
+		Globals: sdk.Globals{
+			Chain: "mainnet",
+		},
+	}
+	_, _, err := opts.ModifyName(op, crud.CrudFromName(*name))
+	return err
+}
+
+// ApplyBulkNames creates and updates the custom names of the preview's create and update
+// rows as one batch. If any write fails the writes already made are undone. The name
+// facets are reset once at the end. The returned batch is saved beside the names database
+// so it can be passed to RollbackBulkNames, even after a restart.
+func (c *NamesCollection) ApplyBulkNames(preview *BulkPreview) (*BulkBatch, error) {
+	if !namesLock.CompareAndSwap(0, 1) {
+		return nil, ErrNamesBusy
+	}
+	defer namesLock.Store(0)
+
+	batch, err := applyBulkNames(preview, sdkNameWriter)
+	c.resetAllFacets()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := saveBulkBatch(batch); err != nil {
+		return batch, fmt.Errorf("names were imported, but the batch could not be saved for rollback: %w", err)
+	}
+
+	msgs.EmitStatus(fmt.Sprintf("imported %d names (batch %s)", len(batch.Changes), batch.ID))
+	return batch, nil
+}
+
+// RollbackBulkNames restores every name the batch changed to what it was before
+func (c *NamesCollection) RollbackBulkNames(batchID string) error {
+	batch, err := loadBulkBatch(batchID)
+	if err != nil {
+		return err
+	}
+	if batch.RolledBack {
+		return fmt.Errorf("bulk import batch %s was already rolled back", batchID)
+	}
+
+	if !namesLock.CompareAndSwap(0, 1) {
+		return ErrNamesBusy
+	}
+	defer namesLock.Store(0)
+
+	err = rollbackBulkNames(batch.Changes, sdkNameWriter)
+	c.resetAllFacets()
+	if err != nil {
+		return err
+	}
+	batch.RolledBack = true
+	if err := saveBulkBatch(batch); err != nil {
+		return fmt.Errorf("names were rolled back, but the batch could not be updated: %w", err)
+	}
+
+	msgs.EmitStatus(fmt.Sprintf("rolled back %d names (batch %s)", len(batch.Changes), batch.ID))
+	return nil
+}
+
+// bulkBatchPath returns the file of the batch with the given ID, refusing IDs that are not
+// ones applyBulkNames makes
+func bulkBatchPath(batchID string) (string, error) {
+	digits := strings.TrimPrefix(batchID, "names-")
+	if digits == batchID || digits == "" || strings.Trim(digits, "0123456789") != "" {
+		return "", fmt.Errorf("invalid bulk import batch id %q", batchID)
+	}
+	return filepath.Join(bulkBatchesDir(), batchID+".json"), nil
+}
+
+func saveBulkBatch(batch *BulkBatch) error {
+	path, err := bulkBatchPath(batch.ID)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(batch, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	bulkBatchesMu.Lock()
+	defer bulkBatchesMu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func loadBulkBatch(batchID string) (*BulkBatch, error) {
+	path, err := bulkBatchPath(batchID)
+	if err != nil {
+		return nil, err
+	}
+
+	bulkBatchesMu.Lock()
+	data, err := os.ReadFile(path)
+	bulkBatchesMu.Unlock()
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("no bulk import batch %s", batchID)
+	} else if err != nil {
+		return nil, err
+	}
+
+	var batch BulkBatch
+	if err := json.Unmarshal(data, &batch); err != nil {
+		return nil, fmt.Errorf("bulk import batch %s: %w", batchID, err)
+	}
+	return &batch, nil
+}
+
+func applyBulkNames(preview *BulkPreview, write nameWriter) (*BulkBatch, error) {
+	now := time.Now()
+	batch := &BulkBatch{
+		ID:        fmt.Sprintf("names-%d", now.UnixNano()),
+		AppliedAt: now.Format(time.RFC3339),
+		Changes:   []BulkChange{},
+	}
+
+	for _, item := range preview.Items {
+		if item.Action != BulkCreate && item.Action != BulkUpdate {
+			continue
+		}
+
+		after := &Name{
+			Address:  base.HexToAddress(item.Address),
+			Name:     item.Name,
+			Tags:     item.Tags,
+			Source:   item.Source,
+			IsCustom: true,
+		}
+		change := BulkChange{Op: crud.Create, Address: item.Address, After: after}
+		if item.Action == BulkUpdate {
+			change.Op = crud.Update
+			before := *item.Existing
+			change.Before = &before
+		}
+
+		err := write(change.Op, after)
+		if err == nil {
+			batch.Changes = append(batch.Changes, change)
+			if change.Before != nil && change.Before.Deleted {
+				err = write(crud.Undelete, after)
+			}
+		}
+		if err != nil {
+			if rbErr := rollbackBulkNames(batch.Changes, write); rbErr != nil {
+				return nil, fmt.Errorf("line %d (%s): %w; undoing the batch also failed: %w", item.Line, item.Address, err, rbErr)
+			}
+			return nil, fmt.Errorf("line %d (%s): %w; no names were changed", item.Line, item.Address, err)
+		}
+	}
+	return batch, nil
+}
+
+// rollbackBulkNames undoes changes in reverse order. Created names are deleted and then
+// removed; updated names are written back as they were, including their deleted flag.
+func rollbackBulkNames(changes []BulkChange, write nameWriter) error {
+	var errs []error
+	for i := len(changes) - 1; i >= 0; i-- {
+		change := changes[i]
+		var err error
+		if change.Before == nil {
+			if err = write(crud.Delete, change.After); err == nil {
+				err = write(crud.Remove, change.After)
+			}
+		} else {
+			before := *change.Before
+			before.IsCustom = true
+			if err = write(crud.Update, &before); err == nil && before.Deleted {
+				err = write(crud.Delete, &before)
+			}
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", change.Address, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// resetAllFacets drops every names facet so the next page reloads from the database
+func (c *NamesCollection) resetAllFacets() {
+	for _, facet := range []types.DataFacet{NamesAll, NamesCustom, NamesRegular, NamesPrefund, NamesBaddress} {
+		c.Reset(&types.Payload{DataFacet: facet})
+	}
+}
@@ -0,0 +1,154 @@
+package names
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/crud"
+	coreTypes "github.com/TrueBlocks/trueblocks-chifra/v6/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	addrNew     = "0x1111111111111111111111111111111111111111"
+	addrCustom  = "0x2222222222222222222222222222222222222222"
+	addrSame    = "0x3333333333333333333333333333333333333333"
+	addrPrefund = "0x4444444444444444444444444444444444444444"
+)
+
+func bulkLookup(addr base.Address) (*Name, bool) {
+	existing := map[string]*Name{
+		addrCustom:  {Address: base.HexToAddress(addrCustom), Name: "Old", Tags: "t", IsCustom: true, Parts: coreTypes.Custom},
+		addrSame:    {Address: base.HexToAddress(addrSame), Name: "Same", Tags: "t", Source: "s", IsCustom: true, Parts: coreTypes.Custom},
+		addrPrefund: {Address: base.HexToAddress(addrPrefund), Name: "Genesis", IsPrefund: true, Parts: coreTypes.Prefund},
+	}
+	n, ok := existing[addr.Hex()]
+	return n, ok
+}
+
+func TestParseBulkNames(t *testing.T) {
+	csvItems, err := ParseBulkNames([]byte("source,address,name\nme,"+addrNew+",Alice\n"), "csv")
+	require.NoError(t, err)
+	require.Len(t, csvItems, 1)
+	assert.Equal(t, BulkItem{Line: 2, Address: addrNew, Name: "Alice", Source: "me"}, csvItems[0])
+
+	tsvItems, err := ParseBulkNames([]byte(addrNew+"\tAlice\tfriends\n"+addrSame+"\tBob\n"), "tsv")
+	require.NoError(t, err)
+	require.Len(t, tsvItems, 2)
+	assert.Equal(t, "friends", tsvItems[0].Tags)
+	assert.Equal(t, 2, tsvItems[1].Line)
+
+	jsonItems, err := ParseBulkNames([]byte(`[{"address":"`+addrNew+`","name":"Alice","tags":"x"}]`), "json")
+	require.NoError(t, err)
+	assert.Equal(t, "x", jsonItems[0].Tags)
+
+	_, err = ParseBulkNames([]byte("address,label\n"), "csv")
+	assert.Error(t, err, "a header without a name column")
+	_, err = ParseBulkNames(nil, "xml")
+	assert.Error(t, err)
+}
+
+func TestPreviewBulkNames(t *testing.T) {
+	items := []BulkItem{
+		{Line: 1, Address: addrNew, Name: "Alice"},
+		{Line: 2, Address: addrCustom, Name: "New", Tags: "t"},
+		{Line: 3, Address: addrSame, Name: "Same", Tags: "t", Source: "s"},
+		{Line: 4, Address: addrPrefund, Name: "Mine"},
+		{Line: 5, Address: "0x12", Name: "Bad"},
+		{Line: 6, Address: addrNew, Name: "Again"},
+		{Line: 7, Address: "0x0000000000000000000000000000000000000000", Name: "Zero"},
+		{Line: 8, Address: "0x5555555555555555555555555555555555555555"},
+	}
+	preview := previewBulkNames(items, bulkLookup)
+
+	actions := []BulkAction{}
+	for _, item := range preview.Items {
+		actions = append(actions, item.Action)
+	}
+	assert.Equal(t, []BulkAction{BulkCreate, BulkUpdate, BulkSkip, BulkCreate, BulkInvalid, BulkSkip, BulkInvalid, BulkInvalid}, actions)
+	assert.Equal(t, 2, preview.Creates)
+	assert.Equal(t, 1, preview.Updates)
+	assert.Equal(t, 2, preview.Skips)
+	assert.Equal(t, 3, preview.Invalid)
+
+	assert.Equal(t, "custom", preview.Items[1].Collision)
+	assert.Equal(t, "prefund", preview.Items[3].Collision)
+	assert.Contains(t, preview.Items[3].Reason, "Genesis")
+	assert.Contains(t, preview.Items[5].Reason, "line 1")
+}
+
+type recordedWrite struct {
+	op   crud.Operation
+	name string
+}
+
+func TestApplyAndRollbackBulkNames(t *testing.T) {
+	preview := previewBulkNames([]BulkItem{
+		{Line: 1, Address: addrNew, Name: "Alice"},
+		{Line: 2, Address: addrCustom, Name: "New", Tags: "t"},
+		{Line: 3, Address: addrSame, Name: "Same", Tags: "t", Source: "s"},
+	}, bulkLookup)
+
+	var writes []recordedWrite
+	write := func(op crud.Operation, n *Name) error {
+		writes = append(writes, recordedWrite{op, n.Name})
+		return nil
+	}
+
+	batch, err := applyBulkNames(preview, write)
+	require.NoError(t, err)
+	require.Len(t, batch.Changes, 2, "the unchanged row is not written")
+	assert.Equal(t, []recordedWrite{{crud.Create, "Alice"}, {crud.Update, "New"}}, writes)
+	assert.Equal(t, "Old", batch.Changes[1].Before.Name)
+
+	writes = nil
+	require.NoError(t, rollbackBulkNames(batch.Changes, write))
+	assert.Equal(t, []recordedWrite{{crud.Update, "Old"}, {crud.Delete, "Alice"}, {crud.Remove, "Alice"}}, writes)
+}
+
+func TestApplyBulkNamesUndoesAFailedBatch(t *testing.T) {
+	preview := previewBulkNames([]BulkItem{
+		{Line: 1, Address: addrNew, Name: "Alice"},
+		{Line: 2, Address: addrCustom, Name: "New"},
+	}, bulkLookup)
+
+	var writes []recordedWrite
+	write := func(op crud.Operation, n *Name) error {
+		if op == crud.Update && n.Name == "New" {
+			return errors.New("disk full")
+		}
+		writes = append(writes, recordedWrite{op, n.Name})
+		return nil
+	}
+
+	_, err := applyBulkNames(preview, write)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 2")
+	assert.Equal(t, []recordedWrite{{crud.Create, "Alice"}, {crud.Delete, "Alice"}, {crud.Remove, "Alice"}}, writes)
+}
+
+func TestBulkBatchesArePersisted(t *testing.T) {
+	dir := t.TempDir()
+	saved := bulkBatchesDir
+	bulkBatchesDir = func() string { return dir }
+	t.Cleanup(func() { bulkBatchesDir = saved })
+
+	preview := previewBulkNames([]BulkItem{{Line: 1, Address: addrCustom, Name: "New"}}, bulkLookup)
+	batch, err := applyBulkNames(preview, func(crud.Operation, *Name) error { return nil })
+	require.NoError(t, err)
+	require.NoError(t, saveBulkBatch(batch))
+
+	loaded, err := loadBulkBatch(batch.ID)
+	require.NoError(t, err)
+	assert.Equal(t, batch.ID, loaded.ID)
+	require.Len(t, loaded.Changes, 1)
+	assert.Equal(t, "Old", loaded.Changes[0].Before.Name)
+	assert.Equal(t, crud.Update, loaded.Changes[0].Op)
+
+	_, err = loadBulkBatch("names-1")
+	assert.ErrorContains(t, err, "no bulk import batch")
+	_, err = loadBulkBatch("../names-1")
+	assert.ErrorContains(t, err, "invalid bulk import batch id")
+}
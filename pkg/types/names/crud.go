@@ -14,57 +14,14 @@ import (
 
 var namesLock atomic.Int32
 
-// AutonameAddress performs autoname operation for a given address using the SDK.
-// This function can be called from other collections for cross-collection autoname support.
-func AutonameAddress(address string) error {
-	// Acquire lock to prevent concurrent autoname operations
-	if !namesLock.CompareAndSwap(0, 1) {
-		return fmt.Errorf("autoname operation already in progress")
-	}
-	defer namesLock.Store(0)
-
-	// Create name object for the address
-	name := &Name{
-		Address:  base.HexToAddress(address),
-		IsCustom: true,
-	}
-
-	// Prepare SDK options and CRUD data
-	cd := crud.CrudFromName(*name)
-	opts := sdk.NamesOptions{
-		Globals: sdk.Globals{
-			Chain: "mainnet",
-		},
-	}
-
-	// Execute autoname operation via SDK
-	if _, _, err := opts.ModifyName(crud.Autoname, cd); err != nil {
-		msgs.EmitError("AutonameAddress", err)
+// AutonameAddress names a single address on chain through the autoname queue and waits
+// for it. Other collections call this for cross-collection autoname support.
+func AutonameAddress(chain, address string) error {
+	job, err := AutonameAddresses(chain, []string{address})
+	if err != nil {
 		return err
 	}
-
-	// Reset Names collection cache to ensure fresh data is loaded
-	// Create a minimal payload to get the Names collection instance
-	payload := &types.Payload{
-		Collection: "names",
-		DataFacet:  NamesCustom, // Use custom facet as it's most commonly used for autoname
-	}
-	collection := GetNamesCollection(payload)
-
-	reset := func(c *NamesCollection, f types.DataFacet) {
-		payload := &types.Payload{DataFacet: f}
-		c.Reset(payload)
-	}
-	// Reset all facets to ensure consistency across all Names views
-	reset(collection, NamesAll)
-	reset(collection, NamesCustom)
-	reset(collection, NamesRegular)
-	// Note: Prefund and Baddress typically don't change with autoname, but reset for consistency
-	reset(collection, NamesPrefund)
-	reset(collection, NamesBaddress)
-
-	msgs.EmitStatus(fmt.Sprintf("completed autoname operation for address: %s", address))
-	return nil
+	return job.Wait()
 }
 
 func (c *NamesCollection) Crud(
@@ -83,13 +40,9 @@ func (c *NamesCollection) Crud(
 		}
 	}
 
-	// Special handling for Autoname - delegate to public function
+	// Autoname goes through the queue, which resets the facets when it is done
 	if op == crud.Autoname {
-		if err := AutonameAddress(name.Address.Hex()); err != nil {
-			return err
-		}
-		c.Reset(payload)
-		return nil
+		return AutonameAddress(payload.ActiveChain, name.Address.Hex())
 	}
 
 	// For all other operations, use the standard lock/SDK pattern
@@ -13,6 +13,8 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/query"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/sorting"
 	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
 	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
 )
@@ -68,14 +70,15 @@ func (c *NamesCollection) GetPage(
 		facet := c.allFacet
 		var filterFunc func(*Name) bool
 		if filter != "" {
-			filterFunc = func(item *Name) bool {
-				return c.matchesAllFilter(item, filter)
+			var err error
+			if filterFunc, err = query.NewFilter(filter, query.FacetFields(c, dataFacet), c.matchesAllFilter); err != nil {
+				return nil, types.NewValidationError("names", dataFacet, "GetPage", err)
 			}
 		}
 		sortFunc := func(items []Name, sort sdk.SortSpec) error {
-			return sdk.SortNames(items, sort)
+			return sorting.SortBy(items, sort)
 		}
-		if result, err := facet.GetPage(first, pageSize, filterFunc, sortSpec, sortFunc); err != nil {
+		if result, err := facet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
 			return nil, types.NewStoreError("names", dataFacet, "GetPage", err)
 		} else {
 			page.Names = result.Items
@@ -87,14 +90,15 @@ func (c *NamesCollection) GetPage(
 		facet := c.customFacet
 		var filterFunc func(*Name) bool
 		if filter != "" {
-			filterFunc = func(item *Name) bool {
-				return c.matchesCustomFilter(item, filter)
+			var err error
+			if filterFunc, err = query.NewFilter(filter, query.FacetFields(c, dataFacet), c.matchesCustomFilter); err != nil {
+				return nil, types.NewValidationError("names", dataFacet, "GetPage", err)
 			}
 		}
 		sortFunc := func(items []Name, sort sdk.SortSpec) error {
-			return sdk.SortNames(items, sort)
+			return sorting.SortBy(items, sort)
 		}
-		if result, err := facet.GetPage(first, pageSize, filterFunc, sortSpec, sortFunc); err != nil {
+		if result, err := facet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
 			return nil, types.NewStoreError("names", dataFacet, "GetPage", err)
 		} else {
 			page.Names = result.Items
@@ -106,14 +110,15 @@ func (c *NamesCollection) GetPage(
 		facet := c.prefundFacet
 		var filterFunc func(*Name) bool
 		if filter != "" {
-			filterFunc = func(item *Name) bool {
-				return c.matchesPrefundFilter(item, filter)
+			var err error
+			if filterFunc, err = query.NewFilter(filter, query.FacetFields(c, dataFacet), c.matchesPrefundFilter); err != nil {
+				return nil, types.NewValidationError("names", dataFacet, "GetPage", err)
 			}
 		}
 		sortFunc := func(items []Name, sort sdk.SortSpec) error {
-			return sdk.SortNames(items, sort)
+			return sorting.SortBy(items, sort)
 		}
-		if result, err := facet.GetPage(first, pageSize, filterFunc, sortSpec, sortFunc); err != nil {
+		if result, err := facet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
 			return nil, types.NewStoreError("names", dataFacet, "GetPage", err)
 		} else {
 			page.Names = result.Items
@@ -125,14 +130,15 @@ func (c *NamesCollection) GetPage(
 		facet := c.regularFacet
 		var filterFunc func(*Name) bool
 		if filter != "" {
-			filterFunc = func(item *Name) bool {
-				return c.matchesRegularFilter(item, filter)
+			var err error
+			if filterFunc, err = query.NewFilter(filter, query.FacetFields(c, dataFacet), c.matchesRegularFilter); err != nil {
+				return nil, types.NewValidationError("names", dataFacet, "GetPage", err)
 			}
 		}
 		sortFunc := func(items []Name, sort sdk.SortSpec) error {
-			return sdk.SortNames(items, sort)
+			return sorting.SortBy(items, sort)
 		}
-		if result, err := facet.GetPage(first, pageSize, filterFunc, sortSpec, sortFunc); err != nil {
+		if result, err := facet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
 			return nil, types.NewStoreError("names", dataFacet, "GetPage", err)
 		} else {
 			page.Names = result.Items
@@ -144,14 +150,15 @@ func (c *NamesCollection) GetPage(
 		facet := c.baddressFacet
 		var filterFunc func(*Name) bool
 		if filter != "" {
-			filterFunc = func(item *Name) bool {
-				return c.matchesBaddressFilter(item, filter)
+			var err error
+			if filterFunc, err = query.NewFilter(filter, query.FacetFields(c, dataFacet), c.matchesBaddressFilter); err != nil {
+				return nil, types.NewValidationError("names", dataFacet, "GetPage", err)
 			}
 		}
 		sortFunc := func(items []Name, sort sdk.SortSpec) error {
-			return sdk.SortNames(items, sort)
+			return sorting.SortBy(items, sort)
 		}
-		if result, err := facet.GetPage(first, pageSize, filterFunc, sortSpec, sortFunc); err != nil {
+		if result, err := facet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
 			return nil, types.NewStoreError("names", dataFacet, "GetPage", err)
 		} else {
 			page.Names = result.Items
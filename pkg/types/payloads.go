@@ -1,5 +1,9 @@
 package types
 
+import (
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+)
+
 type Payload struct {
 	Collection       string    `json:"collection"`
 	DataFacet        DataFacet `json:"dataFacet"`
@@ -12,6 +16,9 @@ type Payload struct {
 	TargetSwitch     bool      `json:"targetSwitch,omitempty"`
 	Format           string    `json:"format,omitempty"`
 	ProjectPath      string    `json:"projectPath,omitempty"`
+	// Sort is the facet's (possibly multi-field) sort, used when a call does not
+	// carry its own SortSpec (for example ExportData)
+	Sort *sdk.SortSpec `json:"sort,omitempty"`
 }
 
 func (p *Payload) ShouldSummarize() bool {
@@ -0,0 +1,167 @@
+// Copyright 2016, 2026 The Authors. All rights reserved.
+// Use of this source code is governed by a license that can
+// be found in the LICENSE file.
+/*
+ * Parts of this file were auto generated. Edit only those parts of
+ * the code inside of 'EXISTING_CODE' tags.
+ */
+
+package portfolio
+
+import "github.com/TrueBlocks/trueblocks-explorer/pkg/types"
+
+// GetConfig returns the ViewConfig for the Portfolio view
+func (c *PortfolioCollection) GetConfig() (*types.ViewConfig, error) {
+	facets := c.buildStaticFacets()
+	facetOrder := c.buildFacetOrder()
+
+	cfg := &types.ViewConfig{
+		ViewName:   "portfolio",
+		Facets:     facets,
+		FacetOrder: facetOrder,
+		Actions:    c.buildActions(),
+	}
+
+	c.addDynamicFields(cfg)
+
+	types.DeriveFacets(cfg)
+	types.SortFields(cfg)
+	types.SetMenuOrder(cfg)
+	return cfg, nil
+}
+
+func (c *PortfolioCollection) buildStaticFacets() map[string]types.FacetConfig {
+	return map[string]types.FacetConfig{
+		"assets": {
+			Name:          "Assets",
+			Store:         "holdings",
+			ViewType:      "table",
+			DividerBefore: false,
+			Fields:        getHoldingsFields(),
+			Actions:       []string{},
+			HeaderActions: []string{"export"},
+		},
+		"balances": {
+			Name:          "Balances",
+			Store:         "positions",
+			ViewType:      "table",
+			DividerBefore: false,
+			Fields:        getPositionsFields(),
+			Actions:       []string{},
+			HeaderActions: []string{"export"},
+		},
+		"statements": {
+			Name:          "Statements",
+			Store:         "movements",
+			ViewType:      "table",
+			DividerBefore: false,
+			Fields:        getMovementsFields(),
+			Actions:       []string{},
+			HeaderActions: []string{"export"},
+		},
+	}
+}
+
+func (c *PortfolioCollection) buildFacetOrder() []string {
+	return []string{
+		"assets",
+		"balances",
+		"statements",
+	}
+}
+
+func (c *PortfolioCollection) buildActions() map[string]types.ActionConfig {
+	return map[string]types.ActionConfig{
+		"export": {Name: "export", Label: "Export", Icon: "Export"},
+	}
+}
+
+func getHoldingsFields() []types.FieldConfig {
+	ret := []types.FieldConfig{
+		{Section: "Asset", Key: "asset", Type: "address"},
+		{Section: "Asset", Key: "assetName", Type: "string"},
+		{Section: "Asset", Key: "symbol", Type: "string"},
+		{Section: "Asset", Key: "decimals", Type: "value", NoTable: true},
+		{Section: "Holdings", Key: "balanceEth", Type: "ether"},
+		{Section: "Holdings", Key: "balance", Type: "int256", NoTable: true},
+		{Section: "Holdings", Key: "spotPrice", Type: "float64"},
+		{Section: "Holdings", Key: "value", Type: "float64"},
+		{Section: "Holdings", Key: "holders", Type: "uint64"},
+		{Section: "Holdings", Key: "lastBlock", Type: "blknum", NoTable: true},
+	}
+	types.NormalizeFields(&ret)
+	return ret
+}
+
+func getMovementsFields() []types.FieldConfig {
+	ret := []types.FieldConfig{
+		{Section: "Asset", Key: "timestamp", Type: "timestamp"},
+		{Section: "Asset", Key: "asset", Type: "address"},
+		{Section: "Asset", Key: "assetName", Type: "string"},
+		{Section: "Asset", Key: "symbol", Type: "string", NoTable: true},
+		{Section: "Asset", Key: "decimals", Type: "value", NoTable: true},
+		{Section: "Asset", Key: "priceSource", Type: "string", NoTable: true},
+		{Section: "Reconciliation", Key: "calcs.begBalEth", Type: "ether"},
+		{Section: "Reconciliation", Key: "calcs.totalInEth", Type: "ether"},
+		{Section: "Reconciliation", Key: "calcs.totalOutEth", Type: "ether"},
+		{Section: "Reconciliation", Key: "calcs.amountNetEth", Type: "ether", NoTable: true},
+		{Section: "Reconciliation", Key: "calcs.endBalEth", Type: "ether"},
+		{Section: "Asset", Key: "spotPrice", Type: "float64", NoTable: true},
+		{Section: "Reconciliation", Key: "calcs.endBalCalcEth", Type: "ether", NoTable: true},
+		{Section: "Summary", Key: "date", Type: "datetime", NoTable: true},
+		{Section: "Summary", Key: "gasUsed", Type: "gas", NoTable: true},
+		{Section: "Summary", Key: "calcs.reconciliationType", Type: "string", NoTable: true},
+		{Section: "Summary", Key: "accountedFor", Type: "address"},
+		{Section: "Summary", Key: "accountedForName", Type: "string", NoTable: true},
+		{Section: "Reconciliation", Key: "calcs.reconciled", Type: "checkmark", Label: "chk"},
+		{Section: "Summary", Key: "internal", Type: "checkmark"},
+		{Section: "Inflow", Key: "amountIn", Type: "int256", NoTable: true},
+		{Section: "Inflow", Key: "internalIn", Type: "int256", NoTable: true},
+		{Section: "Inflow", Key: "selfDestructIn", Type: "int256", NoTable: true},
+		{Section: "Inflow", Key: "minerBaseRewardIn", Type: "int256", NoTable: true},
+		{Section: "Inflow", Key: "minerTxFeeIn", Type: "int256", NoTable: true},
+		{Section: "Inflow", Key: "prefundIn", Type: "int256", NoTable: true},
+		{Section: "Outflow", Key: "amountOut", Type: "int256", NoTable: true},
+		{Section: "Outflow", Key: "internalOut", Type: "int256", NoTable: true},
+		{Section: "Outflow", Key: "selfDestructOut", Type: "int256", NoTable: true},
+		{Section: "Outflow", Key: "gasOut", Type: "int256", NoTable: true},
+		{Section: "Details", Key: "blockNumber", Type: "blknum", NoTable: true},
+		{Section: "Details", Key: "transactionIndex", Type: "txnum", NoTable: true},
+		{Section: "Details", Key: "logIndex", Type: "lognum", NoTable: true},
+		{Section: "Details", Key: "transactionHash", Type: "hash", NoTable: true},
+		{Section: "Details", Key: "sender", Type: "address", NoTable: true},
+		{Section: "Details", Key: "senderName", Type: "string", NoTable: true},
+		{Section: "Details", Key: "recipient", Type: "address", NoTable: true},
+		{Section: "Details", Key: "recipientName", Type: "string", NoTable: true},
+		{Section: "Analysis", Key: "prevBal", Type: "int256", NoTable: true},
+		{Section: "Analysis", Key: "begBalDiff", Type: "int256", NoTable: true},
+		{Section: "Analysis", Key: "endBalDiff", Type: "int256", NoTable: true},
+		{Section: "Analysis", Key: "correctingReasons", Type: "string", NoTable: true},
+		{Section: "Corrections", Key: "correctBegBalIn", Type: "int256", NoTable: true},
+		{Section: "Corrections", Key: "correctAmountIn", Type: "int256", NoTable: true},
+		{Section: "Corrections", Key: "correctEndBalIn", Type: "int256", NoTable: true},
+		{Section: "Corrections", Key: "correctBegBalOut", Type: "int256", NoTable: true},
+		{Section: "Corrections", Key: "correctAmountOut", Type: "int256", NoTable: true},
+		{Section: "Corrections", Key: "correctEndBalOut", Type: "int256", NoTable: true},
+	}
+	types.NormalizeFields(&ret)
+	return ret
+}
+
+func getPositionsFields() []types.FieldConfig {
+	ret := []types.FieldConfig{
+		{Section: "Token", Key: "asset", Type: "address"},
+		{Section: "Token", Key: "assetName", Type: "string"},
+		{Section: "Token", Key: "symbol", Type: "string"},
+		{Section: "Token", Key: "decimals", Type: "value", NoTable: true},
+		{Section: "Balances", Key: "balanceEth", Type: "ether"},
+		{Section: "Balances", Key: "balance", Type: "int256", NoTable: true},
+		{Section: "Balances", Key: "holders", Type: "uint64"},
+		{Section: "Balances", Key: "lastBlock", Type: "blknum", NoTable: true},
+	}
+	types.NormalizeFields(&ret)
+	return ret
+}
+
+// EXISTING_CODE
+// EXISTING_CODE
@@ -0,0 +1,339 @@
+package portfolio
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types/exports"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types/names"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+)
+
+var (
+	addressSource   func() []base.Address
+	addressSourceMu sync.RWMutex
+)
+
+// SetAddressSource installs the function the portfolio calls to list the active project's
+// addresses. The app sets this once at startup.
+func SetAddressSource(source func() []base.Address) {
+	addressSourceMu.Lock()
+	defer addressSourceMu.Unlock()
+	addressSource = source
+}
+
+// projectAddresses returns the active project's addresses sorted and without duplicates
+func projectAddresses() []base.Address {
+	addressSourceMu.RLock()
+	source := addressSource
+	addressSourceMu.RUnlock()
+	if source == nil {
+		return nil
+	}
+
+	seen := make(map[base.Address]bool)
+	ret := make([]base.Address, 0)
+	for _, addr := range source() {
+		if addr == base.ZeroAddr || seen[addr] {
+			continue
+		}
+		seen[addr] = true
+		ret = append(ret, addr)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Hex() < ret[j].Hex()
+	})
+	return ret
+}
+
+// addressesKey returns a short, stable key for a set of addresses so that a project whose
+// address list changes gets fresh stores
+func addressesKey(addrs []base.Address) string {
+	if len(addrs) == 0 {
+		return "none"
+	}
+	hexes := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		hexes = append(hexes, addr.Hex())
+	}
+	sum := sha256.Sum256([]byte(strings.Join(hexes, ",")))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// loadStatements and loadBalances read a single address's data from the exports stores.
+// They are variables so tests can supply their own data.
+var (
+	loadStatements = exports.LoadStatements
+	loadBalances   = exports.LoadBalances
+)
+
+func collectStatements(chain string, addrs []base.Address) ([]*sdk.Statement, error) {
+	ret := make([]*sdk.Statement, 0)
+	for _, addr := range addrs {
+		statements, err := loadStatements(chain, addr.Hex())
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, statements...)
+	}
+	return ret, nil
+}
+
+func collectBalances(chain string, addrs []base.Address) ([]*sdk.Balance, error) {
+	ret := make([]*sdk.Balance, 0)
+	for _, addr := range addrs {
+		balances, err := loadBalances(chain, addr.Hex())
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, balances...)
+	}
+	return ret, nil
+}
+
+type transferKey struct {
+	asset     base.Address
+	block     base.Blknum
+	txIndex   base.Txnum
+	logIndex  base.Lognum
+	sender    base.Address
+	recipient base.Address
+}
+
+func newTransferKey(s *sdk.Statement) transferKey {
+	return transferKey{s.Asset, s.BlockNumber, s.TransactionIndex, s.LogIndex, s.Sender, s.Recipient}
+}
+
+// mergeMovements combines the statements of every project address. A transfer between two
+// project addresses appears in both addresses' statements; it is kept once, from the
+// sender's side, and marked internal. The recipient's copy is kept only if the sender's
+// statements do not include the transfer.
+func mergeMovements(statements []*sdk.Statement, addrs []base.Address) []*Movement {
+	inProject := make(map[base.Address]bool, len(addrs))
+	for _, addr := range addrs {
+		inProject[addr] = true
+	}
+
+	isInternal := func(s *sdk.Statement) bool {
+		return s.Sender != s.Recipient && inProject[s.Sender] && inProject[s.Recipient]
+	}
+
+	senderSide := make(map[transferKey]bool)
+	for _, s := range statements {
+		if isInternal(s) && s.AccountedFor == s.Sender {
+			senderSide[newTransferKey(s)] = true
+		}
+	}
+
+	ret := make([]*Movement, 0, len(statements))
+	for _, s := range statements {
+		internal := isInternal(s)
+		if internal && s.AccountedFor == s.Recipient && senderSide[newTransferKey(s)] {
+			continue
+		}
+		ret = append(ret, &Movement{Statement: *s, Internal: internal})
+	}
+
+	sort.SliceStable(ret, func(i, j int) bool {
+		a, b := &ret[i].Statement, &ret[j].Statement
+		if a.BlockNumber != b.BlockNumber {
+			return a.BlockNumber < b.BlockNumber
+		}
+		if a.TransactionIndex != b.TransactionIndex {
+			return a.TransactionIndex < b.TransactionIndex
+		}
+		if a.LogIndex != b.LogIndex {
+			return a.LogIndex < b.LogIndex
+		}
+		return a.AccountedFor.Hex() < b.AccountedFor.Hex()
+	})
+	return ret
+}
+
+// mergeHoldings reports, for each asset, the sum of every project address's ending
+// balance on its most recent statement for that asset
+func mergeHoldings(statements []*sdk.Statement, addrs []base.Address) []*Holding {
+	type holdingKey struct {
+		holder base.Address
+		asset  base.Address
+	}
+
+	latest := make(map[holdingKey]*sdk.Statement)
+	newest := make(map[base.Address]*sdk.Statement)
+	for _, s := range statements {
+		key := holdingKey{s.AccountedFor, s.Asset}
+		if prev, ok := latest[key]; !ok || statementAfter(s, prev) {
+			latest[key] = s
+		}
+		if prev, ok := newest[s.Asset]; !ok || statementAfter(s, prev) {
+			newest[s.Asset] = s
+		}
+	}
+
+	byAsset := make(map[base.Address]*Holding)
+	for key, s := range latest {
+		holding := byAsset[key.asset]
+		if holding == nil {
+			holding = newHolding(addrs)
+			holding.Asset = s.Asset
+			holding.Decimals = uint64(s.Decimals)
+			byAsset[key.asset] = holding
+		}
+		holding.add(key.holder, &s.EndBal, s.BlockNumber)
+	}
+
+	ret := make([]*Holding, 0, len(byAsset))
+	for asset, holding := range byAsset {
+		s := newest[asset]
+		holding.AssetName = s.AssetName
+		holding.Symbol = s.Symbol
+		holding.SpotPrice = s.SpotPrice.Float64()
+		holding.finish()
+		ret = append(ret, holding)
+	}
+	sortHoldings(ret)
+	return ret
+}
+
+// mergePositions reports, for each token, the sum of every project address's most recent
+// balance of that token
+func mergePositions(balances []*sdk.Balance, addrs []base.Address) []*Position {
+	type positionKey struct {
+		holder base.Address
+		token  base.Address
+	}
+
+	latest := make(map[positionKey]*sdk.Balance)
+	for _, b := range balances {
+		key := positionKey{b.Holder, b.Address}
+		if prev, ok := latest[key]; !ok || balanceAfter(b, prev) {
+			latest[key] = b
+		}
+	}
+
+	byToken := make(map[base.Address]*Position)
+	for key, b := range latest {
+		position := byToken[key.token]
+		if position == nil {
+			position = newHolding(addrs)
+			position.Asset = b.Address
+			position.Decimals = b.Decimals
+			position.Symbol = b.Symbol
+			position.AssetName = b.AddressName
+			if position.AssetName == "" {
+				position.AssetName = b.Name
+			}
+			byToken[key.token] = position
+		}
+		position.add(key.holder, &b.Balance, b.BlockNumber)
+	}
+
+	ret := make([]*Position, 0, len(byToken))
+	for _, position := range byToken {
+		position.finish()
+		ret = append(ret, position)
+	}
+	sortHoldings(ret)
+	return ret
+}
+
+func newHolding(addrs []base.Address) *Holding {
+	holding := &Holding{Breakdown: make(map[string]string, len(addrs))}
+	for _, addr := range addrs {
+		holding.Breakdown[breakdownKey(addr)] = "0"
+	}
+	return holding
+}
+
+// add folds one address's balance into the holding
+func (h *Holding) add(holder base.Address, balance *base.Wei, block base.Blknum) {
+	h.Balance = *h.Balance.Add(&h.Balance, balance)
+	if !balance.IsZero() {
+		h.Holders++
+	}
+	if block > h.LastBlock {
+		h.LastBlock = block
+	}
+	h.Breakdown[breakdownKey(holder)] = balance.ToFloatString(int(h.Decimals))
+}
+
+// finish fills in the display balance and its value at the spot price
+func (h *Holding) finish() {
+	h.BalanceEth = h.Balance.ToFloatString(int(h.Decimals))
+	if amount, err := strconv.ParseFloat(h.BalanceEth, 64); err == nil {
+		h.Value = amount * h.SpotPrice
+	}
+}
+
+// addDynamicFields adds the columns that depend on the addresses in the active project
+func (c *PortfolioCollection) addDynamicFields(cfg *types.ViewConfig) {
+	addBreakdownFields(cfg, projectAddresses())
+}
+
+// addBreakdownFields adds a column per project address to the assets and balances facets
+// showing that address's share of the combined balance
+func addBreakdownFields(cfg *types.ViewConfig, addrs []base.Address) {
+	if len(addrs) == 0 {
+		return
+	}
+
+	breakdown := make([]types.FieldConfig, 0, len(addrs))
+	for _, addr := range addrs {
+		label := names.NameAddress(addr)
+		if label == "" {
+			hex := addr.Hex()
+			label = hex[:6] + "..." + hex[len(hex)-4:]
+		}
+		breakdown = append(breakdown, types.FieldConfig{
+			Section: "Breakdown",
+			Key:     "breakdown." + breakdownKey(addr),
+			Type:    "ether",
+			Label:   label,
+		})
+	}
+	types.NormalizeFields(&breakdown)
+
+	for _, dataFacet := range []types.DataFacet{PortfolioAssets, PortfolioBalances} {
+		if facet, ok := cfg.Facets[string(dataFacet)]; ok {
+			facet.Fields = append(facet.Fields, breakdown...)
+			cfg.Facets[string(dataFacet)] = facet
+		}
+	}
+}
+
+func breakdownKey(addr base.Address) string {
+	return strings.ToLower(addr.Hex())
+}
+
+func sortHoldings(holdings []*Holding) {
+	sort.Slice(holdings, func(i, j int) bool {
+		if holdings[i].Value != holdings[j].Value {
+			return holdings[i].Value > holdings[j].Value
+		}
+		return holdings[i].Asset.Hex() < holdings[j].Asset.Hex()
+	})
+}
+
+func statementAfter(a, b *sdk.Statement) bool {
+	if a.BlockNumber != b.BlockNumber {
+		return a.BlockNumber > b.BlockNumber
+	}
+	if a.TransactionIndex != b.TransactionIndex {
+		return a.TransactionIndex > b.TransactionIndex
+	}
+	return a.LogIndex > b.LogIndex
+}
+
+func balanceAfter(a, b *sdk.Balance) bool {
+	if a.BlockNumber != b.BlockNumber {
+		return a.BlockNumber > b.BlockNumber
+	}
+	return a.TransactionIndex > b.TransactionIndex
+}
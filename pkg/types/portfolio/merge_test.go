@@ -0,0 +1,135 @@
+package portfolio
+
+import (
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	alice    = base.HexToAddress("0x00000000000000000000000000000000000000a1")
+	bob      = base.HexToAddress("0x00000000000000000000000000000000000000b0")
+	stranger = base.HexToAddress("0x00000000000000000000000000000000000000c0")
+	usdc     = base.HexToAddress("0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48")
+)
+
+func newStatement(accountedFor, sender, recipient base.Address, block, txIndex uint64, endBal string) *sdk.Statement {
+	s := &sdk.Statement{
+		AccountedFor:     accountedFor,
+		Asset:            usdc,
+		Symbol:           "USDC",
+		Decimals:         6,
+		BlockNumber:      base.Blknum(block),
+		TransactionIndex: base.Txnum(txIndex),
+		Sender:           sender,
+		Recipient:        recipient,
+		EndBal:           *base.NewWeiStr(endBal),
+	}
+	s.SpotPrice.SetFloat64(1)
+	return s
+}
+
+func TestMergeMovementsDedupesInternalTransfers(t *testing.T) {
+	addrs := []base.Address{alice, bob}
+	statements := []*sdk.Statement{
+		newStatement(alice, stranger, alice, 10, 0, "5000000"),
+		newStatement(alice, alice, bob, 20, 1, "3000000"),
+		newStatement(bob, alice, bob, 20, 1, "2000000"),
+		newStatement(alice, bob, alice, 30, 0, "4000000"), // bob's side was never exported
+	}
+
+	movements := mergeMovements(statements, addrs)
+	require.Len(t, movements, 3)
+
+	assert.False(t, movements[0].Internal, "a transfer from outside the project is external")
+	assert.True(t, movements[1].Internal)
+	assert.Equal(t, alice, movements[1].AccountedFor, "the sender's side of an internal transfer is kept")
+	assert.True(t, movements[2].Internal, "the recipient's side is kept when the sender's is missing")
+	assert.Equal(t, alice, movements[2].AccountedFor)
+}
+
+func TestMergeHoldingsSumsLatestBalances(t *testing.T) {
+	addrs := []base.Address{alice, bob}
+	statements := []*sdk.Statement{
+		newStatement(alice, stranger, alice, 10, 0, "5000000"),
+		newStatement(alice, alice, bob, 20, 1, "3000000"),
+		newStatement(bob, alice, bob, 20, 1, "2000000"),
+	}
+
+	holdings := mergeHoldings(statements, addrs)
+	require.Len(t, holdings, 1)
+
+	h := holdings[0]
+	assert.Equal(t, "5000000", h.Balance.String())
+	assert.Equal(t, "5", h.BalanceEth)
+	assert.Equal(t, 5.0, h.Value)
+	assert.Equal(t, uint64(2), h.Holders)
+	assert.Equal(t, base.Blknum(20), h.LastBlock)
+	assert.Equal(t, "3", h.Breakdown[breakdownKey(alice)])
+	assert.Equal(t, "2", h.Breakdown[breakdownKey(bob)])
+}
+
+func TestMergePositionsFillsEveryAddress(t *testing.T) {
+	addrs := []base.Address{alice, bob}
+	balances := []*sdk.Balance{
+		{Address: usdc, Holder: alice, Balance: *base.NewWeiStr("1000000"), BlockNumber: 5, Decimals: 6, Symbol: "USDC"},
+		{Address: usdc, Holder: alice, Balance: *base.NewWeiStr("7000000"), BlockNumber: 9, Decimals: 6, Symbol: "USDC"},
+	}
+
+	positions := mergePositions(balances, addrs)
+	require.Len(t, positions, 1)
+	assert.Equal(t, "7", positions[0].BalanceEth)
+	assert.Equal(t, uint64(1), positions[0].Holders)
+	assert.Equal(t, "7", positions[0].Breakdown[breakdownKey(alice)])
+	assert.Equal(t, "0", positions[0].Breakdown[breakdownKey(bob)], "addresses without the token still get a column")
+}
+
+func TestPortfolioStoreMergesEveryAddress(t *testing.T) {
+	SetAddressSource(func() []base.Address { return []base.Address{bob, alice, bob} })
+	defer SetAddressSource(nil)
+
+	byAddress := map[string][]*sdk.Statement{
+		alice.Hex(): {newStatement(alice, alice, bob, 20, 1, "3000000")},
+		bob.Hex():   {newStatement(bob, alice, bob, 20, 1, "2000000")},
+	}
+	saved := loadStatements
+	loadStatements = func(chain, address string) ([]*sdk.Statement, error) {
+		return byAddress[address], nil
+	}
+	defer func() { loadStatements = saved }()
+
+	payload := &types.Payload{Collection: "portfolio", DataFacet: PortfolioStatements, ActiveChain: "mainnet"}
+	theStore := GetPortfolioCollection(payload).statementsFacet.GetStore()
+	require.NoError(t, theStore.Fetch())
+
+	items := theStore.GetItems(false)
+	require.Len(t, items, 1)
+	assert.True(t, items[0].Internal)
+
+	cfg, err := GetPortfolioCollection(payload).GetConfig()
+	require.NoError(t, err)
+	keys := make([]string, 0)
+	for _, col := range cfg.Facets[string(PortfolioAssets)].Columns {
+		keys = append(keys, col.Key)
+	}
+	assert.Contains(t, keys, "breakdown."+breakdownKey(alice))
+	assert.Contains(t, keys, "breakdown."+breakdownKey(bob))
+}
+
+func TestStoreKeyFollowsAddressSet(t *testing.T) {
+	defer SetAddressSource(nil)
+	payload := &types.Payload{Collection: "portfolio", ActiveChain: "mainnet"}
+
+	SetAddressSource(func() []base.Address { return []base.Address{alice, bob} })
+	both := getStoreKey(payload)
+	SetAddressSource(func() []base.Address { return []base.Address{bob, alice} })
+	assert.Equal(t, both, getStoreKey(payload), "address order does not matter")
+
+	SetAddressSource(func() []base.Address { return []base.Address{alice} })
+	assert.NotEqual(t, both, getStoreKey(payload), "a changed address set gets its own stores")
+}
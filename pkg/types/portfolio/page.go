@@ -0,0 +1,231 @@
+// Copyright 2016, 2026 The Authors. All rights reserved.
+// Use of this source code is governed by a license that can
+// be found in the LICENSE file.
+/*
+ * Parts of this file were auto generated. Edit only those parts of
+ * the code inside of 'EXISTING_CODE' tags.
+ */
+
+package portfolio
+
+// EXISTING_CODE
+import (
+	"fmt"
+	"strings"
+
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/query"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/sorting"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+)
+
+// EXISTING_CODE
+
+type PortfolioPage struct {
+	Facet         types.DataFacet  `json:"facet"`
+	Assets        []Holding        `json:"assets"`
+	Balances      []Position       `json:"balances"`
+	Statements    []Movement       `json:"statements"`
+	TotalItems    int              `json:"totalItems"`
+	ExpectedTotal int              `json:"expectedTotal"`
+	State         types.StoreState `json:"state"`
+	// EXISTING_CODE
+	// EXISTING_CODE
+}
+
+func (p *PortfolioPage) GetFacet() types.DataFacet {
+	return p.Facet
+}
+
+func (p *PortfolioPage) GetTotalItems() int {
+	return p.TotalItems
+}
+
+func (p *PortfolioPage) GetExpectedTotal() int {
+	return p.ExpectedTotal
+}
+
+func (p *PortfolioPage) GetState() types.StoreState {
+	return p.State
+}
+
+func (c *PortfolioCollection) GetPage(
+	payload *types.Payload,
+	first, pageSize int,
+	sortSpec sdk.SortSpec,
+	filter string,
+) (types.Page, error) {
+	filter = strings.ToLower(filter)
+	dataFacet := payload.DataFacet
+	page := &PortfolioPage{
+		Facet: dataFacet,
+	}
+	_ = preprocessPage(c, page, payload, first, pageSize, sortSpec)
+
+	if c.shouldSummarize(payload) {
+		return c.getSummaryPage(payload, first, pageSize, sortSpec, filter)
+	}
+
+	switch dataFacet {
+
+	case PortfolioAssets:
+		facet := c.assetsFacet
+		var filterFunc func(*Holding) bool
+		if filter != "" {
+			var err error
+			if filterFunc, err = query.NewFilter(filter, query.FacetFields(c, dataFacet), c.matchesHoldingFilter); err != nil {
+				return nil, types.NewValidationError("portfolio", dataFacet, "GetPage", err)
+			}
+		}
+		sortFunc := func(items []Holding, sort sdk.SortSpec) error {
+			return sorting.SortBy(items, sort)
+		}
+		if result, err := facet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
+			return nil, types.NewStoreError("portfolio", dataFacet, "GetPage", err)
+		} else {
+			page.Assets = result.Items
+			page.TotalItems = result.TotalItems
+			page.State = result.State
+		}
+		page.ExpectedTotal = facet.ExpectedCount()
+	case PortfolioBalances:
+		facet := c.balancesFacet
+		var filterFunc func(*Position) bool
+		if filter != "" {
+			var err error
+			if filterFunc, err = query.NewFilter(filter, query.FacetFields(c, dataFacet), c.matchesPositionFilter); err != nil {
+				return nil, types.NewValidationError("portfolio", dataFacet, "GetPage", err)
+			}
+		}
+		sortFunc := func(items []Position, sort sdk.SortSpec) error {
+			return sorting.SortBy(items, sort)
+		}
+		if result, err := facet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
+			return nil, types.NewStoreError("portfolio", dataFacet, "GetPage", err)
+		} else {
+			page.Balances = result.Items
+			page.TotalItems = result.TotalItems
+			page.State = result.State
+		}
+		page.ExpectedTotal = facet.ExpectedCount()
+	case PortfolioStatements:
+		facet := c.statementsFacet
+		var filterFunc func(*Movement) bool
+		if filter != "" {
+			var err error
+			if filterFunc, err = query.NewFilter(filter, query.FacetFields(c, dataFacet), c.matchesMovementFilter); err != nil {
+				return nil, types.NewValidationError("portfolio", dataFacet, "GetPage", err)
+			}
+		}
+		sortFunc := func(items []Movement, sort sdk.SortSpec) error {
+			return sorting.SortBy(items, sort)
+		}
+		if result, err := facet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
+			return nil, types.NewStoreError("portfolio", dataFacet, "GetPage", err)
+		} else {
+			page.Statements = result.Items
+			page.TotalItems = result.TotalItems
+			page.State = result.State
+		}
+		page.ExpectedTotal = facet.ExpectedCount()
+	default:
+		return nil, types.NewValidationError("portfolio", payload.DataFacet, "GetPage",
+			fmt.Errorf("[GetPage] unsupported dataFacet: %v", payload.DataFacet))
+	}
+
+	return page, nil
+}
+
+// shouldSummarize returns true if the current facet can be simmarized by period
+func (c *PortfolioCollection) shouldSummarize(payload *types.Payload) bool {
+	if !payload.ShouldSummarize() {
+		return false
+	}
+	// EXISTING_CODE
+	// EXISTING_CODE
+	return false
+}
+
+// getSummaryPage returns paginated summary data for a given period
+func (c *PortfolioCollection) getSummaryPage(
+	payload *types.Payload,
+	first, pageSize int,
+	sortSpec sdk.SortSpec,
+	filter string,
+) (types.Page, error) {
+	// TODO: Use these
+	dataFacet := payload.DataFacet
+	period := payload.ActivePeriod
+	_ = first
+	_ = pageSize
+	_ = sortSpec
+	_ = filter
+	// CRITICAL: Ensure underlying raw data is loaded before generating summaries
+	// For summary periods, we need the blockly (raw) data to be loaded first
+	c.FetchByFacet(payload)
+	if err := c.generateSummariesForPeriod(dataFacet, period); err != nil {
+		return nil, types.NewStoreError("exports", dataFacet, "getSummaryPage", err)
+	}
+
+	page := &PortfolioPage{
+		Facet: dataFacet,
+	}
+
+	switch dataFacet {
+	// EXISTING_CODE
+	// EXISTING_CODE
+	default:
+		return nil, types.NewValidationError("portfolio", dataFacet, "getSummaryPage",
+			fmt.Errorf("[getSummaryPage] unsupported dataFacet: %v %v", dataFacet, page.Facet))
+	}
+}
+
+// generateSummariesForPeriod ensures summaries are generated for the given period
+func (c *PortfolioCollection) generateSummariesForPeriod(dataFacet types.DataFacet, period types.Period) error {
+	// TODO: Use this
+	_ = period
+	switch dataFacet {
+	// EXISTING_CODE
+	// EXISTING_CODE
+	default:
+		return fmt.Errorf("[generateSummariesForPeriod] unsupported dataFacet for summary: %v", dataFacet)
+	}
+}
+
+func preprocessPage(
+	c *PortfolioCollection,
+	page *PortfolioPage,
+	payload *types.Payload,
+	first, pageSize int,
+	sortSpec sdk.SortSpec,
+) error {
+	_ = page
+	_ = c
+	_ = payload
+	_ = first
+	_ = pageSize
+	_ = sortSpec
+	// EXISTING_CODE
+	// EXISTING_CODE
+	return nil
+}
+
+// EXISTING_CODE
+func (c *PortfolioCollection) matchesHoldingFilter(item *Holding, filter string) bool {
+	return strings.Contains(strings.ToLower(item.Asset.Hex()), filter) ||
+		strings.Contains(strings.ToLower(item.AssetName), filter) ||
+		strings.Contains(strings.ToLower(item.Symbol), filter)
+}
+
+func (c *PortfolioCollection) matchesPositionFilter(item *Position, filter string) bool {
+	return c.matchesHoldingFilter(item, filter)
+}
+
+func (c *PortfolioCollection) matchesMovementFilter(item *Movement, filter string) bool {
+	return strings.Contains(strings.ToLower(item.AccountedFor.Hex()), filter) ||
+		strings.Contains(strings.ToLower(item.Asset.Hex()), filter) ||
+		strings.Contains(strings.ToLower(item.Symbol), filter) ||
+		(item.Internal && strings.Contains("internal", filter))
+}
+
+// EXISTING_CODE
@@ -0,0 +1,252 @@
+// Copyright 2016, 2026 The Authors. All rights reserved.
+// Use of this source code is governed by a license that can
+// be found in the LICENSE file.
+/*
+ * Parts of this file were auto generated. Edit only those parts of
+ * the code inside of 'EXISTING_CODE' tags.
+ */
+
+package portfolio
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	// EXISTING_CODE
+	// EXISTING_CODE
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/facets"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/logging"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
+)
+
+const (
+	PortfolioAssets     types.DataFacet = "assets"
+	PortfolioBalances   types.DataFacet = "balances"
+	PortfolioStatements types.DataFacet = "statements"
+)
+
+func init() {
+	types.RegisterDataFacet(PortfolioAssets)
+	types.RegisterDataFacet(PortfolioBalances)
+	types.RegisterDataFacet(PortfolioStatements)
+}
+
+type PortfolioCollection struct {
+	assetsFacet     *facets.Facet[Holding]
+	balancesFacet   *facets.Facet[Position]
+	statementsFacet *facets.Facet[Movement]
+	summary         types.Summary
+	summaryMutex    sync.RWMutex
+}
+
+func NewPortfolioCollection(payload *types.Payload) *PortfolioCollection {
+	c := &PortfolioCollection{}
+	c.ResetSummary()
+	c.initializeFacets(payload)
+	return c
+}
+
+func (c *PortfolioCollection) initializeFacets(payload *types.Payload) {
+	c.assetsFacet = facets.NewFacet(
+		PortfolioAssets,
+		isAsset,
+		isDupHolding(),
+		c.getHoldingsStore(payload, PortfolioAssets),
+		"portfolio",
+		c,
+		false,
+	)
+
+	c.balancesFacet = facets.NewFacet(
+		PortfolioBalances,
+		isBalance,
+		isDupPosition(),
+		c.getPositionsStore(payload, PortfolioBalances),
+		"portfolio",
+		c,
+		false,
+	)
+
+	c.statementsFacet = facets.NewFacet(
+		PortfolioStatements,
+		isStatement,
+		isDupMovement(),
+		c.getMovementsStore(payload, PortfolioStatements),
+		"portfolio",
+		c,
+		false,
+	)
+}
+
+func isAsset(item *Holding) bool {
+	// EXISTING_CODE
+	return true
+	// EXISTING_CODE
+}
+
+func isBalance(item *Position) bool {
+	// EXISTING_CODE
+	return true
+	// EXISTING_CODE
+}
+
+func isStatement(item *Movement) bool {
+	// EXISTING_CODE
+	return true
+	// EXISTING_CODE
+}
+
+func isDupHolding() func(existing []*Holding, newItem *Holding) bool {
+	// EXISTING_CODE
+	return nil
+	// EXISTING_CODE
+}
+
+func isDupMovement() func(existing []*Movement, newItem *Movement) bool {
+	// EXISTING_CODE
+	return nil
+	// EXISTING_CODE
+}
+
+func isDupPosition() func(existing []*Position, newItem *Position) bool {
+	// EXISTING_CODE
+	return nil
+	// EXISTING_CODE
+}
+
+func (c *PortfolioCollection) FetchByFacet(payload *types.Payload) {
+	dataFacet := payload.DataFacet
+	if !c.NeedsUpdate(payload) {
+		return
+	}
+
+	go func() {
+		switch dataFacet {
+		case PortfolioAssets:
+			if err := c.assetsFacet.FetchFacet(); err != nil {
+				logging.LogError(fmt.Sprintf("LoadData.%s from store: %%v", dataFacet), err, facets.ErrAlreadyLoading)
+			}
+		case PortfolioBalances:
+			if err := c.balancesFacet.FetchFacet(); err != nil {
+				logging.LogError(fmt.Sprintf("LoadData.%s from store: %%v", dataFacet), err, facets.ErrAlreadyLoading)
+			}
+		case PortfolioStatements:
+			if err := c.statementsFacet.FetchFacet(); err != nil {
+				logging.LogError(fmt.Sprintf("LoadData.%s from store: %%v", dataFacet), err, facets.ErrAlreadyLoading)
+			}
+		default:
+			logging.LogError("LoadData: unexpected dataFacet: %v", fmt.Errorf("invalid dataFacet: %s", dataFacet), nil)
+			return
+		}
+	}()
+}
+
+func (c *PortfolioCollection) Reset(payload *types.Payload) {
+	switch payload.DataFacet {
+	case PortfolioAssets:
+		c.assetsFacet.Reset()
+	case PortfolioBalances:
+		c.balancesFacet.Reset()
+	case PortfolioStatements:
+		c.statementsFacet.Reset()
+	default:
+		return
+	}
+}
+
+func (c *PortfolioCollection) NeedsUpdate(payload *types.Payload) bool {
+	switch payload.DataFacet {
+	case PortfolioAssets:
+		return c.assetsFacet.NeedsUpdate()
+	case PortfolioBalances:
+		return c.balancesFacet.NeedsUpdate()
+	case PortfolioStatements:
+		return c.statementsFacet.NeedsUpdate()
+	default:
+		return false
+	}
+}
+
+func (c *PortfolioCollection) AccumulateItem(item interface{}, summary *types.Summary) {
+	// EXISTING_CODE
+	c.summaryMutex.Lock()
+	defer c.summaryMutex.Unlock()
+
+	if summary.FacetCounts == nil {
+		summary.FacetCounts = make(map[types.DataFacet]int)
+	}
+	if summary.CustomData == nil {
+		summary.CustomData = make(map[string]interface{})
+	}
+
+	switch it := item.(type) {
+	case *Holding:
+		summary.TotalCount++
+		totalValue, _ := summary.CustomData["totalValue"].(float64)
+		summary.CustomData["totalValue"] = totalValue + it.Value
+
+	case *Movement:
+		summary.TotalCount++
+		summary.FacetCounts[PortfolioStatements]++
+		if it.Internal {
+			internalCount, _ := summary.CustomData["internalCount"].(int)
+			summary.CustomData["internalCount"] = internalCount + 1
+		}
+	}
+	// EXISTING_CODE
+}
+
+func (c *PortfolioCollection) GetSummary(payload *types.Payload) types.Summary {
+	_ = payload // delint
+	c.summaryMutex.RLock()
+	defer c.summaryMutex.RUnlock()
+
+	summary := c.summary
+	summary.FacetCounts = make(map[types.DataFacet]int)
+	for k, v := range c.summary.FacetCounts {
+		summary.FacetCounts[k] = v
+	}
+
+	if c.summary.CustomData != nil {
+		summary.CustomData = make(map[string]interface{})
+		for k, v := range c.summary.CustomData {
+			summary.CustomData[k] = v
+		}
+	}
+
+	return summary
+}
+
+func (c *PortfolioCollection) ResetSummary() {
+	c.summaryMutex.Lock()
+	defer c.summaryMutex.Unlock()
+	c.summary = types.Summary{
+		TotalCount:  0,
+		FacetCounts: make(map[types.DataFacet]int),
+		CustomData:  make(map[string]interface{}),
+		LastUpdated: time.Now().Unix(),
+	}
+}
+
+func (c *PortfolioCollection) ExportData(payload *types.Payload) (string, error) {
+	switch payload.DataFacet {
+	case PortfolioAssets:
+		return c.assetsFacet.ExportData(payload, string(PortfolioAssets))
+	case PortfolioBalances:
+		return c.balancesFacet.ExportData(payload, string(PortfolioBalances))
+	case PortfolioStatements:
+		return c.statementsFacet.ExportData(payload, string(PortfolioStatements))
+	default:
+		return "", fmt.Errorf("[ExportData] unsupported portfolio facet: %s", payload.DataFacet)
+	}
+}
+
+func (c *PortfolioCollection) ChangeVisibility(payload *types.Payload) error {
+	// EXISTING_CODE
+	// EXISTING_CODE
+	return nil
+}
+
+// EXISTING_CODE
+// EXISTING_CODE
@@ -0,0 +1,258 @@
+// Copyright 2016, 2026 The Authors. All rights reserved.
+// Use of this source code is governed by a license that can
+// be found in the LICENSE file.
+/*
+ * Parts of this file were auto generated. Edit only those parts of
+ * the code inside of 'EXISTING_CODE' tags.
+ */
+
+package portfolio
+
+// EXISTING_CODE
+import (
+	"fmt"
+	"sync"
+
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/logging"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/store"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/types/names"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/output"
+)
+
+// EXISTING_CODE
+
+var (
+	holdingsStore   = make(map[string]*store.Store[Holding])
+	holdingsStoreMu sync.Mutex
+
+	movementsStore   = make(map[string]*store.Store[Movement])
+	movementsStoreMu sync.Mutex
+
+	positionsStore   = make(map[string]*store.Store[Position])
+	positionsStoreMu sync.Mutex
+)
+
+func (c *PortfolioCollection) getHoldingsStore(payload *types.Payload, facet types.DataFacet) *store.Store[Holding] {
+	holdingsStoreMu.Lock()
+	defer holdingsStoreMu.Unlock()
+
+	// EXISTING_CODE
+	// EXISTING_CODE
+
+	storeKey := getStoreKey(payload)
+	theStore := holdingsStore[storeKey]
+	if theStore == nil {
+		queryFunc := func(ctx *output.RenderCtx) error {
+			// EXISTING_CODE
+			addrs := projectAddresses()
+			statements, err := collectStatements(payload.ActiveChain, addrs)
+			if err != nil {
+				wrappedErr := types.NewSDKError("portfolio", PortfolioAssets, "fetch", err)
+				logging.LogBEWarning(fmt.Sprintf("Portfolio assets query error: %v", wrappedErr))
+				return wrappedErr
+			}
+			go func() {
+				defer close(ctx.ModelChan)
+				defer close(ctx.ErrorChan)
+				for _, holding := range mergeHoldings(statements, addrs) {
+					ctx.ModelChan <- holding
+				}
+			}()
+			// EXISTING_CODE
+			return nil
+		}
+
+		processFunc := func(item interface{}) *Holding {
+			if it, ok := item.(*Holding); ok {
+				it.AssetName = names.NameAddress(it.Asset)
+				// EXISTING_CODE
+				// EXISTING_CODE
+				return it
+			}
+			return nil
+		}
+
+		mappingFunc := func(item *Holding) (key string, includeInMap bool) {
+			return "", false
+		}
+
+		storeName := c.getStoreName(payload, facet)
+		theStore = store.NewStore(storeName, queryFunc, processFunc, mappingFunc)
+
+		// EXISTING_CODE
+		// EXISTING_CODE
+
+		holdingsStore[storeKey] = theStore
+	}
+
+	return theStore
+}
+
+func (c *PortfolioCollection) getMovementsStore(payload *types.Payload, facet types.DataFacet) *store.Store[Movement] {
+	movementsStoreMu.Lock()
+	defer movementsStoreMu.Unlock()
+
+	// EXISTING_CODE
+	// EXISTING_CODE
+
+	storeKey := getStoreKey(payload)
+	theStore := movementsStore[storeKey]
+	if theStore == nil {
+		queryFunc := func(ctx *output.RenderCtx) error {
+			// EXISTING_CODE
+			addrs := projectAddresses()
+			statements, err := collectStatements(payload.ActiveChain, addrs)
+			if err != nil {
+				wrappedErr := types.NewSDKError("portfolio", PortfolioStatements, "fetch", err)
+				logging.LogBEWarning(fmt.Sprintf("Portfolio statements query error: %v", wrappedErr))
+				return wrappedErr
+			}
+			go func() {
+				defer close(ctx.ModelChan)
+				defer close(ctx.ErrorChan)
+				for _, movement := range mergeMovements(statements, addrs) {
+					ctx.ModelChan <- movement
+				}
+			}()
+			// EXISTING_CODE
+			return nil
+		}
+
+		processFunc := func(item interface{}) *Movement {
+			if it, ok := item.(*Movement); ok {
+				it.AssetName = names.NameAddress(it.Asset)
+				it.AccountedForName = names.NameAddress(it.AccountedFor)
+				it.SenderName = names.NameAddress(it.Sender)
+				it.RecipientName = names.NameAddress(it.Recipient)
+				// EXISTING_CODE
+				// EXISTING_CODE
+				return it
+			}
+			return nil
+		}
+
+		mappingFunc := func(item *Movement) (key string, includeInMap bool) {
+			return "", false
+		}
+
+		storeName := c.getStoreName(payload, facet)
+		theStore = store.NewStore(storeName, queryFunc, processFunc, mappingFunc)
+
+		// EXISTING_CODE
+		// EXISTING_CODE
+
+		movementsStore[storeKey] = theStore
+	}
+
+	return theStore
+}
+
+func (c *PortfolioCollection) getPositionsStore(payload *types.Payload, facet types.DataFacet) *store.Store[Position] {
+	positionsStoreMu.Lock()
+	defer positionsStoreMu.Unlock()
+
+	// EXISTING_CODE
+	// EXISTING_CODE
+
+	storeKey := getStoreKey(payload)
+	theStore := positionsStore[storeKey]
+	if theStore == nil {
+		queryFunc := func(ctx *output.RenderCtx) error {
+			// EXISTING_CODE
+			addrs := projectAddresses()
+			balances, err := collectBalances(payload.ActiveChain, addrs)
+			if err != nil {
+				wrappedErr := types.NewSDKError("portfolio", PortfolioBalances, "fetch", err)
+				logging.LogBEWarning(fmt.Sprintf("Portfolio balances query error: %v", wrappedErr))
+				return wrappedErr
+			}
+			go func() {
+				defer close(ctx.ModelChan)
+				defer close(ctx.ErrorChan)
+				for _, position := range mergePositions(balances, addrs) {
+					ctx.ModelChan <- position
+				}
+			}()
+			// EXISTING_CODE
+			return nil
+		}
+
+		processFunc := func(item interface{}) *Position {
+			if it, ok := item.(*Position); ok {
+				it.AssetName = names.NameAddress(it.Asset)
+				// EXISTING_CODE
+				// EXISTING_CODE
+				return it
+			}
+			return nil
+		}
+
+		mappingFunc := func(item *Position) (key string, includeInMap bool) {
+			return "", false
+		}
+
+		storeName := c.getStoreName(payload, facet)
+		theStore = store.NewStore(storeName, queryFunc, processFunc, mappingFunc)
+
+		// EXISTING_CODE
+		// EXISTING_CODE
+
+		positionsStore[storeKey] = theStore
+	}
+
+	return theStore
+}
+
+func (c *PortfolioCollection) getStoreName(payload *types.Payload, facet types.DataFacet) string {
+	name := ""
+
+	// EXISTING_CODE
+	// The portfolio spans every project address, so its stores are named for the address
+	// set rather than for the active address
+	payload = &types.Payload{ActiveChain: payload.ActiveChain, ActiveAddress: addressesKey(projectAddresses())}
+	// EXISTING_CODE
+
+	switch facet {
+	case PortfolioAssets:
+		name = "portfolio-holdings"
+	case PortfolioBalances:
+		name = "portfolio-positions"
+	case PortfolioStatements:
+		name = "portfolio-movements"
+	default:
+		return ""
+	}
+	name = fmt.Sprintf("%s-%s-%s", name, payload.ActiveChain, payload.ActiveAddress)
+	return name
+}
+
+var (
+	collections   = make(map[string]*PortfolioCollection)
+	collectionsMu sync.Mutex
+)
+
+func GetPortfolioCollection(payload *types.Payload) *PortfolioCollection {
+	collectionsMu.Lock()
+	defer collectionsMu.Unlock()
+
+	pl := *payload
+	key := getStoreKey(&pl)
+	if collection, exists := collections[key]; exists {
+		return collection
+	}
+
+	collection := NewPortfolioCollection(payload)
+	collections[key] = collection
+	return collection
+}
+
+func getStoreKey(payload *types.Payload) string {
+	// EXISTING_CODE
+	return payload.ActiveChain + "_" + addressesKey(projectAddresses())
+	// EXISTING_CODE
+}
+
+// EXISTING_CODE
+// EXISTING_CODE
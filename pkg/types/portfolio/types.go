@@ -0,0 +1,74 @@
+package portfolio
+
+import (
+	"sort"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+)
+
+// Holding is one asset held across every address in a project. Breakdown carries the
+// per-address balance (in display units) keyed by lowercase address hex, with an entry
+// for every project address so exported columns line up from row to row.
+type Holding struct {
+	Asset      base.Address      `json:"asset"`
+	AssetName  string            `json:"assetName,omitempty"`
+	Symbol     string            `json:"symbol"`
+	Decimals   uint64            `json:"decimals"`
+	Balance    base.Wei          `json:"balance"`
+	BalanceEth string            `json:"balanceEth"`
+	SpotPrice  float64           `json:"spotPrice"`
+	Value      float64           `json:"value"`
+	Holders    uint64            `json:"holders"`
+	LastBlock  base.Blknum       `json:"lastBlock"`
+	Breakdown  map[string]string `json:"breakdown"`
+}
+
+// Position is a token balance combined across every address in a project. It shares
+// Holding's shape but is built from the exports Balances facet rather than statements.
+type Position = Holding
+
+func (h *Holding) Model(chain, format string, verbose bool, extraOpts map[string]any) sdk.Model {
+	data := map[string]any{
+		"asset":      h.Asset.Hex(),
+		"assetName":  h.AssetName,
+		"symbol":     h.Symbol,
+		"decimals":   h.Decimals,
+		"balance":    h.Balance.String(),
+		"balanceEth": h.BalanceEth,
+		"spotPrice":  h.SpotPrice,
+		"value":      h.Value,
+		"holders":    h.Holders,
+		"lastBlock":  h.LastBlock,
+	}
+	order := []string{
+		"asset", "assetName", "symbol", "decimals", "balance", "balanceEth",
+		"spotPrice", "value", "holders", "lastBlock",
+	}
+
+	keys := make([]string, 0, len(h.Breakdown))
+	for key := range h.Breakdown {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		data[key] = h.Breakdown[key]
+		order = append(order, key)
+	}
+
+	return sdk.Model{Data: data, Order: order}
+}
+
+// Movement is a statement from one of a project's addresses. Internal is true when the
+// statement records a transfer between two of the project's own addresses.
+type Movement struct {
+	sdk.Statement
+	Internal bool `json:"internal"`
+}
+
+func (m *Movement) Model(chain, format string, verbose bool, extraOpts map[string]any) sdk.Model {
+	model := m.Statement.Model(chain, format, verbose, extraOpts)
+	model.Data["internal"] = m.Internal
+	model.Order = append(model.Order, "internal")
+	return model
+}
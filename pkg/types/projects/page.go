@@ -13,7 +13,8 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/TrueBlocks/trueblocks-explorer/pkg/project"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/query"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/sorting"
 	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
 	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
 )
@@ -70,14 +71,15 @@ func (c *ProjectsCollection) GetPage(
 		facet := c.manageFacet
 		var filterFunc func(*Project) bool
 		if filter != "" {
-			filterFunc = func(item *Project) bool {
-				return c.matchesManageFilter(item, filter)
+			var err error
+			if filterFunc, err = query.NewFilter(filter, query.FacetFields(c, dataFacet), c.matchesManageFilter); err != nil {
+				return nil, types.NewValidationError("projects", dataFacet, "GetPage", err)
 			}
 		}
 		sortFunc := func(items []Project, sort sdk.SortSpec) error {
-			return project.SortProjects(items, sort)
+			return sorting.SortBy(items, sort)
 		}
-		if result, err := facet.GetPage(first, pageSize, filterFunc, sortSpec, sortFunc); err != nil {
+		if result, err := facet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
 			return nil, types.NewStoreError("projects", dataFacet, "GetPage", err)
 		} else {
 			page.Projects = result.Items
@@ -96,9 +98,9 @@ func (c *ProjectsCollection) GetPage(
 				}
 			}
 			sortFunc := func(items []AddressList, sort sdk.SortSpec) error {
-				return nil // project.SortAddressList(items, sort)
+				return sorting.SortBy(items, sort)
 			}
-			if result, err := projectFacet.GetPage(first, pageSize, filterFunc, sortSpec, sortFunc); err != nil {
+			if result, err := projectFacet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
 				return nil, types.NewStoreError("projects", payload.DataFacet, "GetPage", err)
 			} else {
 				page.AddressList = result.Items
@@ -6,6 +6,7 @@ const (
 	StateStale    StoreState = "stale"    // Needs refresh
 	StateFetching StoreState = "fetching" // Currently loading
 	StateLoaded   StoreState = "loaded"   // Complete data
+	StateCached   StoreState = "cached"   // Showing a snapshot while newer data loads
 )
 
 var AllStates = []struct {
@@ -15,4 +16,5 @@ var AllStates = []struct {
 	{StateStale, "STALE"},
 	{StateFetching, "FETCHING"},
 	{StateLoaded, "LOADED"},
+	{StateCached, "CACHED"},
 }
@@ -20,6 +20,8 @@ func (c *StatusCollection) GetBuckets(payload *types.Payload) (*types.Buckets, e
 		facet = c.cachesFacet
 	case StatusChains:
 		facet = c.chainsFacet
+	case StatusHealth:
+		facet = c.healthFacet
 	default:
 		return &types.Buckets{
 			Series:   make(map[string][]types.Bucket),
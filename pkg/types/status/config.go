@@ -57,6 +57,15 @@ func (c *StatusCollection) buildStaticFacets() map[string]types.FacetConfig {
 			Actions:       []string{},
 			HeaderActions: []string{"export"},
 		},
+		"health": {
+			Name:          "Health",
+			Store:         "health",
+			ViewType:      "table",
+			DividerBefore: false,
+			Fields:        getHealthFields(),
+			Actions:       []string{},
+			HeaderActions: []string{"export"},
+		},
 	}
 }
 
@@ -65,6 +74,7 @@ func (c *StatusCollection) buildFacetOrder() []string {
 		"status",
 		"caches",
 		"chains",
+		"health",
 	}
 }
 
@@ -103,6 +113,23 @@ func getChainsFields() []types.FieldConfig {
 	return ret
 }
 
+func getHealthFields() []types.FieldConfig {
+	ret := []types.FieldConfig{
+		{Section: "Provider", Key: "checkedAt", Type: "timestamp"},
+		{Section: "Provider", Key: "chain", Type: "string", NoTable: true},
+		{Section: "Provider", Key: "url", Type: "url"},
+		{Section: "Health", Key: "healthy", Type: "boolean"},
+		{Section: "Health", Key: "selected", Type: "boolean"},
+		{Section: "Health", Key: "latencyMs", Type: "int64"},
+		{Section: "Health", Key: "error", Type: "string"},
+		{Section: "Chain", Key: "chainId", Type: "uint64"},
+		{Section: "Chain", Key: "headBlock", Type: "blknum"},
+		{Section: "Chain", Key: "lag", Type: "uint64"},
+	}
+	types.NormalizeFields(&ret)
+	return ret
+}
+
 func getStatusFields() []types.FieldConfig {
 	ret := []types.FieldConfig{
 		{Section: "Paths", Key: "cachePath", Type: "path"},
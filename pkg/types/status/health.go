@@ -0,0 +1,40 @@
+package status
+
+import (
+	"sync"
+
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/rpcpool"
+)
+
+var (
+	healthSource   func(chain string) []rpcpool.Check
+	healthSourceMu sync.RWMutex
+)
+
+// SetHealthSource installs the function the health facet calls for the provider checks of
+// a chain. Without one, the facet is empty.
+func SetHealthSource(fn func(chain string) []rpcpool.Check) {
+	healthSourceMu.Lock()
+	defer healthSourceMu.Unlock()
+	healthSource = fn
+}
+
+func healthHistory(chain string) []Health {
+	healthSourceMu.RLock()
+	fn := healthSource
+	healthSourceMu.RUnlock()
+	if fn == nil {
+		return []Health{}
+	}
+	return fn(chain)
+}
+
+// MarkHealthStale marks the health store of chain stale, so an open health view shows the
+// latest checks
+func MarkHealthStale(chain, reason string) {
+	healthStoreMu.Lock()
+	defer healthStoreMu.Unlock()
+	if s := healthStore[chain]; s != nil {
+		s.MarkStale(reason)
+	}
+}
@@ -13,6 +13,8 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/query"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/sorting"
 	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
 	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
 )
@@ -23,6 +25,7 @@ type StatusPage struct {
 	Facet         types.DataFacet  `json:"facet"`
 	Caches        []Cache          `json:"caches"`
 	Chains        []Chain          `json:"chains"`
+	Health        []Health         `json:"health"`
 	Status        []Status         `json:"status"`
 	TotalItems    int              `json:"totalItems"`
 	ExpectedTotal int              `json:"expectedTotal"`
@@ -70,14 +73,15 @@ func (c *StatusCollection) GetPage(
 		facet := c.statusFacet
 		var filterFunc func(*Status) bool
 		if filter != "" {
-			filterFunc = func(item *Status) bool {
-				return c.matchesStatusFilter(item, filter)
+			var err error
+			if filterFunc, err = query.NewFilter(filter, query.FacetFields(c, dataFacet), c.matchesStatusFilter); err != nil {
+				return nil, types.NewValidationError("status", dataFacet, "GetPage", err)
 			}
 		}
 		sortFunc := func(items []Status, sort sdk.SortSpec) error {
-			return sdk.SortStatus(items, sort)
+			return sorting.SortBy(items, sort)
 		}
-		if result, err := facet.GetPage(first, pageSize, filterFunc, sortSpec, sortFunc); err != nil {
+		if result, err := facet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
 			return nil, types.NewStoreError("status", dataFacet, "GetPage", err)
 		} else {
 			page.Status = result.Items
@@ -89,14 +93,15 @@ func (c *StatusCollection) GetPage(
 		facet := c.cachesFacet
 		var filterFunc func(*Cache) bool
 		if filter != "" {
-			filterFunc = func(item *Cache) bool {
-				return c.matchesCacheFilter(item, filter)
+			var err error
+			if filterFunc, err = query.NewFilter(filter, query.FacetFields(c, dataFacet), c.matchesCacheFilter); err != nil {
+				return nil, types.NewValidationError("status", dataFacet, "GetPage", err)
 			}
 		}
 		sortFunc := func(items []Cache, sort sdk.SortSpec) error {
-			return sdk.SortCaches(items, sort)
+			return sorting.SortBy(items, sort)
 		}
-		if result, err := facet.GetPage(first, pageSize, filterFunc, sortSpec, sortFunc); err != nil {
+		if result, err := facet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
 			return nil, types.NewStoreError("status", dataFacet, "GetPage", err)
 		} else {
 			page.Caches = result.Items
@@ -108,14 +113,15 @@ func (c *StatusCollection) GetPage(
 		facet := c.chainsFacet
 		var filterFunc func(*Chain) bool
 		if filter != "" {
-			filterFunc = func(item *Chain) bool {
-				return c.matchesChainFilter(item, filter)
+			var err error
+			if filterFunc, err = query.NewFilter(filter, query.FacetFields(c, dataFacet), c.matchesChainFilter); err != nil {
+				return nil, types.NewValidationError("status", dataFacet, "GetPage", err)
 			}
 		}
 		sortFunc := func(items []Chain, sort sdk.SortSpec) error {
-			return sdk.SortChains(items, sort)
+			return sorting.SortBy(items, sort)
 		}
-		if result, err := facet.GetPage(first, pageSize, filterFunc, sortSpec, sortFunc); err != nil {
+		if result, err := facet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
 			return nil, types.NewStoreError("status", dataFacet, "GetPage", err)
 		} else {
 			page.Chains = result.Items
@@ -123,6 +129,26 @@ func (c *StatusCollection) GetPage(
 			page.State = result.State
 		}
 		page.ExpectedTotal = facet.ExpectedCount()
+	case StatusHealth:
+		facet := c.healthFacet
+		var filterFunc func(*Health) bool
+		if filter != "" {
+			var err error
+			if filterFunc, err = query.NewFilter(filter, query.FacetFields(c, dataFacet), c.matchesHealthFilter); err != nil {
+				return nil, types.NewValidationError("status", dataFacet, "GetPage", err)
+			}
+		}
+		sortFunc := func(items []Health, sort sdk.SortSpec) error {
+			return sorting.SortBy(items, sort)
+		}
+		if result, err := facet.GetPage(first, pageSize, filter, filterFunc, sortSpec, sortFunc); err != nil {
+			return nil, types.NewStoreError("status", dataFacet, "GetPage", err)
+		} else {
+			page.Health = result.Items
+			page.TotalItems = result.TotalItems
+			page.State = result.State
+		}
+		page.ExpectedTotal = facet.ExpectedCount()
 	default:
 		return nil, types.NewValidationError("status", payload.DataFacet, "GetPage",
 			fmt.Errorf("[GetPage] unsupported dataFacet: %v", payload.DataFacet))
@@ -218,6 +244,11 @@ func (c *StatusCollection) matchesChainFilter(item *Chain, filter string) bool {
 	return true
 }
 
+func (c *StatusCollection) matchesHealthFilter(item *Health, filter string) bool {
+	return strings.Contains(strings.ToLower(item.URL), filter) ||
+		strings.Contains(strings.ToLower(item.Error), filter)
+}
+
 func (c *StatusCollection) matchesStatusFilter(item *Status, filter string) bool {
 	_ = item
 	_ = filter
@@ -24,18 +24,21 @@ const (
 	StatusStatus types.DataFacet = "status"
 	StatusCaches types.DataFacet = "caches"
 	StatusChains types.DataFacet = "chains"
+	StatusHealth types.DataFacet = "health"
 )
 
 func init() {
 	types.RegisterDataFacet(StatusStatus)
 	types.RegisterDataFacet(StatusCaches)
 	types.RegisterDataFacet(StatusChains)
+	types.RegisterDataFacet(StatusHealth)
 }
 
 type StatusCollection struct {
 	statusFacet  *facets.Facet[Status]
 	cachesFacet  *facets.Facet[Cache]
 	chainsFacet  *facets.Facet[Chain]
+	healthFacet  *facets.Facet[Health]
 	summary      types.Summary
 	summaryMutex sync.RWMutex
 }
@@ -77,6 +80,16 @@ func (c *StatusCollection) initializeFacets(payload *types.Payload) {
 		c,
 		false,
 	)
+
+	c.healthFacet = facets.NewFacet(
+		StatusHealth,
+		isHealth,
+		isDupHealth(),
+		c.getHealthStore(payload, StatusHealth),
+		"status",
+		c,
+		false,
+	)
 }
 
 func isStatus(item *Status) bool {
@@ -97,6 +110,12 @@ func isChain(item *Chain) bool {
 	// EXISTING_CODE
 }
 
+func isHealth(item *Health) bool {
+	// EXISTING_CODE
+	return true
+	// EXISTING_CODE
+}
+
 func isDupCache() func(existing []*Cache, newItem *Cache) bool {
 	// EXISTING_CODE
 	return func(existing []*Cache, newItem *Cache) bool {
@@ -113,6 +132,12 @@ func isDupChain() func(existing []*Chain, newItem *Chain) bool {
 	// EXISTING_CODE
 }
 
+func isDupHealth() func(existing []*Health, newItem *Health) bool {
+	// EXISTING_CODE
+	return nil
+	// EXISTING_CODE
+}
+
 func isDupStatus() func(existing []*Status, newItem *Status) bool {
 	// EXISTING_CODE
 	return func(existing []*Status, newItem *Status) bool {
@@ -141,6 +166,10 @@ func (c *StatusCollection) FetchByFacet(payload *types.Payload) {
 			if err := c.chainsFacet.FetchFacet(); err != nil {
 				logging.LogError(fmt.Sprintf("LoadData.%s from store: %%v", dataFacet), err, facets.ErrAlreadyLoading)
 			}
+		case StatusHealth:
+			if err := c.healthFacet.FetchFacet(); err != nil {
+				logging.LogError(fmt.Sprintf("LoadData.%s from store: %%v", dataFacet), err, facets.ErrAlreadyLoading)
+			}
 		default:
 			logging.LogError("LoadData: unexpected dataFacet: %v", fmt.Errorf("invalid dataFacet: %s", dataFacet), nil)
 			return
@@ -156,6 +185,8 @@ func (c *StatusCollection) Reset(payload *types.Payload) {
 		c.cachesFacet.Reset()
 	case StatusChains:
 		c.chainsFacet.Reset()
+	case StatusHealth:
+		c.healthFacet.Reset()
 	default:
 		return
 	}
@@ -169,6 +200,8 @@ func (c *StatusCollection) NeedsUpdate(payload *types.Payload) bool {
 		return c.cachesFacet.NeedsUpdate()
 	case StatusChains:
 		return c.chainsFacet.NeedsUpdate()
+	case StatusHealth:
+		return c.healthFacet.NeedsUpdate()
 	default:
 		return false
 	}
@@ -206,6 +239,19 @@ func (c *StatusCollection) AccumulateItem(item interface{}, summary *types.Summa
 		chainsCount++
 
 		summary.CustomData["chainsCount"] = chainsCount
+
+	case *Health:
+		summary.TotalCount++
+		summary.FacetCounts[StatusHealth]++
+		if summary.CustomData == nil {
+			summary.CustomData = make(map[string]interface{})
+		}
+
+		if !item.(*Health).Healthy {
+			unhealthyCount, _ := summary.CustomData["unhealthyCount"].(int)
+			unhealthyCount++
+			summary.CustomData["unhealthyCount"] = unhealthyCount
+		}
 	}
 	// EXISTING_CODE
 }
@@ -250,6 +296,8 @@ func (c *StatusCollection) ExportData(payload *types.Payload) (string, error) {
 		return c.cachesFacet.ExportData(payload, string(StatusCaches))
 	case StatusChains:
 		return c.chainsFacet.ExportData(payload, string(StatusChains))
+	case StatusHealth:
+		return c.healthFacet.ExportData(payload, string(StatusHealth))
 	default:
 		return "", fmt.Errorf("[ExportData] unsupported status facet: %s", payload.DataFacet)
 	}
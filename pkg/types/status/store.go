@@ -14,6 +14,7 @@ import (
 	"sync"
 
 	"github.com/TrueBlocks/trueblocks-explorer/pkg/logging"
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/rpcpool"
 	"github.com/TrueBlocks/trueblocks-explorer/pkg/store"
 	"github.com/TrueBlocks/trueblocks-explorer/pkg/types"
 
@@ -24,6 +25,7 @@ import (
 type Cache = sdk.Cache
 type Chain = sdk.Chain
 type Status = sdk.Status
+type Health = rpcpool.Check
 
 // EXISTING_CODE
 
@@ -34,6 +36,9 @@ var (
 	chainsStore   = make(map[string]*store.Store[Chain])
 	chainsStoreMu sync.Mutex
 
+	healthStore   = make(map[string]*store.Store[Health])
+	healthStoreMu sync.Mutex
+
 	statusStore   = make(map[string]*store.Store[Status])
 	statusStoreMu sync.Mutex
 )
@@ -138,6 +143,59 @@ func (c *StatusCollection) getChainsStore(payload *types.Payload, facet types.Da
 	return theStore
 }
 
+func (c *StatusCollection) getHealthStore(payload *types.Payload, facet types.DataFacet) *store.Store[Health] {
+	healthStoreMu.Lock()
+	defer healthStoreMu.Unlock()
+
+	// EXISTING_CODE
+	// EXISTING_CODE
+
+	storeKey := getStoreKey(payload)
+	theStore := healthStore[storeKey]
+	if theStore == nil {
+		queryFunc := func(ctx *output.RenderCtx) error {
+			// EXISTING_CODE
+			checks := healthHistory(payload.ActiveChain)
+			go func() {
+				defer close(ctx.ModelChan)
+				defer close(ctx.ErrorChan)
+				for i := range checks {
+					select {
+					case ctx.ModelChan <- &checks[i]:
+					case <-ctx.Ctx.Done():
+						return
+					}
+				}
+			}()
+			// EXISTING_CODE
+			return nil
+		}
+
+		processFunc := func(item interface{}) *Health {
+			if it, ok := item.(*Health); ok {
+				// EXISTING_CODE
+				// EXISTING_CODE
+				return it
+			}
+			return nil
+		}
+
+		mappingFunc := func(item *Health) (key string, includeInMap bool) {
+			return "", false
+		}
+
+		storeName := c.getStoreName(payload, facet)
+		theStore = store.NewStore(storeName, queryFunc, processFunc, mappingFunc)
+
+		// EXISTING_CODE
+		// EXISTING_CODE
+
+		healthStore[storeKey] = theStore
+	}
+
+	return theStore
+}
+
 func (c *StatusCollection) getStatusStore(payload *types.Payload, facet types.DataFacet) *store.Store[Status] {
 	statusStoreMu.Lock()
 	defer statusStoreMu.Unlock()
@@ -201,6 +259,8 @@ func (c *StatusCollection) getStoreName(payload *types.Payload, facet types.Data
 		name = "status-caches"
 	case StatusChains:
 		name = "status-chains"
+	case StatusHealth:
+		name = "status-health"
 	default:
 		return ""
 	}
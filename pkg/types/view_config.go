@@ -44,6 +44,7 @@ type FieldConfig struct {
 	DetailOrder int    `json:"detailOrder"`
 	NoTable     bool   `json:"-"`
 	NoDetail    bool   `json:"-"`
+	NoFilter    bool   `json:"-"`
 	ColumnLabel string `json:"-"`
 	DetailLabel string `json:"-"`
 }
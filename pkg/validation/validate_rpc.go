@@ -1,8 +1,13 @@
 package validation
 
 import (
+	"fmt"
 	"net/url"
+	"strconv"
 	"strings"
+	"time"
+
+	"github.com/TrueBlocks/trueblocks-explorer/pkg/rpcpool"
 )
 
 func ValidRPC(input string) error {
@@ -24,3 +29,73 @@ func ValidRPC(input string) error {
 
 	return nil
 }
+
+// RpcCapabilities reports what an RPC endpoint says about itself: the chain it serves, its
+// client, and whether it answers the trace and historical state queries chifra needs.
+type RpcCapabilities struct {
+	URL             string `json:"url"`
+	Reachable       bool   `json:"reachable"`
+	ChainId         uint64 `json:"chainId"`
+	ExpectedChainId uint64 `json:"expectedChainId"`
+	ChainIdMatches  bool   `json:"chainIdMatches"`
+	ClientVersion   string `json:"clientVersion"`
+	HeadBlock       uint64 `json:"headBlock"`
+	Traces          bool   `json:"traces"`
+	Archive         bool   `json:"archive"`
+	LatencyMs       int64  `json:"latencyMs"`
+	Error           string `json:"error,omitempty"`
+}
+
+// CheckRPC validates input as ValidRPC does and then queries the endpoint. A chainId of
+// zero is not compared. Nothing it sends is reported to the RPC pool. The report is returned whenever the URL is well formed; the error
+// says why the endpoint should not be used for the chain, if it should not.
+func CheckRPC(input string, chainId uint64) (*RpcCapabilities, error) {
+	if err := ValidRPC(input); err != nil {
+		return nil, err
+	}
+
+	endpoint := strings.TrimSpace(input)
+	ret := &RpcCapabilities{URL: endpoint, ExpectedChainId: chainId}
+	fail := func(problem string) (*RpcCapabilities, error) {
+		err := ValidationError{"rpc", problem}
+		ret.Error = err.Error()
+		return ret, err
+	}
+
+	start := time.Now()
+	hex, err := rpcpool.ProbeCallString(endpoint, "eth_chainId")
+	ret.LatencyMs = time.Since(start).Milliseconds()
+	if err != nil {
+		return fail(fmt.Sprintf("not reachable: %v", err))
+	}
+	ret.Reachable = true
+	if ret.ChainId, err = parseQuantity(hex); err != nil {
+		return fail(fmt.Sprintf("bad chain id %q", hex))
+	}
+
+	ret.ClientVersion, _ = rpcpool.ProbeCallString(endpoint, "web3_clientVersion")
+	if head, err := rpcpool.ProbeCallString(endpoint, "eth_blockNumber"); err == nil {
+		ret.HeadBlock, _ = parseQuantity(head)
+	}
+	// Traces are asked for just below the head, which even a pruned tracing node keeps.
+	// Block one is old enough that only an archive node still has the state to answer a
+	// balance at it.
+	traceBlock := "latest"
+	if ret.HeadBlock > 0 {
+		traceBlock = fmt.Sprintf("0x%x", ret.HeadBlock-1)
+	}
+	_, err = rpcpool.ProbeCall(endpoint, "trace_block", traceBlock)
+	ret.Traces = err == nil
+	_, err = rpcpool.ProbeCallString(endpoint, "eth_getBalance", "0x0000000000000000000000000000000000000000", "0x1")
+	ret.Archive = err == nil
+
+	ret.ChainIdMatches = chainId == 0 || ret.ChainId == chainId
+	if !ret.ChainIdMatches {
+		return fail(fmt.Sprintf("reports chain id %d, expected %d", ret.ChainId, chainId))
+	}
+	return ret, nil
+}
+
+func parseQuantity(hex string) (uint64, error) {
+	return strconv.ParseUint(strings.TrimPrefix(hex, "0x"), 16, 64)
+}
@@ -0,0 +1,97 @@
+package validation
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rpcServer answers the methods in results and fails every other one
+func rpcServer(t *testing.T, results map[string]string) string {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string `json:"method"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if result, ok := results[req.Method]; ok {
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":` + result + `}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"the method ` + req.Method + ` does not exist"}}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+func TestCheckRPC(t *testing.T) {
+	archive := rpcServer(t, map[string]string{
+		"eth_chainId":        `"0x1"`,
+		"web3_clientVersion": `"erigon/2.60.0/linux-amd64/go1.22"`,
+		"eth_blockNumber":    `"0x1312d00"`,
+		"trace_block":        `[]`,
+		"eth_getBalance":     `"0x0"`,
+	})
+	caps, err := CheckRPC(archive, 1)
+	require.NoError(t, err)
+	assert.True(t, caps.Reachable)
+	assert.True(t, caps.ChainIdMatches)
+	assert.Equal(t, "erigon/2.60.0/linux-amd64/go1.22", caps.ClientVersion)
+	assert.Equal(t, uint64(20000000), caps.HeadBlock)
+	assert.True(t, caps.Traces)
+	assert.True(t, caps.Archive)
+
+	// A Sepolia endpoint in the mainnet slot is reported along with what it can do
+	sepolia := rpcServer(t, map[string]string{
+		"eth_chainId":     `"0xaa36a7"`,
+		"eth_blockNumber": `"0x10"`,
+	})
+	caps, err = CheckRPC(sepolia, 1)
+	assert.EqualError(t, err, "invalid rpc: reports chain id 11155111, expected 1")
+	require.NotNil(t, caps)
+	assert.False(t, caps.ChainIdMatches)
+	assert.False(t, caps.Traces)
+	assert.False(t, caps.Archive)
+	assert.Equal(t, err.Error(), caps.Error)
+
+	caps, err = CheckRPC(sepolia, 0)
+	assert.NoError(t, err, "a zero chain id is not compared")
+	assert.True(t, caps.ChainIdMatches)
+
+	_, err = CheckRPC("ftp://example.com", 1)
+	assert.EqualError(t, err, "invalid rpc: must begin with http or https")
+
+	caps, err = CheckRPC("http://127.0.0.1:1", 1)
+	assert.ErrorContains(t, err, "not reachable")
+	assert.False(t, caps.Reachable)
+}
+
+func TestCheckRPCPrunedTracingNode(t *testing.T) {
+	// The node traces recent blocks but has pruned everything before block 0x1000
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string   `json:"method"`
+			Params []string `json:"params"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		switch {
+		case req.Method == "eth_chainId":
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x1"}`))
+		case req.Method == "eth_blockNumber":
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x1312d00"}`))
+		case req.Method == "trace_block" && len(req.Params) == 1 && req.Params[0] == "0x1312cff":
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":[]}`))
+		default:
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"missing trie node"}}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	caps, err := CheckRPC(srv.URL, 1)
+	require.NoError(t, err)
+	assert.True(t, caps.Traces, "traces are probed just below the head")
+	assert.False(t, caps.Archive)
+}